@@ -1,8 +1,16 @@
 package orderscl
 
-import "context"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 type Client interface {
 	SetOrderComposition(ctx context.Context, in SetOrderCompositionIn) (err error)
 	CheckOrdersExistsByProductID(ctx context.Context, productID int64) (result bool, err error)
+	SetOrderReturnResult(ctx context.Context, in SetOrderReturnResultIn) (err error)
+	GetOrderWithSecretKey(ctx context.Context, orderID int64, secretKey uuid.UUID) (result *OrderWithSecretKey, err error)
+	GetProductCoPurchases(ctx context.Context, in GetProductCoPurchasesIn) (items []ProductCoPurchase, err error)
+	ExpireOrderStockReserve(ctx context.Context, orderID int64) (canRelease bool, err error)
 }
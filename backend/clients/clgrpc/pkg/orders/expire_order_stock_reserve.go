@@ -0,0 +1,23 @@
+package orderscl
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+)
+
+func (c *ClientImpl) ExpireOrderStockReserve(ctx context.Context, orderID int64) (bool, error) {
+
+	result, err := c.api.ExpireOrderStockReserve(ctx, &ordersv1.ExpireOrderStockReserveRequest{OrderId: orderID})
+
+	if err != nil {
+		if ok, lgErr := e.ErrConvertGRPCToLogic(err); ok {
+			return false, lgErr
+		}
+
+		return false, err
+	}
+
+	return result.GetCanRelease(), nil
+}
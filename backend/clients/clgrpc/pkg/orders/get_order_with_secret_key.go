@@ -0,0 +1,41 @@
+package orderscl
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+)
+
+func (c *ClientImpl) GetOrderWithSecretKey(ctx context.Context, orderID int64, secretKey uuid.UUID) (*OrderWithSecretKey, error) {
+
+	result, err := c.api.GetOrderWithSecretKey(ctx, &ordersv1.GetOrderWithSecretKeyRequest{
+		OrderId:   orderID,
+		SecretKey: secretKey.String(),
+	})
+
+	if err != nil {
+		if ok, lgErr := e.ErrConvertGRPCToLogic(err); ok {
+			return nil, lgErr
+		}
+
+		return nil, err
+	}
+
+	out := &OrderWithSecretKey{
+		OrderID:   result.GetOrderId(),
+		Status:    result.GetStatus(),
+		CanReview: result.GetCanReview(),
+		Products:  make([]OrderProductRef, 0, len(result.GetProducts())),
+	}
+
+	for _, product := range result.GetProducts() {
+		out.Products = append(out.Products, OrderProductRef{
+			ProductID: product.GetProductId(),
+			VariantID: product.GetVariantId(),
+		})
+	}
+
+	return out, nil
+}
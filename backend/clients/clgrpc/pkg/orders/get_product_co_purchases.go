@@ -0,0 +1,39 @@
+package orderscl
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (c *ClientImpl) GetProductCoPurchases(ctx context.Context, in GetProductCoPurchasesIn) ([]ProductCoPurchase, error) {
+
+	result, err := c.api.GetProductCoPurchases(ctx, &ordersv1.GetProductCoPurchasesRequest{
+		ProductIds:      in.ProductIDs,
+		CreatedFrom:     timestamppb.New(in.CreatedFrom),
+		MinOrders:       in.MinOrders,
+		LimitPerProduct: in.LimitPerProduct,
+	})
+
+	if err != nil {
+		if ok, lgErr := e.ErrConvertGRPCToLogic(err); ok {
+			return nil, lgErr
+		}
+
+		return nil, err
+	}
+
+	out := make([]ProductCoPurchase, 0, len(result.GetItems()))
+
+	for _, item := range result.GetItems() {
+		out = append(out, ProductCoPurchase{
+			ProductID:        item.GetProductId(),
+			RelatedProductID: item.GetRelatedProductId(),
+			OrdersCount:      item.GetOrdersCount(),
+		})
+	}
+
+	return out, nil
+}
@@ -1,13 +1,20 @@
 package orderscl
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
 type OrderCompositionItem struct {
-	ProductID int64
-	Quantity  int32
-	Price     decimal.Decimal
+	ProductID       int64
+	VariantID       int64
+	Quantity        int32
+	Price           decimal.Decimal
+	TaxRate         decimal.Decimal
+	Name            string
+	SKU             string
+	ImagePreviewURL string
 }
 
 type SetOrderCompositionIn struct {
@@ -15,4 +22,48 @@ type SetOrderCompositionIn struct {
 	IsOk          bool
 	OrderProducts *[]OrderCompositionItem
 	OrderStatus   *string
+	// Версия заказа, которую видел клиент
+	OrderVersion *int64
+	OrderDetails *OrderDetails
+}
+
+type OrderDetails struct {
+	ClientName      string
+	ClientSurname   string
+	ClientEmail     string
+	ClientPhone     string
+	DeliveryAddress string
+	DeliveryZone    string
+}
+
+type SetOrderReturnResultIn struct {
+	ReturnID int64
+	IsOk     bool
+}
+
+type OrderProductRef struct {
+	ProductID int64
+	VariantID int64
+}
+
+type OrderWithSecretKey struct {
+	OrderID int64
+	Status  string
+	// Покупатель может оставить отзывы на товары заказа
+	CanReview bool
+	Products  []OrderProductRef
+}
+
+type GetProductCoPurchasesIn struct {
+	ProductIDs []int64
+	// Учитываются заказы, созданные не раньше
+	CreatedFrom     time.Time
+	MinOrders       int32
+	LimitPerProduct int32
+}
+
+type ProductCoPurchase struct {
+	ProductID        int64
+	RelatedProductID int64
+	OrdersCount      int64
 }
@@ -24,14 +24,34 @@ func (c *ClientImpl) SetOrderComposition(ctx context.Context, in SetOrderComposi
 		req.OrderStatus = wrapperspb.String(*in.OrderStatus)
 	}
 
+	if in.OrderVersion != nil {
+		req.OrderVersion = wrapperspb.Int64(*in.OrderVersion)
+	}
+
+	if in.OrderDetails != nil {
+		req.OrderDetails = &ordersv1.OrderDetails{
+			ClientName:      in.OrderDetails.ClientName,
+			ClientSurname:   in.OrderDetails.ClientSurname,
+			ClientEmail:     in.OrderDetails.ClientEmail,
+			ClientPhone:     in.OrderDetails.ClientPhone,
+			DeliveryAddress: in.OrderDetails.DeliveryAddress,
+			DeliveryZone:    in.OrderDetails.DeliveryZone,
+		}
+	}
+
 	if in.OrderProducts != nil {
 		req.OptionalProducts = &ordersv1.SetOrderCompositionRequest_ItemsSet{
 			ItemsSet: &ordersv1.OrderProductList{
 				Items: lo.Map(*in.OrderProducts, func(item OrderCompositionItem, _ int) *ordersv1.OrderProduct {
 					return &ordersv1.OrderProduct{
-						ProductId: item.ProductID,
-						Quantity:  item.Quantity,
-						Price:     item.Price.String(),
+						ProductId:       item.ProductID,
+						VariantId:       item.VariantID,
+						Quantity:        item.Quantity,
+						Price:           item.Price.String(),
+						TaxRate:         item.TaxRate.String(),
+						Name:            item.Name,
+						Sku:             item.SKU,
+						ImagePreviewUrl: item.ImagePreviewURL,
 					}
 				}),
 			},
@@ -0,0 +1,26 @@
+package orderscl
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+)
+
+func (c *ClientImpl) SetOrderReturnResult(ctx context.Context, in SetOrderReturnResultIn) error {
+
+	_, err := c.api.SetOrderReturnResult(ctx, &ordersv1.SetOrderReturnResultRequest{
+		ReturnId: in.ReturnID,
+		IsOk:     in.IsOk,
+	})
+
+	if err != nil {
+		if ok, lgErr := e.ErrConvertGRPCToLogic(err); ok {
+			return lgErr
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package productscl
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	productsv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/products"
+)
+
+func (c *ClientImpl) ApplyOrderBlockByOrderID(ctx context.Context, orderID int64) error {
+
+	_, err := c.api.ApplyOrderBlockByOrderID(ctx, &productsv1.ApplyOrderBlockByOrderIDRequest{
+		OrderId: orderID,
+	})
+	if err != nil {
+		if ok, lgErr := e.ErrConvertGRPCToLogic(err); ok {
+			return lgErr
+		}
+		return err
+	}
+
+	return nil
+}
@@ -1,9 +1,15 @@
 package productscl
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Client interface {
 	GetProductsByIds(ctx context.Context, ids []int64) (items []*ProductListItem, err error)
 	GetOrderBlockedProductsByOrderID(ctx context.Context, orderID int64) (items []*OrderBlockedProduct, err error)
 	SetOrderBlockedProductsByOrderID(ctx context.Context, in SetOrderBlockedProductsByOrderIDIn) (err error)
+	SetReturnRestockByReturnID(ctx context.Context, in SetReturnRestockByReturnIDIn) (err error)
+	ExtendOrderBlockByOrderID(ctx context.Context, orderID int64, orderStatus string) (expiresAt *time.Time, err error)
+	ApplyOrderBlockByOrderID(ctx context.Context, orderID int64) (err error)
 }
@@ -0,0 +1,31 @@
+package productscl
+
+import (
+	"context"
+	"time"
+
+	"github.com/m11ano/e"
+	productsv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/products"
+)
+
+func (c *ClientImpl) ExtendOrderBlockByOrderID(ctx context.Context, orderID int64, orderStatus string) (*time.Time, error) {
+
+	result, err := c.api.ExtendOrderBlockByOrderID(ctx, &productsv1.ExtendOrderBlockByOrderIDRequest{
+		OrderId:     orderID,
+		OrderStatus: orderStatus,
+	})
+	if err != nil {
+		if ok, lgErr := e.ErrConvertGRPCToLogic(err); ok {
+			return nil, lgErr
+		}
+		return nil, err
+	}
+
+	if result.GetExpiresAt() == nil {
+		return nil, nil
+	}
+
+	expiresAt := result.GetExpiresAt().AsTime()
+
+	return &expiresAt, nil
+}
@@ -21,6 +21,7 @@ func (c *ClientImpl) GetOrderBlockedProductsByOrderID(ctx context.Context, order
 	for i, item := range items.GetItems() {
 		result[i] = &OrderBlockedProduct{
 			ProductID: item.GetProductId(),
+			VariantID: item.GetVariantId(),
 			Quantity:  item.GetQuantity(),
 		}
 	}
@@ -37,13 +37,32 @@ func (c *ClientImpl) GetProductsByIds(ctx context.Context, ids []int64) ([]*Prod
 			ID:                  item.GetId(),
 			IsPublished:         item.GetIsPublished(),
 			Name:                item.GetName(),
+			SKU:                 item.GetSku(),
 			FullDescription:     item.GetFullDescription(),
 			Price:               price,
 			StockAvailable:      item.GetStockAvailable(),
 			ImagePreviewFileURL: item.GetImagePreviewFileUrl(),
+			Variants:            make([]ProductVariant, len(item.GetVariants())),
 			CreatedAt:           item.GetCreatedAt().AsTime(),
 		}
 
+		for j, variant := range item.GetVariants() {
+			variantPrice, err := decimal.NewFromString(variant.GetPrice())
+			if err != nil {
+				c.logger.ErrorContext(ctx, "converting variant price", slog.Any("price", variant.GetPrice()), slog.Any("error", err))
+				return nil, e.ErrInternal.Wrap(err)
+			}
+
+			result[i].Variants[j] = ProductVariant{
+				ID:             variant.GetId(),
+				SKU:            variant.GetSku(),
+				Options:        variant.GetOptions(),
+				Price:          variantPrice,
+				StockAvailable: variant.GetStockAvailable(),
+				Sort:           variant.GetSort(),
+			}
+		}
+
 		if item.GetUpdatedAt() != nil {
 			result[i].UpdatedAt = lo.ToPtr(item.GetUpdatedAt().AsTime())
 		}
@@ -52,6 +71,16 @@ func (c *ClientImpl) GetProductsByIds(ctx context.Context, ids []int64) ([]*Prod
 			result[i].DeletedAt = lo.ToPtr(item.GetDeletedAt().AsTime())
 		}
 
+		if item.GetTaxRate() != nil {
+			taxRate, err := decimal.NewFromString(item.GetTaxRate().GetValue())
+			if err != nil {
+				c.logger.ErrorContext(ctx, "converting tax rate", slog.Any("tax_rate", item.GetTaxRate().GetValue()), slog.Any("error", err))
+				return nil, e.ErrInternal.Wrap(err)
+			}
+
+			result[i].TaxRate = &taxRate
+		}
+
 		if item.GetImagePreviewFileId() != nil {
 			uuid, err := uuid.Parse(item.GetImagePreviewFileId().GetValue())
 			if err != nil {
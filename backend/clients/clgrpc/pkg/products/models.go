@@ -11,24 +11,64 @@ type ProductListItem struct {
 	ID                 int64
 	IsPublished        bool
 	Name               string
+	SKU                string
 	FullDescription    string
 	Price              decimal.Decimal
+	TaxRate            *decimal.Decimal
 	StockAvailable     int32
 	ImagePreviewFileID *uuid.UUID
 
 	ImagePreviewFileURL string
 
+	Variants []ProductVariant
+
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 	DeletedAt *time.Time
 }
 
+type ProductVariant struct {
+	ID             int64
+	SKU            string
+	Options        map[string]string
+	Price          decimal.Decimal
+	StockAvailable int32
+	Sort           int32
+}
+
+// Вариант товара по ID, товар без вариантов продается сам по себе
+func (p *ProductListItem) FindVariant(id int64) (ProductVariant, bool) {
+	for _, variant := range p.Variants {
+		if variant.ID == id {
+			return variant, true
+		}
+	}
+
+	return ProductVariant{}, false
+}
+
 type OrderBlockedProduct struct {
 	ProductID int64
+	VariantID int64
 	Quantity  int32
 }
 
 type SetOrderBlockedProductsByOrderIDIn struct {
 	OrderID       int64
 	OrderProducts []OrderBlockedProduct
+	// Определяет срок брони, пустой - срок текущей брони сохраняется
+	OrderStatus string
+	// Зона доставки, по которой выбирается склад
+	DeliveryZone string
+}
+
+type ReturnRestockProduct struct {
+	ProductID int64
+	VariantID int64
+	Quantity  int32
+}
+
+type SetReturnRestockByReturnIDIn struct {
+	ReturnID int64
+	Products []ReturnRestockProduct
 }
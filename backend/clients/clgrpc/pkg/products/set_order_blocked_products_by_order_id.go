@@ -11,10 +11,13 @@ import (
 func (c *ClientImpl) SetOrderBlockedProductsByOrderID(ctx context.Context, in SetOrderBlockedProductsByOrderIDIn) error {
 
 	_, err := c.api.SetOrderBlockedProductsByOrderID(ctx, &productsv1.SetOrderBlockedProductsByOrderIDRequest{
-		OrderId: in.OrderID,
+		OrderId:      in.OrderID,
+		OrderStatus:  in.OrderStatus,
+		DeliveryZone: in.DeliveryZone,
 		Items: lo.Map(in.OrderProducts, func(item OrderBlockedProduct, _ int) *productsv1.OrderProduct {
 			return &productsv1.OrderProduct{
 				ProductId: item.ProductID,
+				VariantId: item.VariantID,
 				Quantity:  item.Quantity,
 			}
 		}),
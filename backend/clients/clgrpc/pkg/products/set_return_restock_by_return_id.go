@@ -0,0 +1,33 @@
+package productscl
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	productsv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/products"
+	"github.com/samber/lo"
+)
+
+func (c *ClientImpl) SetReturnRestockByReturnID(ctx context.Context, in SetReturnRestockByReturnIDIn) error {
+
+	_, err := c.api.SetReturnRestockByReturnID(ctx, &productsv1.SetReturnRestockByReturnIDRequest{
+		ReturnId: in.ReturnID,
+		Items: lo.Map(in.Products, func(item ReturnRestockProduct, _ int) *productsv1.OrderProduct {
+			return &productsv1.OrderProduct{
+				ProductId: item.ProductID,
+				VariantId: item.VariantID,
+				Quantity:  item.Quantity,
+			}
+		}),
+	})
+
+	if err != nil {
+		if ok, lgErr := e.ErrConvertGRPCToLogic(err); ok {
+			return lgErr
+		}
+
+		return err
+	}
+
+	return nil
+}
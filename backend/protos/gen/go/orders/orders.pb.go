@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
@@ -24,12 +25,17 @@ const (
 )
 
 type OrderProduct struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity        int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price           string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	TaxRate         string                 `protobuf:"bytes,4,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	Name            string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Sku             string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku,omitempty"`
+	ImagePreviewUrl string                 `protobuf:"bytes,7,opt,name=image_preview_url,json=imagePreviewUrl,proto3" json:"image_preview_url,omitempty"`
+	VariantId       int64                  `protobuf:"varint,8,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *OrderProduct) Reset() {
@@ -83,6 +89,41 @@ func (x *OrderProduct) GetPrice() string {
 	return ""
 }
 
+func (x *OrderProduct) GetTaxRate() string {
+	if x != nil {
+		return x.TaxRate
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetImagePreviewUrl() string {
+	if x != nil {
+		return x.ImagePreviewUrl
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
 type OrderProductList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*OrderProduct        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -137,8 +178,12 @@ type SetOrderCompositionRequest struct {
 	//	*SetOrderCompositionRequest_NoItems
 	OptionalProducts isSetOrderCompositionRequest_OptionalProducts `protobuf_oneof:"optional_products"`
 	OrderStatus      *wrapperspb.StringValue                       `protobuf:"bytes,5,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Версия заказа, которую видел клиент. Если задана, изменение применяется только к этой версии и увеличивает ее
+	OrderVersion *wrapperspb.Int64Value `protobuf:"bytes,6,opt,name=order_version,json=orderVersion,proto3" json:"order_version,omitempty"`
+	// Данные заказа, сохраняются вместе с составом
+	OrderDetails  *OrderDetails `protobuf:"bytes,7,opt,name=order_details,json=orderDetails,proto3" json:"order_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SetOrderCompositionRequest) Reset() {
@@ -217,6 +262,20 @@ func (x *SetOrderCompositionRequest) GetOrderStatus() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *SetOrderCompositionRequest) GetOrderVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.OrderVersion
+	}
+	return nil
+}
+
+func (x *SetOrderCompositionRequest) GetOrderDetails() *OrderDetails {
+	if x != nil {
+		return x.OrderDetails
+	}
+	return nil
+}
+
 type isSetOrderCompositionRequest_OptionalProducts interface {
 	isSetOrderCompositionRequest_OptionalProducts()
 }
@@ -233,6 +292,90 @@ func (*SetOrderCompositionRequest_ItemsSet) isSetOrderCompositionRequest_Optiona
 
 func (*SetOrderCompositionRequest_NoItems) isSetOrderCompositionRequest_OptionalProducts() {}
 
+type OrderDetails struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ClientName      string                 `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	ClientSurname   string                 `protobuf:"bytes,2,opt,name=client_surname,json=clientSurname,proto3" json:"client_surname,omitempty"`
+	ClientEmail     string                 `protobuf:"bytes,3,opt,name=client_email,json=clientEmail,proto3" json:"client_email,omitempty"`
+	ClientPhone     string                 `protobuf:"bytes,4,opt,name=client_phone,json=clientPhone,proto3" json:"client_phone,omitempty"`
+	DeliveryAddress string                 `protobuf:"bytes,5,opt,name=delivery_address,json=deliveryAddress,proto3" json:"delivery_address,omitempty"`
+	DeliveryZone    string                 `protobuf:"bytes,6,opt,name=delivery_zone,json=deliveryZone,proto3" json:"delivery_zone,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OrderDetails) Reset() {
+	*x = OrderDetails{}
+	mi := &file_orders_orders_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderDetails) ProtoMessage() {}
+
+func (x *OrderDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderDetails.ProtoReflect.Descriptor instead.
+func (*OrderDetails) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderDetails) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *OrderDetails) GetClientSurname() string {
+	if x != nil {
+		return x.ClientSurname
+	}
+	return ""
+}
+
+func (x *OrderDetails) GetClientEmail() string {
+	if x != nil {
+		return x.ClientEmail
+	}
+	return ""
+}
+
+func (x *OrderDetails) GetClientPhone() string {
+	if x != nil {
+		return x.ClientPhone
+	}
+	return ""
+}
+
+func (x *OrderDetails) GetDeliveryAddress() string {
+	if x != nil {
+		return x.DeliveryAddress
+	}
+	return ""
+}
+
+func (x *OrderDetails) GetDeliveryZone() string {
+	if x != nil {
+		return x.DeliveryZone
+	}
+	return ""
+}
+
 type SetOrderCompositionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -241,7 +384,7 @@ type SetOrderCompositionResponse struct {
 
 func (x *SetOrderCompositionResponse) Reset() {
 	*x = SetOrderCompositionResponse{}
-	mi := &file_orders_orders_proto_msgTypes[3]
+	mi := &file_orders_orders_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -253,7 +396,7 @@ func (x *SetOrderCompositionResponse) String() string {
 func (*SetOrderCompositionResponse) ProtoMessage() {}
 
 func (x *SetOrderCompositionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orders_orders_proto_msgTypes[3]
+	mi := &file_orders_orders_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +409,7 @@ func (x *SetOrderCompositionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetOrderCompositionResponse.ProtoReflect.Descriptor instead.
 func (*SetOrderCompositionResponse) Descriptor() ([]byte, []int) {
-	return file_orders_orders_proto_rawDescGZIP(), []int{3}
+	return file_orders_orders_proto_rawDescGZIP(), []int{4}
 }
 
 type CheckOrdersExistsByProductIDRequest struct {
@@ -278,7 +421,7 @@ type CheckOrdersExistsByProductIDRequest struct {
 
 func (x *CheckOrdersExistsByProductIDRequest) Reset() {
 	*x = CheckOrdersExistsByProductIDRequest{}
-	mi := &file_orders_orders_proto_msgTypes[4]
+	mi := &file_orders_orders_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -290,7 +433,7 @@ func (x *CheckOrdersExistsByProductIDRequest) String() string {
 func (*CheckOrdersExistsByProductIDRequest) ProtoMessage() {}
 
 func (x *CheckOrdersExistsByProductIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orders_orders_proto_msgTypes[4]
+	mi := &file_orders_orders_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +446,7 @@ func (x *CheckOrdersExistsByProductIDRequest) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use CheckOrdersExistsByProductIDRequest.ProtoReflect.Descriptor instead.
 func (*CheckOrdersExistsByProductIDRequest) Descriptor() ([]byte, []int) {
-	return file_orders_orders_proto_rawDescGZIP(), []int{4}
+	return file_orders_orders_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CheckOrdersExistsByProductIDRequest) GetProductId() int64 {
@@ -322,7 +465,7 @@ type CheckOrdersExistsByProductIDResponse struct {
 
 func (x *CheckOrdersExistsByProductIDResponse) Reset() {
 	*x = CheckOrdersExistsByProductIDResponse{}
-	mi := &file_orders_orders_proto_msgTypes[5]
+	mi := &file_orders_orders_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +477,7 @@ func (x *CheckOrdersExistsByProductIDResponse) String() string {
 func (*CheckOrdersExistsByProductIDResponse) ProtoMessage() {}
 
 func (x *CheckOrdersExistsByProductIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orders_orders_proto_msgTypes[5]
+	mi := &file_orders_orders_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +490,7 @@ func (x *CheckOrdersExistsByProductIDResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use CheckOrdersExistsByProductIDResponse.ProtoReflect.Descriptor instead.
 func (*CheckOrdersExistsByProductIDResponse) Descriptor() ([]byte, []int) {
-	return file_orders_orders_proto_rawDescGZIP(), []int{5}
+	return file_orders_orders_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CheckOrdersExistsByProductIDResponse) GetExists() bool {
@@ -357,34 +500,617 @@ func (x *CheckOrdersExistsByProductIDResponse) GetExists() bool {
 	return false
 }
 
+type SetOrderReturnResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	IsOk          bool                   `protobuf:"varint,2,opt,name=is_ok,json=isOk,proto3" json:"is_ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrderReturnResultRequest) Reset() {
+	*x = SetOrderReturnResultRequest{}
+	mi := &file_orders_orders_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrderReturnResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrderReturnResultRequest) ProtoMessage() {}
+
+func (x *SetOrderReturnResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrderReturnResultRequest.ProtoReflect.Descriptor instead.
+func (*SetOrderReturnResultRequest) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetOrderReturnResultRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+func (x *SetOrderReturnResultRequest) GetIsOk() bool {
+	if x != nil {
+		return x.IsOk
+	}
+	return false
+}
+
+type SetOrderReturnResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrderReturnResultResponse) Reset() {
+	*x = SetOrderReturnResultResponse{}
+	mi := &file_orders_orders_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrderReturnResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrderReturnResultResponse) ProtoMessage() {}
+
+func (x *SetOrderReturnResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrderReturnResultResponse.ProtoReflect.Descriptor instead.
+func (*SetOrderReturnResultResponse) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{8}
+}
+
+type GetOrderWithSecretKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SecretKey     string                 `protobuf:"bytes,2,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderWithSecretKeyRequest) Reset() {
+	*x = GetOrderWithSecretKeyRequest{}
+	mi := &file_orders_orders_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderWithSecretKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderWithSecretKeyRequest) ProtoMessage() {}
+
+func (x *GetOrderWithSecretKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderWithSecretKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderWithSecretKeyRequest) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderWithSecretKeyRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *GetOrderWithSecretKeyRequest) GetSecretKey() string {
+	if x != nil {
+		return x.SecretKey
+	}
+	return ""
+}
+
+type OrderProductRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantId     int64                  `protobuf:"varint,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderProductRef) Reset() {
+	*x = OrderProductRef{}
+	mi := &file_orders_orders_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderProductRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderProductRef) ProtoMessage() {}
+
+func (x *OrderProductRef) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderProductRef.ProtoReflect.Descriptor instead.
+func (*OrderProductRef) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderProductRef) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *OrderProductRef) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type GetOrderWithSecretKeyResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	OrderId  int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status   string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Products []*OrderProductRef     `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	// Покупатель может оставить отзывы на товары заказа
+	CanReview     bool `protobuf:"varint,4,opt,name=can_review,json=canReview,proto3" json:"can_review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderWithSecretKeyResponse) Reset() {
+	*x = GetOrderWithSecretKeyResponse{}
+	mi := &file_orders_orders_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderWithSecretKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderWithSecretKeyResponse) ProtoMessage() {}
+
+func (x *GetOrderWithSecretKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderWithSecretKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderWithSecretKeyResponse) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOrderWithSecretKeyResponse) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *GetOrderWithSecretKeyResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetOrderWithSecretKeyResponse) GetProducts() []*OrderProductRef {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetOrderWithSecretKeyResponse) GetCanReview() bool {
+	if x != nil {
+		return x.CanReview
+	}
+	return false
+}
+
+type GetProductCoPurchasesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds []int64                `protobuf:"varint,1,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	// Учитываются заказы, созданные не раньше этого момента
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	// Минимальное количество заказов, в которых товары купили вместе
+	MinOrders int32 `protobuf:"varint,3,opt,name=min_orders,json=minOrders,proto3" json:"min_orders,omitempty"`
+	// Максимальное количество сопутствующих товаров для каждого товара
+	LimitPerProduct int32 `protobuf:"varint,4,opt,name=limit_per_product,json=limitPerProduct,proto3" json:"limit_per_product,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetProductCoPurchasesRequest) Reset() {
+	*x = GetProductCoPurchasesRequest{}
+	mi := &file_orders_orders_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductCoPurchasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductCoPurchasesRequest) ProtoMessage() {}
+
+func (x *GetProductCoPurchasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductCoPurchasesRequest.ProtoReflect.Descriptor instead.
+func (*GetProductCoPurchasesRequest) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetProductCoPurchasesRequest) GetProductIds() []int64 {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *GetProductCoPurchasesRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *GetProductCoPurchasesRequest) GetMinOrders() int32 {
+	if x != nil {
+		return x.MinOrders
+	}
+	return 0
+}
+
+func (x *GetProductCoPurchasesRequest) GetLimitPerProduct() int32 {
+	if x != nil {
+		return x.LimitPerProduct
+	}
+	return 0
+}
+
+type ProductCoPurchase struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductId        int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	RelatedProductId int64                  `protobuf:"varint,2,opt,name=related_product_id,json=relatedProductId,proto3" json:"related_product_id,omitempty"`
+	OrdersCount      int64                  `protobuf:"varint,3,opt,name=orders_count,json=ordersCount,proto3" json:"orders_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProductCoPurchase) Reset() {
+	*x = ProductCoPurchase{}
+	mi := &file_orders_orders_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductCoPurchase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductCoPurchase) ProtoMessage() {}
+
+func (x *ProductCoPurchase) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductCoPurchase.ProtoReflect.Descriptor instead.
+func (*ProductCoPurchase) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProductCoPurchase) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductCoPurchase) GetRelatedProductId() int64 {
+	if x != nil {
+		return x.RelatedProductId
+	}
+	return 0
+}
+
+func (x *ProductCoPurchase) GetOrdersCount() int64 {
+	if x != nil {
+		return x.OrdersCount
+	}
+	return 0
+}
+
+type GetProductCoPurchasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ProductCoPurchase   `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductCoPurchasesResponse) Reset() {
+	*x = GetProductCoPurchasesResponse{}
+	mi := &file_orders_orders_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductCoPurchasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductCoPurchasesResponse) ProtoMessage() {}
+
+func (x *GetProductCoPurchasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductCoPurchasesResponse.ProtoReflect.Descriptor instead.
+func (*GetProductCoPurchasesResponse) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetProductCoPurchasesResponse) GetItems() []*ProductCoPurchase {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ExpireOrderStockReserveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireOrderStockReserveRequest) Reset() {
+	*x = ExpireOrderStockReserveRequest{}
+	mi := &file_orders_orders_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireOrderStockReserveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireOrderStockReserveRequest) ProtoMessage() {}
+
+func (x *ExpireOrderStockReserveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireOrderStockReserveRequest.ProtoReflect.Descriptor instead.
+func (*ExpireOrderStockReserveRequest) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExpireOrderStockReserveRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type ExpireOrderStockReserveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Бронь можно вернуть на склад: заказ отменен или уже не держит товары
+	CanRelease    bool `protobuf:"varint,1,opt,name=can_release,json=canRelease,proto3" json:"can_release,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireOrderStockReserveResponse) Reset() {
+	*x = ExpireOrderStockReserveResponse{}
+	mi := &file_orders_orders_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireOrderStockReserveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireOrderStockReserveResponse) ProtoMessage() {}
+
+func (x *ExpireOrderStockReserveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orders_orders_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireOrderStockReserveResponse.ProtoReflect.Descriptor instead.
+func (*ExpireOrderStockReserveResponse) Descriptor() ([]byte, []int) {
+	return file_orders_orders_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExpireOrderStockReserveResponse) GetCanRelease() bool {
+	if x != nil {
+		return x.CanRelease
+	}
+	return false
+}
+
 var File_orders_orders_proto protoreflect.FileDescriptor
 
 const file_orders_orders_proto_rawDesc = "" +
 	"\n" +
-	"\x13orders/orders.proto\x12\x06orders\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1bgoogle/protobuf/empty.proto\"_\n" +
+	"\x13orders/orders.proto\x12\x06orders\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xeb\x01\n" +
 	"\fOrderProduct\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\tR\x05price\">\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x19\n" +
+	"\btax_rate\x18\x04 \x01(\tR\ataxRate\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sku\x18\x06 \x01(\tR\x03sku\x12*\n" +
+	"\x11image_preview_url\x18\a \x01(\tR\x0fimagePreviewUrl\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\b \x01(\x03R\tvariantId\">\n" +
 	"\x10OrderProductList\x12*\n" +
-	"\x05items\x18\x01 \x03(\v2\x14.orders.OrderProductR\x05items\"\x90\x02\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.orders.OrderProductR\x05items\"\x8d\x03\n" +
 	"\x1aSetOrderCompositionRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x13\n" +
 	"\x05is_ok\x18\x02 \x01(\bR\x04isOk\x127\n" +
 	"\titems_set\x18\x03 \x01(\v2\x18.orders.OrderProductListH\x00R\bitemsSet\x123\n" +
 	"\bno_items\x18\x04 \x01(\v2\x16.google.protobuf.EmptyH\x00R\anoItems\x12?\n" +
-	"\forder_status\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\vorderStatusB\x13\n" +
-	"\x11optional_products\"\x1d\n" +
+	"\forder_status\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\vorderStatus\x12@\n" +
+	"\rorder_version\x18\x06 \x01(\v2\x1b.google.protobuf.Int64ValueR\forderVersion\x129\n" +
+	"\rorder_details\x18\a \x01(\v2\x14.orders.OrderDetailsR\forderDetailsB\x13\n" +
+	"\x11optional_products\"\xec\x01\n" +
+	"\fOrderDetails\x12\x1f\n" +
+	"\vclient_name\x18\x01 \x01(\tR\n" +
+	"clientName\x12%\n" +
+	"\x0eclient_surname\x18\x02 \x01(\tR\rclientSurname\x12!\n" +
+	"\fclient_email\x18\x03 \x01(\tR\vclientEmail\x12!\n" +
+	"\fclient_phone\x18\x04 \x01(\tR\vclientPhone\x12)\n" +
+	"\x10delivery_address\x18\x05 \x01(\tR\x0fdeliveryAddress\x12#\n" +
+	"\rdelivery_zone\x18\x06 \x01(\tR\fdeliveryZone\"\x1d\n" +
 	"\x1bSetOrderCompositionResponse\"D\n" +
 	"#CheckOrdersExistsByProductIDRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\">\n" +
 	"$CheckOrdersExistsByProductIDResponse\x12\x16\n" +
-	"\x06exists\x18\x01 \x01(\bR\x06exists2\xe3\x01\n" +
+	"\x06exists\x18\x01 \x01(\bR\x06exists\"O\n" +
+	"\x1bSetOrderReturnResultRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\x12\x13\n" +
+	"\x05is_ok\x18\x02 \x01(\bR\x04isOk\"\x1e\n" +
+	"\x1cSetOrderReturnResultResponse\"X\n" +
+	"\x1cGetOrderWithSecretKeyRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x02 \x01(\tR\tsecretKey\"O\n" +
+	"\x0fOrderProductRef\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\x03R\tvariantId\"\xa6\x01\n" +
+	"\x1dGetOrderWithSecretKeyResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x123\n" +
+	"\bproducts\x18\x03 \x03(\v2\x17.orders.OrderProductRefR\bproducts\x12\x1d\n" +
+	"\n" +
+	"can_review\x18\x04 \x01(\bR\tcanReview\"\xc9\x01\n" +
+	"\x1cGetProductCoPurchasesRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\x03R\n" +
+	"productIds\x12=\n" +
+	"\fcreated_from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"min_orders\x18\x03 \x01(\x05R\tminOrders\x12*\n" +
+	"\x11limit_per_product\x18\x04 \x01(\x05R\x0flimitPerProduct\"\x83\x01\n" +
+	"\x11ProductCoPurchase\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12,\n" +
+	"\x12related_product_id\x18\x02 \x01(\x03R\x10relatedProductId\x12!\n" +
+	"\forders_count\x18\x03 \x01(\x03R\vordersCount\"P\n" +
+	"\x1dGetProductCoPurchasesResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.orders.ProductCoPurchaseR\x05items\";\n" +
+	"\x1eExpireOrderStockReserveRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"B\n" +
+	"\x1fExpireOrderStockReserveResponse\x12\x1f\n" +
+	"\vcan_release\x18\x01 \x01(\bR\n" +
+	"canRelease2\xfe\x04\n" +
 	"\x06Orders\x12^\n" +
 	"\x13SetOrderComposition\x12\".orders.SetOrderCompositionRequest\x1a#.orders.SetOrderCompositionResponse\x12y\n" +
-	"\x1cCheckOrdersExistsByProductID\x12+.orders.CheckOrdersExistsByProductIDRequest\x1a,.orders.CheckOrdersExistsByProductIDResponseB.Z,m11ano.mipt_webdev_course.orders.v1;ordersv1b\x06proto3"
+	"\x1cCheckOrdersExistsByProductID\x12+.orders.CheckOrdersExistsByProductIDRequest\x1a,.orders.CheckOrdersExistsByProductIDResponse\x12a\n" +
+	"\x14SetOrderReturnResult\x12#.orders.SetOrderReturnResultRequest\x1a$.orders.SetOrderReturnResultResponse\x12d\n" +
+	"\x15GetOrderWithSecretKey\x12$.orders.GetOrderWithSecretKeyRequest\x1a%.orders.GetOrderWithSecretKeyResponse\x12d\n" +
+	"\x15GetProductCoPurchases\x12$.orders.GetProductCoPurchasesRequest\x1a%.orders.GetProductCoPurchasesResponse\x12j\n" +
+	"\x17ExpireOrderStockReserve\x12&.orders.ExpireOrderStockReserveRequest\x1a'.orders.ExpireOrderStockReserveResponseB.Z,m11ano.mipt_webdev_course.orders.v1;ordersv1b\x06proto3"
 
 var (
 	file_orders_orders_proto_rawDescOnce sync.Once
@@ -398,31 +1124,57 @@ func file_orders_orders_proto_rawDescGZIP() []byte {
 	return file_orders_orders_proto_rawDescData
 }
 
-var file_orders_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_orders_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_orders_orders_proto_goTypes = []any{
 	(*OrderProduct)(nil),                         // 0: orders.OrderProduct
 	(*OrderProductList)(nil),                     // 1: orders.OrderProductList
 	(*SetOrderCompositionRequest)(nil),           // 2: orders.SetOrderCompositionRequest
-	(*SetOrderCompositionResponse)(nil),          // 3: orders.SetOrderCompositionResponse
-	(*CheckOrdersExistsByProductIDRequest)(nil),  // 4: orders.CheckOrdersExistsByProductIDRequest
-	(*CheckOrdersExistsByProductIDResponse)(nil), // 5: orders.CheckOrdersExistsByProductIDResponse
-	(*emptypb.Empty)(nil),                        // 6: google.protobuf.Empty
-	(*wrapperspb.StringValue)(nil),               // 7: google.protobuf.StringValue
+	(*OrderDetails)(nil),                         // 3: orders.OrderDetails
+	(*SetOrderCompositionResponse)(nil),          // 4: orders.SetOrderCompositionResponse
+	(*CheckOrdersExistsByProductIDRequest)(nil),  // 5: orders.CheckOrdersExistsByProductIDRequest
+	(*CheckOrdersExistsByProductIDResponse)(nil), // 6: orders.CheckOrdersExistsByProductIDResponse
+	(*SetOrderReturnResultRequest)(nil),          // 7: orders.SetOrderReturnResultRequest
+	(*SetOrderReturnResultResponse)(nil),         // 8: orders.SetOrderReturnResultResponse
+	(*GetOrderWithSecretKeyRequest)(nil),         // 9: orders.GetOrderWithSecretKeyRequest
+	(*OrderProductRef)(nil),                      // 10: orders.OrderProductRef
+	(*GetOrderWithSecretKeyResponse)(nil),        // 11: orders.GetOrderWithSecretKeyResponse
+	(*GetProductCoPurchasesRequest)(nil),         // 12: orders.GetProductCoPurchasesRequest
+	(*ProductCoPurchase)(nil),                    // 13: orders.ProductCoPurchase
+	(*GetProductCoPurchasesResponse)(nil),        // 14: orders.GetProductCoPurchasesResponse
+	(*ExpireOrderStockReserveRequest)(nil),       // 15: orders.ExpireOrderStockReserveRequest
+	(*ExpireOrderStockReserveResponse)(nil),      // 16: orders.ExpireOrderStockReserveResponse
+	(*emptypb.Empty)(nil),                        // 17: google.protobuf.Empty
+	(*wrapperspb.StringValue)(nil),               // 18: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),                // 19: google.protobuf.Int64Value
+	(*timestamppb.Timestamp)(nil),                // 20: google.protobuf.Timestamp
 }
 var file_orders_orders_proto_depIdxs = []int32{
-	0, // 0: orders.OrderProductList.items:type_name -> orders.OrderProduct
-	1, // 1: orders.SetOrderCompositionRequest.items_set:type_name -> orders.OrderProductList
-	6, // 2: orders.SetOrderCompositionRequest.no_items:type_name -> google.protobuf.Empty
-	7, // 3: orders.SetOrderCompositionRequest.order_status:type_name -> google.protobuf.StringValue
-	2, // 4: orders.Orders.SetOrderComposition:input_type -> orders.SetOrderCompositionRequest
-	4, // 5: orders.Orders.CheckOrdersExistsByProductID:input_type -> orders.CheckOrdersExistsByProductIDRequest
-	3, // 6: orders.Orders.SetOrderComposition:output_type -> orders.SetOrderCompositionResponse
-	5, // 7: orders.Orders.CheckOrdersExistsByProductID:output_type -> orders.CheckOrdersExistsByProductIDResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: orders.OrderProductList.items:type_name -> orders.OrderProduct
+	1,  // 1: orders.SetOrderCompositionRequest.items_set:type_name -> orders.OrderProductList
+	17, // 2: orders.SetOrderCompositionRequest.no_items:type_name -> google.protobuf.Empty
+	18, // 3: orders.SetOrderCompositionRequest.order_status:type_name -> google.protobuf.StringValue
+	19, // 4: orders.SetOrderCompositionRequest.order_version:type_name -> google.protobuf.Int64Value
+	3,  // 5: orders.SetOrderCompositionRequest.order_details:type_name -> orders.OrderDetails
+	10, // 6: orders.GetOrderWithSecretKeyResponse.products:type_name -> orders.OrderProductRef
+	20, // 7: orders.GetProductCoPurchasesRequest.created_from:type_name -> google.protobuf.Timestamp
+	13, // 8: orders.GetProductCoPurchasesResponse.items:type_name -> orders.ProductCoPurchase
+	2,  // 9: orders.Orders.SetOrderComposition:input_type -> orders.SetOrderCompositionRequest
+	5,  // 10: orders.Orders.CheckOrdersExistsByProductID:input_type -> orders.CheckOrdersExistsByProductIDRequest
+	7,  // 11: orders.Orders.SetOrderReturnResult:input_type -> orders.SetOrderReturnResultRequest
+	9,  // 12: orders.Orders.GetOrderWithSecretKey:input_type -> orders.GetOrderWithSecretKeyRequest
+	12, // 13: orders.Orders.GetProductCoPurchases:input_type -> orders.GetProductCoPurchasesRequest
+	15, // 14: orders.Orders.ExpireOrderStockReserve:input_type -> orders.ExpireOrderStockReserveRequest
+	4,  // 15: orders.Orders.SetOrderComposition:output_type -> orders.SetOrderCompositionResponse
+	6,  // 16: orders.Orders.CheckOrdersExistsByProductID:output_type -> orders.CheckOrdersExistsByProductIDResponse
+	8,  // 17: orders.Orders.SetOrderReturnResult:output_type -> orders.SetOrderReturnResultResponse
+	11, // 18: orders.Orders.GetOrderWithSecretKey:output_type -> orders.GetOrderWithSecretKeyResponse
+	14, // 19: orders.Orders.GetProductCoPurchases:output_type -> orders.GetProductCoPurchasesResponse
+	16, // 20: orders.Orders.ExpireOrderStockReserve:output_type -> orders.ExpireOrderStockReserveResponse
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_orders_orders_proto_init() }
@@ -440,7 +1192,7 @@ func file_orders_orders_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orders_orders_proto_rawDesc), len(file_orders_orders_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Orders_SetOrderComposition_FullMethodName          = "/orders.Orders/SetOrderComposition"
 	Orders_CheckOrdersExistsByProductID_FullMethodName = "/orders.Orders/CheckOrdersExistsByProductID"
+	Orders_SetOrderReturnResult_FullMethodName         = "/orders.Orders/SetOrderReturnResult"
+	Orders_GetOrderWithSecretKey_FullMethodName        = "/orders.Orders/GetOrderWithSecretKey"
+	Orders_GetProductCoPurchases_FullMethodName        = "/orders.Orders/GetProductCoPurchases"
+	Orders_ExpireOrderStockReserve_FullMethodName      = "/orders.Orders/ExpireOrderStockReserve"
 )
 
 // OrdersClient is the client API for Orders service.
@@ -31,6 +35,10 @@ const (
 type OrdersClient interface {
 	SetOrderComposition(ctx context.Context, in *SetOrderCompositionRequest, opts ...grpc.CallOption) (*SetOrderCompositionResponse, error)
 	CheckOrdersExistsByProductID(ctx context.Context, in *CheckOrdersExistsByProductIDRequest, opts ...grpc.CallOption) (*CheckOrdersExistsByProductIDResponse, error)
+	SetOrderReturnResult(ctx context.Context, in *SetOrderReturnResultRequest, opts ...grpc.CallOption) (*SetOrderReturnResultResponse, error)
+	GetOrderWithSecretKey(ctx context.Context, in *GetOrderWithSecretKeyRequest, opts ...grpc.CallOption) (*GetOrderWithSecretKeyResponse, error)
+	GetProductCoPurchases(ctx context.Context, in *GetProductCoPurchasesRequest, opts ...grpc.CallOption) (*GetProductCoPurchasesResponse, error)
+	ExpireOrderStockReserve(ctx context.Context, in *ExpireOrderStockReserveRequest, opts ...grpc.CallOption) (*ExpireOrderStockReserveResponse, error)
 }
 
 type ordersClient struct {
@@ -61,6 +69,46 @@ func (c *ordersClient) CheckOrdersExistsByProductID(ctx context.Context, in *Che
 	return out, nil
 }
 
+func (c *ordersClient) SetOrderReturnResult(ctx context.Context, in *SetOrderReturnResultRequest, opts ...grpc.CallOption) (*SetOrderReturnResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOrderReturnResultResponse)
+	err := c.cc.Invoke(ctx, Orders_SetOrderReturnResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetOrderWithSecretKey(ctx context.Context, in *GetOrderWithSecretKeyRequest, opts ...grpc.CallOption) (*GetOrderWithSecretKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderWithSecretKeyResponse)
+	err := c.cc.Invoke(ctx, Orders_GetOrderWithSecretKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetProductCoPurchases(ctx context.Context, in *GetProductCoPurchasesRequest, opts ...grpc.CallOption) (*GetProductCoPurchasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductCoPurchasesResponse)
+	err := c.cc.Invoke(ctx, Orders_GetProductCoPurchases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) ExpireOrderStockReserve(ctx context.Context, in *ExpireOrderStockReserveRequest, opts ...grpc.CallOption) (*ExpireOrderStockReserveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpireOrderStockReserveResponse)
+	err := c.cc.Invoke(ctx, Orders_ExpireOrderStockReserve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrdersServer is the server API for Orders service.
 // All implementations must embed UnimplementedOrdersServer
 // for forward compatibility.
@@ -69,6 +117,10 @@ func (c *ordersClient) CheckOrdersExistsByProductID(ctx context.Context, in *Che
 type OrdersServer interface {
 	SetOrderComposition(context.Context, *SetOrderCompositionRequest) (*SetOrderCompositionResponse, error)
 	CheckOrdersExistsByProductID(context.Context, *CheckOrdersExistsByProductIDRequest) (*CheckOrdersExistsByProductIDResponse, error)
+	SetOrderReturnResult(context.Context, *SetOrderReturnResultRequest) (*SetOrderReturnResultResponse, error)
+	GetOrderWithSecretKey(context.Context, *GetOrderWithSecretKeyRequest) (*GetOrderWithSecretKeyResponse, error)
+	GetProductCoPurchases(context.Context, *GetProductCoPurchasesRequest) (*GetProductCoPurchasesResponse, error)
+	ExpireOrderStockReserve(context.Context, *ExpireOrderStockReserveRequest) (*ExpireOrderStockReserveResponse, error)
 	mustEmbedUnimplementedOrdersServer()
 }
 
@@ -85,6 +137,18 @@ func (UnimplementedOrdersServer) SetOrderComposition(context.Context, *SetOrderC
 func (UnimplementedOrdersServer) CheckOrdersExistsByProductID(context.Context, *CheckOrdersExistsByProductIDRequest) (*CheckOrdersExistsByProductIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckOrdersExistsByProductID not implemented")
 }
+func (UnimplementedOrdersServer) SetOrderReturnResult(context.Context, *SetOrderReturnResultRequest) (*SetOrderReturnResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOrderReturnResult not implemented")
+}
+func (UnimplementedOrdersServer) GetOrderWithSecretKey(context.Context, *GetOrderWithSecretKeyRequest) (*GetOrderWithSecretKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderWithSecretKey not implemented")
+}
+func (UnimplementedOrdersServer) GetProductCoPurchases(context.Context, *GetProductCoPurchasesRequest) (*GetProductCoPurchasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductCoPurchases not implemented")
+}
+func (UnimplementedOrdersServer) ExpireOrderStockReserve(context.Context, *ExpireOrderStockReserveRequest) (*ExpireOrderStockReserveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpireOrderStockReserve not implemented")
+}
 func (UnimplementedOrdersServer) mustEmbedUnimplementedOrdersServer() {}
 func (UnimplementedOrdersServer) testEmbeddedByValue()                {}
 
@@ -142,6 +206,78 @@ func _Orders_CheckOrdersExistsByProductID_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Orders_SetOrderReturnResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrderReturnResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).SetOrderReturnResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orders_SetOrderReturnResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).SetOrderReturnResult(ctx, req.(*SetOrderReturnResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetOrderWithSecretKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderWithSecretKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetOrderWithSecretKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orders_GetOrderWithSecretKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetOrderWithSecretKey(ctx, req.(*GetOrderWithSecretKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetProductCoPurchases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductCoPurchasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetProductCoPurchases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orders_GetProductCoPurchases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetProductCoPurchases(ctx, req.(*GetProductCoPurchasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_ExpireOrderStockReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpireOrderStockReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).ExpireOrderStockReserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orders_ExpireOrderStockReserve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).ExpireOrderStockReserve(ctx, req.(*ExpireOrderStockReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Orders_ServiceDesc is the grpc.ServiceDesc for Orders service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,6 +293,22 @@ var Orders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckOrdersExistsByProductID",
 			Handler:    _Orders_CheckOrdersExistsByProductID_Handler,
 		},
+		{
+			MethodName: "SetOrderReturnResult",
+			Handler:    _Orders_SetOrderReturnResult_Handler,
+		},
+		{
+			MethodName: "GetOrderWithSecretKey",
+			Handler:    _Orders_GetOrderWithSecretKey_Handler,
+		},
+		{
+			MethodName: "GetProductCoPurchases",
+			Handler:    _Orders_GetProductCoPurchases_Handler,
+		},
+		{
+			MethodName: "ExpireOrderStockReserve",
+			Handler:    _Orders_ExpireOrderStockReserve_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "orders/orders.proto",
@@ -36,6 +36,9 @@ type ProductListItem struct {
 	CreatedAt           *timestamppb.Timestamp  `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt           *timestamppb.Timestamp  `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	DeletedAt           *timestamppb.Timestamp  `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	TaxRate             *wrapperspb.StringValue `protobuf:"bytes,12,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	Sku                 string                  `protobuf:"bytes,13,opt,name=sku,proto3" json:"sku,omitempty"`
+	Variants            []*ProductVariant       `protobuf:"bytes,14,rep,name=variants,proto3" json:"variants,omitempty"`
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -147,17 +150,123 @@ func (x *ProductListItem) GetDeletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ProductListItem) GetTaxRate() *wrapperspb.StringValue {
+	if x != nil {
+		return x.TaxRate
+	}
+	return nil
+}
+
+func (x *ProductListItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductListItem) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type ProductVariant struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku            string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Options        map[string]string      `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Price          string                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	StockAvailable int32                  `protobuf:"varint,5,opt,name=stock_available,json=stockAvailable,proto3" json:"stock_available,omitempty"`
+	Sort           int32                  `protobuf:"varint,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProductVariant) Reset() {
+	*x = ProductVariant{}
+	mi := &file_products_products_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductVariant) ProtoMessage() {}
+
+func (x *ProductVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_products_products_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
+func (*ProductVariant) Descriptor() ([]byte, []int) {
+	return file_products_products_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProductVariant) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetOptions() map[string]string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetStockAvailable() int32 {
+	if x != nil {
+		return x.StockAvailable
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetSort() int32 {
+	if x != nil {
+		return x.Sort
+	}
+	return 0
+}
+
 type OrderBlockedProduct struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     int64                  `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OrderBlockedProduct) Reset() {
 	*x = OrderBlockedProduct{}
-	mi := &file_products_products_proto_msgTypes[1]
+	mi := &file_products_products_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -169,7 +278,7 @@ func (x *OrderBlockedProduct) String() string {
 func (*OrderBlockedProduct) ProtoMessage() {}
 
 func (x *OrderBlockedProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[1]
+	mi := &file_products_products_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -182,7 +291,7 @@ func (x *OrderBlockedProduct) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderBlockedProduct.ProtoReflect.Descriptor instead.
 func (*OrderBlockedProduct) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{1}
+	return file_products_products_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *OrderBlockedProduct) GetProductId() int64 {
@@ -199,6 +308,13 @@ func (x *OrderBlockedProduct) GetQuantity() int32 {
 	return 0
 }
 
+func (x *OrderBlockedProduct) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
 type GetProductsByIDsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
@@ -208,7 +324,7 @@ type GetProductsByIDsRequest struct {
 
 func (x *GetProductsByIDsRequest) Reset() {
 	*x = GetProductsByIDsRequest{}
-	mi := &file_products_products_proto_msgTypes[2]
+	mi := &file_products_products_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -220,7 +336,7 @@ func (x *GetProductsByIDsRequest) String() string {
 func (*GetProductsByIDsRequest) ProtoMessage() {}
 
 func (x *GetProductsByIDsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[2]
+	mi := &file_products_products_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -233,7 +349,7 @@ func (x *GetProductsByIDsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductsByIDsRequest.ProtoReflect.Descriptor instead.
 func (*GetProductsByIDsRequest) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{2}
+	return file_products_products_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetProductsByIDsRequest) GetIds() []int64 {
@@ -252,7 +368,7 @@ type GetProductsByIDsResponse struct {
 
 func (x *GetProductsByIDsResponse) Reset() {
 	*x = GetProductsByIDsResponse{}
-	mi := &file_products_products_proto_msgTypes[3]
+	mi := &file_products_products_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -264,7 +380,7 @@ func (x *GetProductsByIDsResponse) String() string {
 func (*GetProductsByIDsResponse) ProtoMessage() {}
 
 func (x *GetProductsByIDsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[3]
+	mi := &file_products_products_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -277,7 +393,7 @@ func (x *GetProductsByIDsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductsByIDsResponse.ProtoReflect.Descriptor instead.
 func (*GetProductsByIDsResponse) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{3}
+	return file_products_products_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetProductsByIDsResponse) GetItems() []*ProductListItem {
@@ -296,7 +412,7 @@ type GetOrderBlockedProductsByOrderIDRequest struct {
 
 func (x *GetOrderBlockedProductsByOrderIDRequest) Reset() {
 	*x = GetOrderBlockedProductsByOrderIDRequest{}
-	mi := &file_products_products_proto_msgTypes[4]
+	mi := &file_products_products_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -308,7 +424,7 @@ func (x *GetOrderBlockedProductsByOrderIDRequest) String() string {
 func (*GetOrderBlockedProductsByOrderIDRequest) ProtoMessage() {}
 
 func (x *GetOrderBlockedProductsByOrderIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[4]
+	mi := &file_products_products_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -321,7 +437,7 @@ func (x *GetOrderBlockedProductsByOrderIDRequest) ProtoReflect() protoreflect.Me
 
 // Deprecated: Use GetOrderBlockedProductsByOrderIDRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderBlockedProductsByOrderIDRequest) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{4}
+	return file_products_products_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetOrderBlockedProductsByOrderIDRequest) GetOrderId() int64 {
@@ -340,7 +456,7 @@ type GetOrderBlockedProductsByOrderIDResponse struct {
 
 func (x *GetOrderBlockedProductsByOrderIDResponse) Reset() {
 	*x = GetOrderBlockedProductsByOrderIDResponse{}
-	mi := &file_products_products_proto_msgTypes[5]
+	mi := &file_products_products_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -352,7 +468,7 @@ func (x *GetOrderBlockedProductsByOrderIDResponse) String() string {
 func (*GetOrderBlockedProductsByOrderIDResponse) ProtoMessage() {}
 
 func (x *GetOrderBlockedProductsByOrderIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[5]
+	mi := &file_products_products_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +481,7 @@ func (x *GetOrderBlockedProductsByOrderIDResponse) ProtoReflect() protoreflect.M
 
 // Deprecated: Use GetOrderBlockedProductsByOrderIDResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderBlockedProductsByOrderIDResponse) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{5}
+	return file_products_products_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetOrderBlockedProductsByOrderIDResponse) GetItems() []*OrderBlockedProduct {
@@ -379,13 +495,14 @@ type OrderProduct struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     int64                  `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OrderProduct) Reset() {
 	*x = OrderProduct{}
-	mi := &file_products_products_proto_msgTypes[6]
+	mi := &file_products_products_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -397,7 +514,7 @@ func (x *OrderProduct) String() string {
 func (*OrderProduct) ProtoMessage() {}
 
 func (x *OrderProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[6]
+	mi := &file_products_products_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +527,7 @@ func (x *OrderProduct) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderProduct.ProtoReflect.Descriptor instead.
 func (*OrderProduct) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{6}
+	return file_products_products_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *OrderProduct) GetProductId() int64 {
@@ -427,17 +544,28 @@ func (x *OrderProduct) GetQuantity() int32 {
 	return 0
 }
 
+func (x *OrderProduct) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
 type SetOrderBlockedProductsByOrderIDRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Items         []*OrderProduct        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items   []*OrderProduct        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Статус заказа определяет срок брони, пустой - срок текущей брони сохраняется
+	OrderStatus string `protobuf:"bytes,3,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
+	// Зона доставки заказа, используется при выборе склада, пустая - склады по приоритету
+	DeliveryZone  string `protobuf:"bytes,4,opt,name=delivery_zone,json=deliveryZone,proto3" json:"delivery_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SetOrderBlockedProductsByOrderIDRequest) Reset() {
 	*x = SetOrderBlockedProductsByOrderIDRequest{}
-	mi := &file_products_products_proto_msgTypes[7]
+	mi := &file_products_products_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +577,7 @@ func (x *SetOrderBlockedProductsByOrderIDRequest) String() string {
 func (*SetOrderBlockedProductsByOrderIDRequest) ProtoMessage() {}
 
 func (x *SetOrderBlockedProductsByOrderIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[7]
+	mi := &file_products_products_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +590,7 @@ func (x *SetOrderBlockedProductsByOrderIDRequest) ProtoReflect() protoreflect.Me
 
 // Deprecated: Use SetOrderBlockedProductsByOrderIDRequest.ProtoReflect.Descriptor instead.
 func (*SetOrderBlockedProductsByOrderIDRequest) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{7}
+	return file_products_products_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SetOrderBlockedProductsByOrderIDRequest) GetOrderId() int64 {
@@ -479,6 +607,20 @@ func (x *SetOrderBlockedProductsByOrderIDRequest) GetItems() []*OrderProduct {
 	return nil
 }
 
+func (x *SetOrderBlockedProductsByOrderIDRequest) GetOrderStatus() string {
+	if x != nil {
+		return x.OrderStatus
+	}
+	return ""
+}
+
+func (x *SetOrderBlockedProductsByOrderIDRequest) GetDeliveryZone() string {
+	if x != nil {
+		return x.DeliveryZone
+	}
+	return ""
+}
+
 type SetOrderBlockedProductsByOrderIDResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -487,7 +629,7 @@ type SetOrderBlockedProductsByOrderIDResponse struct {
 
 func (x *SetOrderBlockedProductsByOrderIDResponse) Reset() {
 	*x = SetOrderBlockedProductsByOrderIDResponse{}
-	mi := &file_products_products_proto_msgTypes[8]
+	mi := &file_products_products_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +641,7 @@ func (x *SetOrderBlockedProductsByOrderIDResponse) String() string {
 func (*SetOrderBlockedProductsByOrderIDResponse) ProtoMessage() {}
 
 func (x *SetOrderBlockedProductsByOrderIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_products_products_proto_msgTypes[8]
+	mi := &file_products_products_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,14 +654,279 @@ func (x *SetOrderBlockedProductsByOrderIDResponse) ProtoReflect() protoreflect.M
 
 // Deprecated: Use SetOrderBlockedProductsByOrderIDResponse.ProtoReflect.Descriptor instead.
 func (*SetOrderBlockedProductsByOrderIDResponse) Descriptor() ([]byte, []int) {
-	return file_products_products_proto_rawDescGZIP(), []int{8}
+	return file_products_products_proto_rawDescGZIP(), []int{9}
+}
+
+type SetReturnRestockByReturnIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	Items         []*OrderProduct        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReturnRestockByReturnIDRequest) Reset() {
+	*x = SetReturnRestockByReturnIDRequest{}
+	mi := &file_products_products_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReturnRestockByReturnIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReturnRestockByReturnIDRequest) ProtoMessage() {}
+
+func (x *SetReturnRestockByReturnIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_products_products_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReturnRestockByReturnIDRequest.ProtoReflect.Descriptor instead.
+func (*SetReturnRestockByReturnIDRequest) Descriptor() ([]byte, []int) {
+	return file_products_products_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetReturnRestockByReturnIDRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+func (x *SetReturnRestockByReturnIDRequest) GetItems() []*OrderProduct {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SetReturnRestockByReturnIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReturnRestockByReturnIDResponse) Reset() {
+	*x = SetReturnRestockByReturnIDResponse{}
+	mi := &file_products_products_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReturnRestockByReturnIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReturnRestockByReturnIDResponse) ProtoMessage() {}
+
+func (x *SetReturnRestockByReturnIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_products_products_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReturnRestockByReturnIDResponse.ProtoReflect.Descriptor instead.
+func (*SetReturnRestockByReturnIDResponse) Descriptor() ([]byte, []int) {
+	return file_products_products_proto_rawDescGZIP(), []int{11}
+}
+
+type ExtendOrderBlockByOrderIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderStatus   string                 `protobuf:"bytes,2,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendOrderBlockByOrderIDRequest) Reset() {
+	*x = ExtendOrderBlockByOrderIDRequest{}
+	mi := &file_products_products_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendOrderBlockByOrderIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendOrderBlockByOrderIDRequest) ProtoMessage() {}
+
+func (x *ExtendOrderBlockByOrderIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_products_products_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendOrderBlockByOrderIDRequest.ProtoReflect.Descriptor instead.
+func (*ExtendOrderBlockByOrderIDRequest) Descriptor() ([]byte, []int) {
+	return file_products_products_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExtendOrderBlockByOrderIDRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ExtendOrderBlockByOrderIDRequest) GetOrderStatus() string {
+	if x != nil {
+		return x.OrderStatus
+	}
+	return ""
+}
+
+type ExtendOrderBlockByOrderIDResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустое значение - бронь бессрочная
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendOrderBlockByOrderIDResponse) Reset() {
+	*x = ExtendOrderBlockByOrderIDResponse{}
+	mi := &file_products_products_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendOrderBlockByOrderIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendOrderBlockByOrderIDResponse) ProtoMessage() {}
+
+func (x *ExtendOrderBlockByOrderIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_products_products_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendOrderBlockByOrderIDResponse.ProtoReflect.Descriptor instead.
+func (*ExtendOrderBlockByOrderIDResponse) Descriptor() ([]byte, []int) {
+	return file_products_products_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExtendOrderBlockByOrderIDResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ApplyOrderBlockByOrderIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyOrderBlockByOrderIDRequest) Reset() {
+	*x = ApplyOrderBlockByOrderIDRequest{}
+	mi := &file_products_products_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyOrderBlockByOrderIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyOrderBlockByOrderIDRequest) ProtoMessage() {}
+
+func (x *ApplyOrderBlockByOrderIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_products_products_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyOrderBlockByOrderIDRequest.ProtoReflect.Descriptor instead.
+func (*ApplyOrderBlockByOrderIDRequest) Descriptor() ([]byte, []int) {
+	return file_products_products_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ApplyOrderBlockByOrderIDRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type ApplyOrderBlockByOrderIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyOrderBlockByOrderIDResponse) Reset() {
+	*x = ApplyOrderBlockByOrderIDResponse{}
+	mi := &file_products_products_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyOrderBlockByOrderIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyOrderBlockByOrderIDResponse) ProtoMessage() {}
+
+func (x *ApplyOrderBlockByOrderIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_products_products_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyOrderBlockByOrderIDResponse.ProtoReflect.Descriptor instead.
+func (*ApplyOrderBlockByOrderIDResponse) Descriptor() ([]byte, []int) {
+	return file_products_products_proto_rawDescGZIP(), []int{15}
 }
 
 var File_products_products_proto protoreflect.FileDescriptor
 
 const file_products_products_proto_rawDesc = "" +
 	"\n" +
-	"\x17products/products.proto\x12\bproducts\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x03\n" +
+	"\x17products/products.proto\x12\bproducts\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfa\x04\n" +
 	"\x0fProductListItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
 	"\fis_published\x18\x02 \x01(\bR\visPublished\x12\x12\n" +
@@ -535,11 +942,26 @@ const file_products_products_proto_rawDesc = "" +
 	"updated_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
 	"\n" +
-	"deleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"P\n" +
+	"deleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x127\n" +
+	"\btax_rate\x18\f \x01(\v2\x1c.google.protobuf.StringValueR\ataxRate\x12\x10\n" +
+	"\x03sku\x18\r \x01(\tR\x03sku\x124\n" +
+	"\bvariants\x18\x0e \x03(\v2\x18.products.ProductVariantR\bvariants\"\x82\x02\n" +
+	"\x0eProductVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12?\n" +
+	"\aoptions\x18\x03 \x03(\v2%.products.ProductVariant.OptionsEntryR\aoptions\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12'\n" +
+	"\x0fstock_available\x18\x05 \x01(\x05R\x0estockAvailable\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\x05R\x04sort\x1a:\n" +
+	"\fOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"o\n" +
 	"\x13OrderBlockedProduct\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"+\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x03R\tvariantId\"+\n" +
 	"\x17GetProductsByIDsRequest\x12\x10\n" +
 	"\x03ids\x18\x01 \x03(\x03R\x03ids\"K\n" +
 	"\x18GetProductsByIDsResponse\x12/\n" +
@@ -547,19 +969,39 @@ const file_products_products_proto_rawDesc = "" +
 	"'GetOrderBlockedProductsByOrderIDRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\"_\n" +
 	"(GetOrderBlockedProductsByOrderIDResponse\x123\n" +
-	"\x05items\x18\x01 \x03(\v2\x1d.products.OrderBlockedProductR\x05items\"I\n" +
+	"\x05items\x18\x01 \x03(\v2\x1d.products.OrderBlockedProductR\x05items\"h\n" +
 	"\fOrderProduct\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"r\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x03R\tvariantId\"\xba\x01\n" +
 	"'SetOrderBlockedProductsByOrderIDRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\x12,\n" +
-	"\x05items\x18\x02 \x03(\v2\x16.products.OrderProductR\x05items\"*\n" +
-	"(SetOrderBlockedProductsByOrderIDResponse2\xfd\x02\n" +
+	"\x05items\x18\x02 \x03(\v2\x16.products.OrderProductR\x05items\x12!\n" +
+	"\forder_status\x18\x03 \x01(\tR\vorderStatus\x12#\n" +
+	"\rdelivery_zone\x18\x04 \x01(\tR\fdeliveryZone\"*\n" +
+	"(SetOrderBlockedProductsByOrderIDResponse\"n\n" +
+	"!SetReturnRestockByReturnIDRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\x12,\n" +
+	"\x05items\x18\x02 \x03(\v2\x16.products.OrderProductR\x05items\"$\n" +
+	"\"SetReturnRestockByReturnIDResponse\"`\n" +
+	" ExtendOrderBlockByOrderIDRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12!\n" +
+	"\forder_status\x18\x02 \x01(\tR\vorderStatus\"^\n" +
+	"!ExtendOrderBlockByOrderIDResponse\x129\n" +
+	"\n" +
+	"expires_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"<\n" +
+	"\x1fApplyOrderBlockByOrderIDRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"\"\n" +
+	" ApplyOrderBlockByOrderIDResponse2\xdf\x05\n" +
 	"\bProducts\x12Y\n" +
 	"\x10GetProductsByIDs\x12!.products.GetProductsByIDsRequest\x1a\".products.GetProductsByIDsResponse\x12\x89\x01\n" +
 	" GetOrderBlockedProductsByOrderID\x121.products.GetOrderBlockedProductsByOrderIDRequest\x1a2.products.GetOrderBlockedProductsByOrderIDResponse\x12\x89\x01\n" +
-	" SetOrderBlockedProductsByOrderID\x121.products.SetOrderBlockedProductsByOrderIDRequest\x1a2.products.SetOrderBlockedProductsByOrderIDResponseB2Z0m11ano.mipt_webdev_course.products.v1;productsv1b\x06proto3"
+	" SetOrderBlockedProductsByOrderID\x121.products.SetOrderBlockedProductsByOrderIDRequest\x1a2.products.SetOrderBlockedProductsByOrderIDResponse\x12w\n" +
+	"\x1aSetReturnRestockByReturnID\x12+.products.SetReturnRestockByReturnIDRequest\x1a,.products.SetReturnRestockByReturnIDResponse\x12t\n" +
+	"\x19ExtendOrderBlockByOrderID\x12*.products.ExtendOrderBlockByOrderIDRequest\x1a+.products.ExtendOrderBlockByOrderIDResponse\x12q\n" +
+	"\x18ApplyOrderBlockByOrderID\x12).products.ApplyOrderBlockByOrderIDRequest\x1a*.products.ApplyOrderBlockByOrderIDResponseB2Z0m11ano.mipt_webdev_course.products.v1;productsv1b\x06proto3"
 
 var (
 	file_products_products_proto_rawDescOnce sync.Once
@@ -573,39 +1015,58 @@ func file_products_products_proto_rawDescGZIP() []byte {
 	return file_products_products_proto_rawDescData
 }
 
-var file_products_products_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_products_products_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_products_products_proto_goTypes = []any{
 	(*ProductListItem)(nil),                          // 0: products.ProductListItem
-	(*OrderBlockedProduct)(nil),                      // 1: products.OrderBlockedProduct
-	(*GetProductsByIDsRequest)(nil),                  // 2: products.GetProductsByIDsRequest
-	(*GetProductsByIDsResponse)(nil),                 // 3: products.GetProductsByIDsResponse
-	(*GetOrderBlockedProductsByOrderIDRequest)(nil),  // 4: products.GetOrderBlockedProductsByOrderIDRequest
-	(*GetOrderBlockedProductsByOrderIDResponse)(nil), // 5: products.GetOrderBlockedProductsByOrderIDResponse
-	(*OrderProduct)(nil),                             // 6: products.OrderProduct
-	(*SetOrderBlockedProductsByOrderIDRequest)(nil),  // 7: products.SetOrderBlockedProductsByOrderIDRequest
-	(*SetOrderBlockedProductsByOrderIDResponse)(nil), // 8: products.SetOrderBlockedProductsByOrderIDResponse
-	(*wrapperspb.StringValue)(nil),                   // 9: google.protobuf.StringValue
-	(*timestamppb.Timestamp)(nil),                    // 10: google.protobuf.Timestamp
+	(*ProductVariant)(nil),                           // 1: products.ProductVariant
+	(*OrderBlockedProduct)(nil),                      // 2: products.OrderBlockedProduct
+	(*GetProductsByIDsRequest)(nil),                  // 3: products.GetProductsByIDsRequest
+	(*GetProductsByIDsResponse)(nil),                 // 4: products.GetProductsByIDsResponse
+	(*GetOrderBlockedProductsByOrderIDRequest)(nil),  // 5: products.GetOrderBlockedProductsByOrderIDRequest
+	(*GetOrderBlockedProductsByOrderIDResponse)(nil), // 6: products.GetOrderBlockedProductsByOrderIDResponse
+	(*OrderProduct)(nil),                             // 7: products.OrderProduct
+	(*SetOrderBlockedProductsByOrderIDRequest)(nil),  // 8: products.SetOrderBlockedProductsByOrderIDRequest
+	(*SetOrderBlockedProductsByOrderIDResponse)(nil), // 9: products.SetOrderBlockedProductsByOrderIDResponse
+	(*SetReturnRestockByReturnIDRequest)(nil),        // 10: products.SetReturnRestockByReturnIDRequest
+	(*SetReturnRestockByReturnIDResponse)(nil),       // 11: products.SetReturnRestockByReturnIDResponse
+	(*ExtendOrderBlockByOrderIDRequest)(nil),         // 12: products.ExtendOrderBlockByOrderIDRequest
+	(*ExtendOrderBlockByOrderIDResponse)(nil),        // 13: products.ExtendOrderBlockByOrderIDResponse
+	(*ApplyOrderBlockByOrderIDRequest)(nil),          // 14: products.ApplyOrderBlockByOrderIDRequest
+	(*ApplyOrderBlockByOrderIDResponse)(nil),         // 15: products.ApplyOrderBlockByOrderIDResponse
+	nil,                                              // 16: products.ProductVariant.OptionsEntry
+	(*wrapperspb.StringValue)(nil),                   // 17: google.protobuf.StringValue
+	(*timestamppb.Timestamp)(nil),                    // 18: google.protobuf.Timestamp
 }
 var file_products_products_proto_depIdxs = []int32{
-	9,  // 0: products.ProductListItem.image_preview_file_id:type_name -> google.protobuf.StringValue
-	10, // 1: products.ProductListItem.created_at:type_name -> google.protobuf.Timestamp
-	10, // 2: products.ProductListItem.updated_at:type_name -> google.protobuf.Timestamp
-	10, // 3: products.ProductListItem.deleted_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: products.GetProductsByIDsResponse.items:type_name -> products.ProductListItem
-	1,  // 5: products.GetOrderBlockedProductsByOrderIDResponse.items:type_name -> products.OrderBlockedProduct
-	6,  // 6: products.SetOrderBlockedProductsByOrderIDRequest.items:type_name -> products.OrderProduct
-	2,  // 7: products.Products.GetProductsByIDs:input_type -> products.GetProductsByIDsRequest
-	4,  // 8: products.Products.GetOrderBlockedProductsByOrderID:input_type -> products.GetOrderBlockedProductsByOrderIDRequest
-	7,  // 9: products.Products.SetOrderBlockedProductsByOrderID:input_type -> products.SetOrderBlockedProductsByOrderIDRequest
-	3,  // 10: products.Products.GetProductsByIDs:output_type -> products.GetProductsByIDsResponse
-	5,  // 11: products.Products.GetOrderBlockedProductsByOrderID:output_type -> products.GetOrderBlockedProductsByOrderIDResponse
-	8,  // 12: products.Products.SetOrderBlockedProductsByOrderID:output_type -> products.SetOrderBlockedProductsByOrderIDResponse
-	10, // [10:13] is the sub-list for method output_type
-	7,  // [7:10] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	17, // 0: products.ProductListItem.image_preview_file_id:type_name -> google.protobuf.StringValue
+	18, // 1: products.ProductListItem.created_at:type_name -> google.protobuf.Timestamp
+	18, // 2: products.ProductListItem.updated_at:type_name -> google.protobuf.Timestamp
+	18, // 3: products.ProductListItem.deleted_at:type_name -> google.protobuf.Timestamp
+	17, // 4: products.ProductListItem.tax_rate:type_name -> google.protobuf.StringValue
+	1,  // 5: products.ProductListItem.variants:type_name -> products.ProductVariant
+	16, // 6: products.ProductVariant.options:type_name -> products.ProductVariant.OptionsEntry
+	0,  // 7: products.GetProductsByIDsResponse.items:type_name -> products.ProductListItem
+	2,  // 8: products.GetOrderBlockedProductsByOrderIDResponse.items:type_name -> products.OrderBlockedProduct
+	7,  // 9: products.SetOrderBlockedProductsByOrderIDRequest.items:type_name -> products.OrderProduct
+	7,  // 10: products.SetReturnRestockByReturnIDRequest.items:type_name -> products.OrderProduct
+	18, // 11: products.ExtendOrderBlockByOrderIDResponse.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 12: products.Products.GetProductsByIDs:input_type -> products.GetProductsByIDsRequest
+	5,  // 13: products.Products.GetOrderBlockedProductsByOrderID:input_type -> products.GetOrderBlockedProductsByOrderIDRequest
+	8,  // 14: products.Products.SetOrderBlockedProductsByOrderID:input_type -> products.SetOrderBlockedProductsByOrderIDRequest
+	10, // 15: products.Products.SetReturnRestockByReturnID:input_type -> products.SetReturnRestockByReturnIDRequest
+	12, // 16: products.Products.ExtendOrderBlockByOrderID:input_type -> products.ExtendOrderBlockByOrderIDRequest
+	14, // 17: products.Products.ApplyOrderBlockByOrderID:input_type -> products.ApplyOrderBlockByOrderIDRequest
+	4,  // 18: products.Products.GetProductsByIDs:output_type -> products.GetProductsByIDsResponse
+	6,  // 19: products.Products.GetOrderBlockedProductsByOrderID:output_type -> products.GetOrderBlockedProductsByOrderIDResponse
+	9,  // 20: products.Products.SetOrderBlockedProductsByOrderID:output_type -> products.SetOrderBlockedProductsByOrderIDResponse
+	11, // 21: products.Products.SetReturnRestockByReturnID:output_type -> products.SetReturnRestockByReturnIDResponse
+	13, // 22: products.Products.ExtendOrderBlockByOrderID:output_type -> products.ExtendOrderBlockByOrderIDResponse
+	15, // 23: products.Products.ApplyOrderBlockByOrderID:output_type -> products.ApplyOrderBlockByOrderIDResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_products_products_proto_init() }
@@ -619,7 +1080,7 @@ func file_products_products_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_products_products_proto_rawDesc), len(file_products_products_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,9 @@ const (
 	Products_GetProductsByIDs_FullMethodName                 = "/products.Products/GetProductsByIDs"
 	Products_GetOrderBlockedProductsByOrderID_FullMethodName = "/products.Products/GetOrderBlockedProductsByOrderID"
 	Products_SetOrderBlockedProductsByOrderID_FullMethodName = "/products.Products/SetOrderBlockedProductsByOrderID"
+	Products_SetReturnRestockByReturnID_FullMethodName       = "/products.Products/SetReturnRestockByReturnID"
+	Products_ExtendOrderBlockByOrderID_FullMethodName        = "/products.Products/ExtendOrderBlockByOrderID"
+	Products_ApplyOrderBlockByOrderID_FullMethodName         = "/products.Products/ApplyOrderBlockByOrderID"
 )
 
 // ProductsClient is the client API for Products service.
@@ -33,6 +36,9 @@ type ProductsClient interface {
 	GetProductsByIDs(ctx context.Context, in *GetProductsByIDsRequest, opts ...grpc.CallOption) (*GetProductsByIDsResponse, error)
 	GetOrderBlockedProductsByOrderID(ctx context.Context, in *GetOrderBlockedProductsByOrderIDRequest, opts ...grpc.CallOption) (*GetOrderBlockedProductsByOrderIDResponse, error)
 	SetOrderBlockedProductsByOrderID(ctx context.Context, in *SetOrderBlockedProductsByOrderIDRequest, opts ...grpc.CallOption) (*SetOrderBlockedProductsByOrderIDResponse, error)
+	SetReturnRestockByReturnID(ctx context.Context, in *SetReturnRestockByReturnIDRequest, opts ...grpc.CallOption) (*SetReturnRestockByReturnIDResponse, error)
+	ExtendOrderBlockByOrderID(ctx context.Context, in *ExtendOrderBlockByOrderIDRequest, opts ...grpc.CallOption) (*ExtendOrderBlockByOrderIDResponse, error)
+	ApplyOrderBlockByOrderID(ctx context.Context, in *ApplyOrderBlockByOrderIDRequest, opts ...grpc.CallOption) (*ApplyOrderBlockByOrderIDResponse, error)
 }
 
 type productsClient struct {
@@ -73,6 +79,36 @@ func (c *productsClient) SetOrderBlockedProductsByOrderID(ctx context.Context, i
 	return out, nil
 }
 
+func (c *productsClient) SetReturnRestockByReturnID(ctx context.Context, in *SetReturnRestockByReturnIDRequest, opts ...grpc.CallOption) (*SetReturnRestockByReturnIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetReturnRestockByReturnIDResponse)
+	err := c.cc.Invoke(ctx, Products_SetReturnRestockByReturnID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) ExtendOrderBlockByOrderID(ctx context.Context, in *ExtendOrderBlockByOrderIDRequest, opts ...grpc.CallOption) (*ExtendOrderBlockByOrderIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtendOrderBlockByOrderIDResponse)
+	err := c.cc.Invoke(ctx, Products_ExtendOrderBlockByOrderID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) ApplyOrderBlockByOrderID(ctx context.Context, in *ApplyOrderBlockByOrderIDRequest, opts ...grpc.CallOption) (*ApplyOrderBlockByOrderIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyOrderBlockByOrderIDResponse)
+	err := c.cc.Invoke(ctx, Products_ApplyOrderBlockByOrderID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductsServer is the server API for Products service.
 // All implementations must embed UnimplementedProductsServer
 // for forward compatibility.
@@ -82,6 +118,9 @@ type ProductsServer interface {
 	GetProductsByIDs(context.Context, *GetProductsByIDsRequest) (*GetProductsByIDsResponse, error)
 	GetOrderBlockedProductsByOrderID(context.Context, *GetOrderBlockedProductsByOrderIDRequest) (*GetOrderBlockedProductsByOrderIDResponse, error)
 	SetOrderBlockedProductsByOrderID(context.Context, *SetOrderBlockedProductsByOrderIDRequest) (*SetOrderBlockedProductsByOrderIDResponse, error)
+	SetReturnRestockByReturnID(context.Context, *SetReturnRestockByReturnIDRequest) (*SetReturnRestockByReturnIDResponse, error)
+	ExtendOrderBlockByOrderID(context.Context, *ExtendOrderBlockByOrderIDRequest) (*ExtendOrderBlockByOrderIDResponse, error)
+	ApplyOrderBlockByOrderID(context.Context, *ApplyOrderBlockByOrderIDRequest) (*ApplyOrderBlockByOrderIDResponse, error)
 	mustEmbedUnimplementedProductsServer()
 }
 
@@ -101,6 +140,15 @@ func (UnimplementedProductsServer) GetOrderBlockedProductsByOrderID(context.Cont
 func (UnimplementedProductsServer) SetOrderBlockedProductsByOrderID(context.Context, *SetOrderBlockedProductsByOrderIDRequest) (*SetOrderBlockedProductsByOrderIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetOrderBlockedProductsByOrderID not implemented")
 }
+func (UnimplementedProductsServer) SetReturnRestockByReturnID(context.Context, *SetReturnRestockByReturnIDRequest) (*SetReturnRestockByReturnIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReturnRestockByReturnID not implemented")
+}
+func (UnimplementedProductsServer) ExtendOrderBlockByOrderID(context.Context, *ExtendOrderBlockByOrderIDRequest) (*ExtendOrderBlockByOrderIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendOrderBlockByOrderID not implemented")
+}
+func (UnimplementedProductsServer) ApplyOrderBlockByOrderID(context.Context, *ApplyOrderBlockByOrderIDRequest) (*ApplyOrderBlockByOrderIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyOrderBlockByOrderID not implemented")
+}
 func (UnimplementedProductsServer) mustEmbedUnimplementedProductsServer() {}
 func (UnimplementedProductsServer) testEmbeddedByValue()                  {}
 
@@ -176,6 +224,60 @@ func _Products_SetOrderBlockedProductsByOrderID_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Products_SetReturnRestockByReturnID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReturnRestockByReturnIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).SetReturnRestockByReturnID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Products_SetReturnRestockByReturnID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).SetReturnRestockByReturnID(ctx, req.(*SetReturnRestockByReturnIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_ExtendOrderBlockByOrderID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendOrderBlockByOrderIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).ExtendOrderBlockByOrderID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Products_ExtendOrderBlockByOrderID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).ExtendOrderBlockByOrderID(ctx, req.(*ExtendOrderBlockByOrderIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_ApplyOrderBlockByOrderID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyOrderBlockByOrderIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).ApplyOrderBlockByOrderID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Products_ApplyOrderBlockByOrderID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).ApplyOrderBlockByOrderID(ctx, req.(*ApplyOrderBlockByOrderIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Products_ServiceDesc is the grpc.ServiceDesc for Products service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -195,6 +297,18 @@ var Products_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetOrderBlockedProductsByOrderID",
 			Handler:    _Products_SetOrderBlockedProductsByOrderID_Handler,
 		},
+		{
+			MethodName: "SetReturnRestockByReturnID",
+			Handler:    _Products_SetReturnRestockByReturnID_Handler,
+		},
+		{
+			MethodName: "ExtendOrderBlockByOrderID",
+			Handler:    _Products_ExtendOrderBlockByOrderID_Handler,
+		},
+		{
+			MethodName: "ApplyOrderBlockByOrderID",
+			Handler:    _Products_ApplyOrderBlockByOrderID_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "products/products.proto",
@@ -41,6 +41,18 @@ const docTemplate = `{
                         "description": "Offset",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page, offset will be ignored",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Do not count total",
+                        "name": "skip_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -96,7 +108,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
+        "/orders/bulk/jobs/{id}": {
             "get": {
                 "security": [
                     {
@@ -109,11 +121,11 @@ const docTemplate = `{
                 "tags": [
                     "orders"
                 ],
-                "summary": "Получить заказ по ID",
+                "summary": "Получить задачу массовой операции с заказами",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -123,7 +135,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controller.GetOrderOut"
+                            "$ref": "#/definitions/controller.OrderBulkJobOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
                         }
                     },
                     "404": {
@@ -133,20 +151,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/orders/bulk/status": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Небольшие пачки обрабатываются сразу (200), большие - в фоне (202), прогресс доступен по /orders/bulk/jobs/{id}",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "orders"
                 ],
-                "summary": "Обновить заказ",
+                "summary": "Поменять статус нескольким заказам",
                 "parameters": [
                     {
                         "description": "JSON",
@@ -154,22 +178,21 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.UpdateOrderIn"
+                            "$ref": "#/definitions/controller.SetOrdersBulkStatusIn"
                         }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/controller.OrderBulkJobOut"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/controller.OrderBulkJobOut"
                         }
                     },
                     "400": {
@@ -181,43 +204,161 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
-            "put": {
-                "security": [
+        "/orders/cart": {
+            "post": {
+                "description": "Авторизованному покупателю возвращается корзина его аккаунта, анонимному - новая корзина",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Создать корзину",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controller.CartOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/cart/{token}": {
+            "get": {
+                "description": "Цены и наличие сверяются с каталогом при каждом чтении, изменения помечаются в позициях",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Получить корзину",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.CartOut"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/cart/{token}/checkout": {
+            "post": {
+                "description": "Если цены или наличие товаров изменились, заказ не создается и возвращается 409 с описанием изменений.\nНовые цены к этому моменту уже сохранены в корзине, повторный запрос оформит заказ по ним",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "orders"
+                    "cart"
                 ],
-                "summary": "Поменять статус заказу",
+                "summary": "Оформить заказ из корзины",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "description": "JSON",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.SetOrderStatusIn"
+                            "$ref": "#/definitions/controller.CheckoutCartIn"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateOrderOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/cart/{token}/items": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Установить количество товара в корзине",
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.SetCartItemIn"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/controller.CartOut"
                         }
                     },
                     "400": {
@@ -225,125 +366,1043 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorJSON"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Очистить корзину",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
                     }
                 }
             }
         },
-        "/orders/{id}/{secret_key}": {
+        "/orders/reports/sales": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "reports"
                 ],
-                "summary": "Получить заказ по ID + secret_key",
+                "summary": "Отчет по выручке и среднему чеку за период",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD), default: 30 days ago",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD), inclusive, default: today",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Granularity",
+                        "name": "granularity",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controller.GetOrderOut"
+                            "$ref": "#/definitions/controller.GetReportSalesOut"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorJSON"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controller.CreateOrderIn": {
-            "type": "object",
-            "required": [
-                "details",
-                "products"
-            ],
-            "properties": {
-                "details": {
-                    "$ref": "#/definitions/controller.CreateOrderInDetails"
-                },
-                "products": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/controller.CreateOrderInProduct"
+        },
+        "/orders/reports/sales/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Одна строка на товар заказа. Цены включают НДС, sum = net_sum + tax_sum.\nУчитываются те же заказы, что и в отчете по выручке.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Выгрузка продаж за период в CSV с разбивкой по НДС",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD), default: 30 days ago",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD), inclusive, default: today",
+                        "name": "date_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
                     }
                 }
             }
         },
-        "controller.CreateOrderInDetails": {
-            "type": "object",
-            "required": [
-                "client_email",
-                "client_name",
-                "client_phone",
-                "client_surname",
-                "delivery_address"
-            ],
-            "properties": {
-                "client_email": {
-                    "type": "string"
-                },
+        "/orders/reports/statuses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Отчет по количеству заказов в разрезе статусов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD), default: 30 days ago",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD), inclusive, default: today",
+                        "name": "date_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.GetReportStatusesOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/reports/top-products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Отчет по самым продаваемым товарам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD), default: 30 days ago",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD), inclusive, default: today",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "quantity",
+                            "revenue"
+                        ],
+                        "type": "string",
+                        "description": "Sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.GetReportTopProductsOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/returns/{return_id}/approve": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Одобрить возврат: товары вернутся на склад, сумма будет учтена в заказе",
+                "parameters": [
+                    {
+                        "description": "JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ApproveOrderReturnIn"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return ID",
+                        "name": "return_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/returns/{return_id}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Отклонить возврат",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return ID",
+                        "name": "return_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить заказ по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.GetOrderOut"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Order version"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Обновить заказ",
+                "parameters": [
+                    {
+                        "description": "JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.UpdateOrderIn"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order version from ETag",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New order version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/reserve/extend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Срок брони отсчитывается заново по настройке для текущего статуса заказа",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Продлить бронь товаров заказа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ExtendOrderStockReserveOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить возвраты по заказу",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.GetOrderReturnsOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Оформить возврат по заказу",
+                "parameters": [
+                    {
+                        "description": "JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateOrderReturnIn"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controller.OrderReturnOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Поменять статус заказу",
+                "parameters": [
+                    {
+                        "description": "JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.SetOrderStatusIn"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order version from ETag",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New order version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/{secret_key}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить заказ по ID + secret_key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.GetOrderOut"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.ApproveOrderReturnIn": {
+            "type": "object",
+            "properties": {
+                "refund_sum": {
+                    "description": "Если не указана - возвращается полная стоимость товаров из возврата",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "controller.CartOut": {
+            "type": "object",
+            "properties": {
+                "has_changes": {
+                    "description": "Есть изменения цен или наличия, которые нужно показать покупателю",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.CartOutItem"
+                    }
+                },
+                "sum": {
+                    "description": "Сумма доступных для заказа позиций",
+                    "type": "number"
+                },
+                "token": {
+                    "description": "Токен корзины, по нему анонимный покупатель обращается к корзине",
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CartOutItem": {
+            "type": "object",
+            "properties": {
+                "image_preview_url": {
+                    "type": "string"
+                },
+                "is_unavailable": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "not_enough_stock": {
+                    "type": "boolean"
+                },
+                "old_price": {
+                    "description": "Прежняя цена, если она изменилась с прошлого просмотра корзины",
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_changed": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock_available": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.CheckoutCartIn": {
+            "type": "object",
+            "required": [
+                "details"
+            ],
+            "properties": {
+                "details": {
+                    "$ref": "#/definitions/controller.CreateOrderInDetails"
+                }
+            }
+        },
+        "controller.CreateOrderIn": {
+            "type": "object",
+            "required": [
+                "details",
+                "products"
+            ],
+            "properties": {
+                "details": {
+                    "$ref": "#/definitions/controller.CreateOrderInDetails"
+                },
+                "products": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/controller.CreateOrderInProduct"
+                    }
+                }
+            }
+        },
+        "controller.CreateOrderInDetails": {
+            "type": "object",
+            "required": [
+                "client_email",
+                "client_name",
+                "client_phone",
+                "client_surname",
+                "delivery_address"
+            ],
+            "properties": {
+                "client_email": {
+                    "type": "string"
+                },
                 "client_name": {
                     "type": "string",
                     "maxLength": 150,
                     "minLength": 1
                 },
                 "client_phone": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 1
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 1
+                },
+                "client_surname": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 1
+                },
+                "delivery_address": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 1
+                },
+                "delivery_zone": {
+                    "description": "Зона доставки, по ней выбирается склад, пустая - склады по приоритету",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "controller.CreateOrderInProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "controller.CreateOrderOut": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "secret_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateOrderReturnIn": {
+            "type": "object",
+            "required": [
+                "products",
+                "reason"
+            ],
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/controller.CreateOrderReturnInProduct"
+                    }
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 1
+                }
+            }
+        },
+        "controller.CreateOrderReturnInProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "controller.ExtendOrderStockReserveOut": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "null - бронь бессрочная",
+                    "type": "string"
+                }
+            }
+        },
+        "controller.GetOrderOut": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "$ref": "#/definitions/controller.GetOrderOutDetails"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "net_sum": {
+                    "type": "number"
+                },
+                "next_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "order_sum": {
+                    "type": "number"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.GetOrderOutProduct"
+                    }
+                },
+                "refund_sum": {
+                    "type": "number"
+                },
+                "secret_key": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tax_sum": {
+                    "type": "number"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.GetOrderOutDetails": {
+            "type": "object",
+            "properties": {
+                "client_email": {
+                    "type": "string"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "client_phone": {
+                    "type": "string"
                 },
                 "client_surname": {
-                    "type": "string",
-                    "maxLength": 150,
-                    "minLength": 1
+                    "type": "string"
                 },
                 "delivery_address": {
-                    "type": "string",
-                    "maxLength": 150,
-                    "minLength": 1
+                    "type": "string"
+                },
+                "delivery_zone": {
+                    "type": "string"
                 }
             }
         },
-        "controller.CreateOrderInProduct": {
+        "controller.GetOrderOutProduct": {
             "type": "object",
             "properties": {
                 "id": {
-                    "type": "integer",
-                    "minimum": 0
+                    "type": "integer"
+                },
+                "image_preview": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Данные товара на момент оформления заказа",
+                    "type": "string"
+                },
+                "net_sum": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
                 },
                 "quantity": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "type": "number"
+                },
+                "tax_sum": {
+                    "type": "number"
+                },
+                "variant_id": {
+                    "type": "integer"
                 }
             }
         },
-        "controller.CreateOrderOut": {
+        "controller.GetOrderReturnsOut": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.OrderReturnOut"
+                    }
+                }
+            }
+        },
+        "controller.GetOrdersOut": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.GetOrdersOutItem"
+                    }
                 },
-                "secret_key": {
+                "next_cursor": {
+                    "description": "Курсор следующей страницы, пустой на последней странице",
                     "type": "string"
+                },
+                "total": {
+                    "description": "Не возвращается, если передан skip_total",
+                    "type": "integer"
                 }
             }
         },
-        "controller.GetOrderOut": {
+        "controller.GetOrdersOutItem": {
             "type": "object",
             "properties": {
                 "details": {
@@ -352,91 +1411,257 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "order_sum": {
+                "net_sum": {
                     "type": "number"
                 },
-                "products": {
+                "next_statuses": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/controller.GetOrderOutProduct"
+                        "type": "string"
                     }
                 },
+                "order_sum": {
+                    "type": "number"
+                },
+                "refund_sum": {
+                    "type": "number"
+                },
                 "secret_key": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
+                },
+                "tax_sum": {
+                    "type": "number"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "controller.GetOrderOutDetails": {
+        "controller.GetReportSalesOut": {
             "type": "object",
             "properties": {
-                "client_email": {
-                    "type": "string"
+                "avg_order_sum": {
+                    "type": "number"
                 },
-                "client_name": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.GetReportSalesOutItem"
+                    }
                 },
-                "client_phone": {
-                    "type": "string"
+                "orders_count": {
+                    "type": "integer"
                 },
-                "client_surname": {
-                    "type": "string"
+                "revenue": {
+                    "type": "number"
                 },
-                "delivery_address": {
+                "tax": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.GetReportSalesOutItem": {
+            "type": "object",
+            "properties": {
+                "avg_order_sum": {
+                    "type": "number"
+                },
+                "orders_count": {
+                    "type": "integer"
+                },
+                "period": {
                     "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "number"
                 }
             }
         },
-        "controller.GetOrderOutProduct": {
+        "controller.GetReportStatusesOut": {
             "type": "object",
             "properties": {
-                "id": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.GetReportStatusesOutItem"
+                    }
+                }
+            }
+        },
+        "controller.GetReportStatusesOutItem": {
+            "type": "object",
+            "properties": {
+                "orders_count": {
                     "type": "integer"
                 },
-                "price": {
+                "orders_sum": {
                     "type": "number"
                 },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.GetReportTopProductsOut": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.GetReportTopProductsOutItem"
+                    }
+                }
+            }
+        },
+        "controller.GetReportTopProductsOutItem": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "orders_count": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
                 "quantity": {
                     "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
                 }
             }
         },
-        "controller.GetOrdersOut": {
+        "controller.OrderBulkJobOut": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/controller.GetOrdersOutItem"
+                        "$ref": "#/definitions/controller.OrderBulkJobOutItem"
                     }
                 },
+                "processed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "target_status": {
+                    "type": "string"
+                },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "controller.GetOrdersOutItem": {
+        "controller.OrderBulkJobOutItem": {
             "type": "object",
             "properties": {
-                "details": {
-                    "$ref": "#/definitions/controller.GetOrderOutDetails"
+                "error": {
+                    "type": "string"
+                },
+                "is_ok": {
+                    "type": "boolean"
+                },
+                "is_processed": {
+                    "type": "boolean"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.OrderReturnOut": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "order_sum": {
-                    "type": "number"
+                "order_id": {
+                    "type": "integer"
                 },
-                "secret_key": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.OrderReturnOutProduct"
+                    }
+                },
+                "reason": {
                     "type": "string"
                 },
+                "refund_sum": {
+                    "type": "number"
+                },
                 "status": {
                     "type": "string"
                 }
             }
         },
+        "controller.OrderReturnOutProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.SetCartItemIn": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "description": "0 - убрать товар из корзины",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "variant_id": {
+                    "description": "Вариант товара, 0 - товар без вариантов",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
         "controller.SetOrderStatusIn": {
             "type": "object",
             "required": [
@@ -448,6 +1673,44 @@ const docTemplate = `{
                 }
             }
         },
+        "controller.SetOrdersBulkStatusIn": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/controller.SetOrdersBulkStatusInFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.SetOrdersBulkStatusInFilter": {
+            "type": "object",
+            "properties": {
+                "date_from": {
+                    "type": "string"
+                },
+                "date_to": {
+                    "type": "string"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "controller.UpdateOrderIn": {
             "type": "object",
             "required": [
@@ -499,6 +1762,11 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 150,
                     "minLength": 1
+                },
+                "delivery_zone": {
+                    "description": "Зона доставки, по ней выбирается склад, пустая - склады по приоритету",
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
@@ -516,6 +1784,10 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer",
                     "minimum": 1
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
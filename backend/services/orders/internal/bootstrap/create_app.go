@@ -13,6 +13,7 @@ import (
 	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
 	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db/migrations"
 	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/temporal"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
 )
@@ -30,11 +31,18 @@ var App = fx.Options(
 	// Бизнес логика
 	OrderProductModule,
 	OrderModule,
+	OrderReturnProductModule,
+	OrderReturnModule,
+	OrderBulkJobItemModule,
+	OrderBulkJobModule,
+	ReportModule,
+	CartItemModule,
+	CartModule,
 	// Delivery
 	DeliveryHTTP,
 	DeliveryGRPC,
 	// Start && Stop invoke
-	fx.Invoke(func(lc fx.Lifecycle, shutdowner fx.Shutdowner, logger *slog.Logger, config config.Config, dbpool *pgxpool.Pool, fiberApp *fiber.App, grpcServer *grpc.Server, productsGCl *productsgcl.ClientConn, tClient temporal.TemporalClient) {
+	fx.Invoke(func(lc fx.Lifecycle, shutdowner fx.Shutdowner, logger *slog.Logger, config config.Config, dbpool *pgxpool.Pool, fiberApp *fiber.App, grpcServer *grpc.Server, productsGCl *productsgcl.ClientConn, tClient temporal.TemporalClient, orderBulkJobUC usecase.OrderBulkJob) {
 
 		lc.Append(fx.Hook{
 			OnStart: func(ctx context.Context) error {
@@ -62,6 +70,11 @@ var App = fx.Options(
 				}
 				logger.Info("Connection to temporal established")
 
+				err = orderBulkJobUC.ResumeUnfinished(ctx)
+				if err != nil {
+					return err
+				}
+
 				if config.GRPC.Port > 0 {
 					go StartGRPCServer(grpcServer, config, logger, shutdowner)
 				}
@@ -80,7 +93,7 @@ var App = fx.Options(
 
 				return nil
 			},
-			OnStop: func(_ context.Context) error {
+			OnStop: func(ctx context.Context) error {
 				if config.HTTP.Port > 0 {
 					logger.Info("stopping HTTP Fiber")
 					err := fiberApp.ShutdownWithTimeout(time.Duration(config.HTTP.StopTimeout) * time.Second)
@@ -94,6 +107,12 @@ var App = fx.Options(
 					grpcServer.GracefulStop()
 				}
 
+				logger.Info("stopping order bulk jobs")
+				err := orderBulkJobUC.Stop(ctx)
+				if err != nil {
+					logger.Error("failed to stop order bulk jobs", slog.Any("error", err))
+				}
+
 				tClient.Close()
 
 				logger.Info("stopping Postgress")
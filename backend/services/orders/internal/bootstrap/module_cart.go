@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var CartModule = fx.Module(
+	"cart_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewCart, fx.As(new(usecase.CartRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewCartInpl, fx.As(new(usecase.Cart))),
+	),
+)
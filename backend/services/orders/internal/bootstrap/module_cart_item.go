@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var CartItemModule = fx.Module(
+	"cart_item_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewCartItem, fx.As(new(usecase.CartItemRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewCartItemInpl, fx.As(new(usecase.CartItem))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var OrderBulkJobModule = fx.Module(
+	"order_bulk_job_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewOrderBulkJob, fx.As(new(usecase.OrderBulkJobRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewOrderBulkJobInpl, fx.As(new(usecase.OrderBulkJob))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var OrderBulkJobItemModule = fx.Module(
+	"order_bulk_job_item_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewOrderBulkJobItem, fx.As(new(usecase.OrderBulkJobItemRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewOrderBulkJobItemInpl, fx.As(new(usecase.OrderBulkJobItem))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var OrderReturnModule = fx.Module(
+	"order_return_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewOrderReturn, fx.As(new(usecase.OrderReturnRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewOrderReturnInpl, fx.As(new(usecase.OrderReturn))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var OrderReturnProductModule = fx.Module(
+	"order_return_product_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewOrderReturnProduct, fx.As(new(usecase.OrderReturnProductRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewOrderReturnProductInpl, fx.As(new(usecase.OrderReturnProduct))),
+	),
+)
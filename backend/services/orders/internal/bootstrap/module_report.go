@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ReportModule = fx.Module(
+	"report_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewReport, fx.As(new(usecase.ReportRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewReportInpl, fx.As(new(usecase.Report))),
+	),
+)
@@ -0,0 +1,31 @@
+package ordersgrpc
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) ExpireOrderStockReserve(ctx context.Context, in *ordersv1.ExpireOrderStockReserveRequest) (*ordersv1.ExpireOrderStockReserveResponse, error) {
+
+	if in.GetOrderId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty order_id")
+	}
+
+	canRelease, err := s.orderUC.ExpireStockReserve(ctx, in.GetOrderId())
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return nil, appErr.AsGRPCError()
+		}
+		return nil, err
+	}
+
+	out := &ordersv1.ExpireOrderStockReserveResponse{
+		CanRelease: canRelease,
+	}
+
+	return out, nil
+}
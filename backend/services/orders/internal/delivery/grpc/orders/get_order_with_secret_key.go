@@ -0,0 +1,52 @@
+package ordersgrpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) GetOrderWithSecretKey(ctx context.Context, in *ordersv1.GetOrderWithSecretKeyRequest) (*ordersv1.GetOrderWithSecretKeyResponse, error) {
+
+	if in.GetOrderId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty order_id")
+	}
+
+	secretKey, err := uuid.Parse(in.GetSecretKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid secret_key")
+	}
+
+	orderFull, err := s.orderUC.FindOneFullByID(ctx, in.GetOrderId(), nil)
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return nil, appErr.AsGRPCError()
+		}
+		return nil, err
+	}
+
+	// Не раскрываем существование заказа при неверном ключе
+	if orderFull.Order.SecretKey != secretKey {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+
+	out := &ordersv1.GetOrderWithSecretKeyResponse{
+		OrderId:   orderFull.Order.ID,
+		Status:    orderFull.Order.Status.String(),
+		CanReview: orderFull.Order.Status.AllowReviews(),
+		Products:  make([]*ordersv1.OrderProductRef, 0, len(orderFull.Products)),
+	}
+
+	for _, product := range orderFull.Products {
+		out.Products = append(out.Products, &ordersv1.OrderProductRef{
+			ProductId: product.ID,
+			VariantId: product.VariantID,
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package ordersgrpc
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) GetProductCoPurchases(ctx context.Context, in *ordersv1.GetProductCoPurchasesRequest) (*ordersv1.GetProductCoPurchasesResponse, error) {
+
+	if len(in.GetProductIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty product_ids")
+	}
+
+	if in.GetLimitPerProduct() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit_per_product")
+	}
+
+	items, err := s.orderProductUC.FindCoPurchases(ctx, in.GetProductIds(), in.GetCreatedFrom().AsTime(), int64(in.GetMinOrders()), uint64(in.GetLimitPerProduct()))
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return nil, appErr.AsGRPCError()
+		}
+		return nil, err
+	}
+
+	out := &ordersv1.GetProductCoPurchasesResponse{
+		Items: make([]*ordersv1.ProductCoPurchase, len(items)),
+	}
+
+	for i, item := range items {
+		out.Items[i] = &ordersv1.ProductCoPurchase{
+			ProductId:        item.ProductID,
+			RelatedProductId: item.RelatedProductID,
+			OrdersCount:      item.OrdersCount,
+		}
+	}
+
+	return out, nil
+}
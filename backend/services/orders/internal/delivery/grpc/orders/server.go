@@ -12,12 +12,14 @@ type serverAPI struct {
 	cfg            config.Config
 	orderUC        usecase.Order
 	orderProductUC usecase.OrderProduct
+	orderReturnUC  usecase.OrderReturn
 }
 
-func Register(gRPCServer *grpc.Server, cfg config.Config, orderUC usecase.Order, orderProductUC usecase.OrderProduct) {
+func Register(gRPCServer *grpc.Server, cfg config.Config, orderUC usecase.Order, orderProductUC usecase.OrderProduct, orderReturnUC usecase.OrderReturn) {
 	ordersv1.RegisterOrdersServer(gRPCServer, &serverAPI{
 		cfg:            cfg,
 		orderUC:        orderUC,
 		orderProductUC: orderProductUC,
+		orderReturnUC:  orderReturnUC,
 	})
 }
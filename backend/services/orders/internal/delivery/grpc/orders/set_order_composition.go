@@ -7,6 +7,7 @@ import (
 	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
 	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
 	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -32,10 +33,23 @@ func (s *serverAPI) SetOrderComposition(ctx context.Context, in *ordersv1.SetOrd
 					return nil, e.ErrBadRequest.Wrap(err).AsGRPCError()
 				}
 
+				taxRate := decimal.Zero
+				if item.GetTaxRate() != "" {
+					taxRate, err = decimal.NewFromString(item.GetTaxRate())
+					if err != nil {
+						return nil, e.ErrBadRequest.Wrap(err).AsGRPCError()
+					}
+				}
+
 				products[i] = usecase.OrderProductWithPrice{
-					ID:       item.GetProductId(),
-					Quantity: item.GetQuantity(),
-					Price:    price,
+					ID:              item.GetProductId(),
+					VariantID:       item.GetVariantId(),
+					Quantity:        item.GetQuantity(),
+					Price:           price,
+					TaxRate:         taxRate,
+					Name:            item.GetName(),
+					SKU:             item.GetSku(),
+					ImagePreviewURL: item.GetImagePreviewUrl(),
 				}
 			}
 
@@ -55,6 +69,21 @@ func (s *serverAPI) SetOrderComposition(ctx context.Context, in *ordersv1.SetOrd
 			params.Status = &status
 		}
 
+		if in.GetOrderVersion() != nil {
+			params.Version = lo.ToPtr(in.GetOrderVersion().GetValue())
+		}
+
+		if details := in.GetOrderDetails(); details != nil {
+			params.Details = &usecase.OrderDataDetailsIn{
+				ClientName:      details.GetClientName(),
+				ClientSurname:   details.GetClientSurname(),
+				ClientEmail:     details.GetClientEmail(),
+				ClientPhone:     details.GetClientPhone(),
+				DeliveryAddress: details.GetDeliveryAddress(),
+				DeliveryZone:    details.GetDeliveryZone(),
+			}
+		}
+
 		err := s.orderUC.SetOrderComposition(ctx, params)
 
 		if err != nil {
@@ -0,0 +1,27 @@
+package ordersgrpc
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	ordersv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/orders"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) SetOrderReturnResult(ctx context.Context, in *ordersv1.SetOrderReturnResultRequest) (*ordersv1.SetOrderReturnResultResponse, error) {
+
+	if in.GetReturnId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty return_id")
+	}
+
+	err := s.orderReturnUC.SetReturnResult(ctx, in.GetReturnId(), in.GetIsOk())
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return nil, appErr.AsGRPCError()
+		}
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/validation"
+	"github.com/shopspring/decimal"
+)
+
+type ApproveOrderReturnIn struct {
+	// Если не указана - возвращается полная стоимость товаров из возврата
+	RefundSum *float64 `json:"refund_sum" validate:"omitempty,gte=0"`
+}
+
+func (ctrl *Controller) ApproveOrderReturnHandlerValidate(in *ApproveOrderReturnIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Одобрить возврат: товары вернутся на склад, сумма будет учтена в заказе
+// @Security BearerAuth
+// @Tags orders
+// @Accept  json
+// @Param request body ApproveOrderReturnIn true "JSON"
+// @Param return_id path int true "Return ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/returns/{return_id}/approve [put]
+func (ctrl *Controller) ApproveOrderReturnHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	in := &ApproveOrderReturnIn{}
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(in); err != nil {
+			return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+		}
+	}
+
+	ok, errMsg := ctrl.ApproveOrderReturnHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	returnID, err := c.ParamsInt("return_id")
+	if err != nil {
+		return err
+	}
+
+	var refundSum *decimal.Decimal
+	if in.RefundSum != nil {
+		value := decimal.NewFromFloat(*in.RefundSum).Round(2)
+		refundSum = &value
+	}
+
+	err = ctrl.orderReturnUC.Approve(c.Context(), int64(returnID), refundSum)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
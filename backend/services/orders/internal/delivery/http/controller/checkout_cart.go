@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+type CheckoutCartIn struct {
+	Details CreateOrderInDetails `json:"details" validate:"required"`
+}
+
+func (ctrl *Controller) CheckoutCartHandlerValidate(in *CheckoutCartIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Оформить заказ из корзины
+// @Description Если цены или наличие товаров изменились, заказ не создается и возвращается 409 с описанием изменений.
+// @Description Новые цены к этому моменту уже сохранены в корзине, повторный запрос оформит заказ по ним
+// @Tags cart
+// @Accept  json
+// @Produce  json
+// @Param token path string true "Cart token"
+// @Param request body CheckoutCartIn true "JSON"
+// @Success 201 {object} CreateOrderOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /orders/cart/{token}/checkout [post]
+func (ctrl *Controller) CheckoutCartHandler(c *fiber.Ctx) error {
+
+	token, err := uuid.Parse(c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	in := &CheckoutCartIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.CheckoutCartHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	order, err := ctrl.cartUC.Checkout(c.Context(), token, cartAccountID(c), usecase.OrderDataDetailsIn{
+		ClientName:      in.Details.ClientName,
+		ClientSurname:   in.Details.ClientSurname,
+		ClientEmail:     in.Details.ClientEmail,
+		ClientPhone:     in.Details.ClientPhone,
+		DeliveryAddress: in.Details.DeliveryAddress,
+		DeliveryZone:    in.Details.DeliveryZone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(CreateOrderOut{
+		ID:        order.ID,
+		SecretKey: order.SecretKey,
+	})
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// @Summary Очистить корзину
+// @Tags cart
+// @Param token path string true "Cart token"
+// @Success 204
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /orders/cart/{token}/items [delete]
+func (ctrl *Controller) ClearCartHandler(c *fiber.Ctx) error {
+
+	token, err := uuid.Parse(c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.cartUC.Clear(c.Context(), token, cartAccountID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -9,17 +9,25 @@ import (
 )
 
 type Controller struct {
-	logger  *slog.Logger
-	vldtr   *validator.Validate
-	cfg     config.Config
-	orderUC usecase.Order
+	logger         *slog.Logger
+	vldtr          *validator.Validate
+	cfg            config.Config
+	orderUC        usecase.Order
+	orderReturnUC  usecase.OrderReturn
+	orderBulkJobUC usecase.OrderBulkJob
+	reportUC       usecase.Report
+	cartUC         usecase.Cart
 }
 
-func New(logger *slog.Logger, vldtr *validator.Validate, cfg config.Config, orderUC usecase.Order) *Controller {
+func New(logger *slog.Logger, vldtr *validator.Validate, cfg config.Config, orderUC usecase.Order, orderReturnUC usecase.OrderReturn, orderBulkJobUC usecase.OrderBulkJob, reportUC usecase.Report, cartUC usecase.Cart) *Controller {
 	return &Controller{
-		logger:  logger,
-		vldtr:   vldtr,
-		cfg:     cfg,
-		orderUC: orderUC,
+		logger:         logger,
+		vldtr:          vldtr,
+		cfg:            cfg,
+		orderUC:        orderUC,
+		orderReturnUC:  orderReturnUC,
+		orderBulkJobUC: orderBulkJobUC,
+		reportUC:       reportUC,
+		cartUC:         cartUC,
 	}
 }
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary Создать корзину
+// @Description Авторизованному покупателю возвращается корзина его аккаунта, анонимному - новая корзина
+// @Tags cart
+// @Produce  json
+// @Success 201 {object} CartOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/cart [post]
+func (ctrl *Controller) CreateCartHandler(c *fiber.Ctx) error {
+
+	accountID := cartAccountID(c)
+
+	cart, err := ctrl.cartUC.Create(c.Context(), accountID)
+	if err != nil {
+		return err
+	}
+
+	data, err := ctrl.cartUC.FindOneFullByID(c.Context(), cart.ID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(cartFullToOut(data))
+}
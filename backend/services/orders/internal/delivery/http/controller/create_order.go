@@ -19,11 +19,14 @@ type CreateOrderInDetails struct {
 	ClientEmail     string `json:"client_email" validate:"required,email"`
 	ClientPhone     string `json:"client_phone" validate:"required,min=1,max=20"`
 	DeliveryAddress string `json:"delivery_address" validate:"required,min=1,max=150"`
+	// Зона доставки, по ней выбирается склад, пустая - склады по приоритету
+	DeliveryZone string `json:"delivery_zone" validate:"max=100"`
 }
 
 type CreateOrderInProduct struct {
-	ID       int64 `json:"id" validate:"gte=0"`
-	Quantity int32 `json:"quantity" validate:"gte=1"`
+	ID        int64 `json:"id" validate:"gte=0"`
+	VariantID int64 `json:"variant_id" validate:"gte=0"`
+	Quantity  int32 `json:"quantity" validate:"gte=1"`
 }
 
 type CreateOrderOut struct {
@@ -66,14 +69,16 @@ func (ctrl *Controller) CreateOrderHandler(c *fiber.Ctx) error {
 			ClientEmail:     in.Details.ClientEmail,
 			ClientPhone:     in.Details.ClientPhone,
 			DeliveryAddress: in.Details.DeliveryAddress,
+			DeliveryZone:    in.Details.DeliveryZone,
 		},
 		Products: make([]usecase.OrderProductIn, len(in.Products)),
 	}
 
 	for i, item := range in.Products {
 		createIn.Products[i] = usecase.OrderProductIn{
-			ID:       item.ID,
-			Quantity: item.Quantity,
+			ID:        item.ID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
 		}
 	}
 
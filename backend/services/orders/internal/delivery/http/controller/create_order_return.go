@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+type CreateOrderReturnIn struct {
+	Reason   string                       `json:"reason" validate:"required,min=1,max=1000"`
+	Products []CreateOrderReturnInProduct `json:"products" validate:"required,min=1"`
+}
+
+type CreateOrderReturnInProduct struct {
+	ID        int64 `json:"id" validate:"gte=1"`
+	VariantID int64 `json:"variant_id" validate:"gte=0"`
+	Quantity  int32 `json:"quantity" validate:"gte=1"`
+}
+
+func (ctrl *Controller) CreateOrderReturnHandlerValidate(in *CreateOrderReturnIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Оформить возврат по заказу
+// @Security BearerAuth
+// @Tags orders
+// @Accept  json
+// @Produce  json
+// @Param request body CreateOrderReturnIn true "JSON"
+// @Param id path int true "Order ID"
+// @Success 201 {object} OrderReturnOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/{id}/returns [post]
+func (ctrl *Controller) CreateOrderReturnHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	in := &CreateOrderReturnIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.CreateOrderReturnHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	orderID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	createIn := usecase.OrderReturnCreateIn{
+		Reason:   in.Reason,
+		Products: make([]usecase.OrderProductIn, len(in.Products)),
+	}
+
+	for i, item := range in.Products {
+		createIn.Products[i] = usecase.OrderProductIn{
+			ID:        item.ID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	data, err := ctrl.orderReturnUC.Create(c.Context(), int64(orderID), createIn)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(orderReturnToOut(data))
+}
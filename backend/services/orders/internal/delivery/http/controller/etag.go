@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+)
+
+// Версия заказа передается в заголовках ETag / If-Match в виде "123"
+func versionToETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+func parseIfMatch(c *fiber.Ctx) (int64, error) {
+	value := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if value == "" {
+		return 0, fiber.NewError(fiber.StatusPreconditionRequired, "If-Match header is required")
+	}
+
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("invalid If-Match header")
+	}
+
+	return version, nil
+}
+
+// Конфликт версий отдаем как 412 Precondition Failed
+func versionMismatchToHTTP(err error) error {
+	if errors.Is(err, domain.ErrOrderVersionMismatch) {
+		return fiber.NewError(fiber.StatusPreconditionFailed, err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+)
+
+type ExtendOrderStockReserveOut struct {
+	// null - бронь бессрочная
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// @Summary Продлить бронь товаров заказа
+// @Description Срок брони отсчитывается заново по настройке для текущего статуса заказа
+// @Security BearerAuth
+// @Tags orders
+// @Produce  json
+// @Param id path int true "Order ID"
+// @Success 200 {object} ExtendOrderStockReserveOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /orders/{id}/reserve/extend [post]
+func (ctrl *Controller) ExtendOrderStockReserveHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	orderID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	expiresAt, err := ctrl.orderUC.ExtendStockReserve(c.Context(), int64(orderID))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(ExtendOrderStockReserveOut{
+		ExpiresAt: expiresAt,
+	})
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+type CartOut struct {
+	// Токен корзины, по нему анонимный покупатель обращается к корзине
+	Token uuid.UUID     `json:"token"`
+	Items []CartOutItem `json:"items"`
+	// Сумма доступных для заказа позиций
+	Sum float64 `json:"sum"`
+	// Есть изменения цен или наличия, которые нужно показать покупателю
+	HasChanges bool `json:"has_changes"`
+}
+
+type CartOutItem struct {
+	ProductID       int64   `json:"product_id"`
+	VariantID       int64   `json:"variant_id"`
+	Name            string  `json:"name"`
+	SKU             string  `json:"sku"`
+	ImagePreviewURL string  `json:"image_preview_url"`
+	Quantity        int32   `json:"quantity"`
+	Price           float64 `json:"price"`
+	// Прежняя цена, если она изменилась с прошлого просмотра корзины
+	OldPrice       *float64 `json:"old_price"`
+	StockAvailable int32    `json:"stock_available"`
+	PriceChanged   bool     `json:"price_changed"`
+	IsUnavailable  bool     `json:"is_unavailable"`
+	NotEnoughStock bool     `json:"not_enough_stock"`
+}
+
+// Аккаунт покупателя, если он авторизован
+func cartAccountID(c *fiber.Ctx) *uuid.UUID {
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth || authData.AccountID == uuid.Nil {
+		return nil
+	}
+
+	return &authData.AccountID
+}
+
+func cartFullToOut(data *usecase.CartFullOut) CartOut {
+	sum, _ := data.Sum.Float64()
+
+	out := CartOut{
+		Token:      data.Cart.ID,
+		Items:      make([]CartOutItem, len(data.Lines)),
+		Sum:        sum,
+		HasChanges: data.HasChanges(),
+	}
+
+	for i, line := range data.Lines {
+		price, _ := line.Price.Float64()
+
+		out.Items[i] = CartOutItem{
+			ProductID:       line.Item.ProductID,
+			VariantID:       line.Item.VariantID,
+			Name:            line.Name,
+			SKU:             line.SKU,
+			ImagePreviewURL: line.ImagePreviewURL,
+			Quantity:        line.Item.Quantity,
+			Price:           price,
+			StockAvailable:  line.StockAvailable,
+			PriceChanged:    line.IsPriceChanged(),
+			IsUnavailable:   line.IsUnavailable,
+			NotEnoughStock:  line.IsNotEnoughStock,
+		}
+
+		if line.OldPrice != nil {
+			oldPrice, _ := line.OldPrice.Float64()
+			out.Items[i].OldPrice = &oldPrice
+		}
+	}
+
+	return out
+}
+
+// @Summary Получить корзину
+// @Description Цены и наличие сверяются с каталогом при каждом чтении, изменения помечаются в позициях
+// @Tags cart
+// @Produce  json
+// @Param token path string true "Cart token"
+// @Success 200 {object} CartOut
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /orders/cart/{token} [get]
+func (ctrl *Controller) GetCartHandler(c *fiber.Ctx) error {
+
+	token, err := uuid.Parse(c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	data, err := ctrl.cartUC.FindOneFullByID(c.Context(), token, cartAccountID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(cartFullToOut(data))
+}
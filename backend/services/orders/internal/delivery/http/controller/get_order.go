@@ -5,15 +5,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/m11ano/e"
 	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
 )
 
 type GetOrderOut struct {
 	ID        int64                `json:"id"`
 	SecretKey uuid.UUID            `json:"secret_key"`
 	OrderSum  float64              `json:"order_sum"`
+	NetSum    float64              `json:"net_sum"`
+	TaxSum    float64              `json:"tax_sum"`
+	RefundSum float64              `json:"refund_sum"`
 	Status    string               `json:"status"`
+	Version   int64                `json:"version"`
 	Details   GetOrderOutDetails   `json:"details"`
 	Products  []GetOrderOutProduct `json:"products"`
+
+	NextStatuses []string `json:"next_statuses,omitempty"`
 }
 
 type GetOrderOutDetails struct {
@@ -22,12 +29,22 @@ type GetOrderOutDetails struct {
 	ClientEmail     string `json:"client_email"`
 	ClientPhone     string `json:"client_phone"`
 	DeliveryAddress string `json:"delivery_address"`
+	DeliveryZone    string `json:"delivery_zone"`
 }
 
 type GetOrderOutProduct struct {
-	ID       int64   `json:"id"`
-	Quantity int32   `json:"quantity"`
-	Price    float64 `json:"price"`
+	ID        int64   `json:"id"`
+	VariantID int64   `json:"variant_id"`
+	Quantity  int32   `json:"quantity"`
+	Price     float64 `json:"price"`
+	TaxRate   float64 `json:"tax_rate"`
+	NetSum    float64 `json:"net_sum"`
+	TaxSum    float64 `json:"tax_sum"`
+
+	// Данные товара на момент оформления заказа
+	Name         string `json:"name"`
+	SKU          string `json:"sku"`
+	ImagePreview string `json:"image_preview"`
 }
 
 // @Summary Получить заказ по ID
@@ -36,6 +53,7 @@ type GetOrderOutProduct struct {
 // @Produce  json
 // @Param id path int true "Order ID"
 // @Success 200 {object} GetOrderOut
+// @Header 200 {string} ETag "Order version"
 // @Failure 404 {object} middleware.ErrorJSON
 // @Router /orders/{id} [get]
 func (ctrl *Controller) GetOrderHandler(c *fiber.Ctx) error {
@@ -57,11 +75,18 @@ func (ctrl *Controller) GetOrderHandler(c *fiber.Ctx) error {
 	}
 
 	orderSum, _ := data.Order.OrderSum.Float64()
+	netSum, _ := data.Order.NetSum.Float64()
+	taxSum, _ := data.Order.TaxSum.Float64()
+	refundSum, _ := data.Order.RefundSum.Float64()
 
 	out := &GetOrderOut{
 		ID:        data.Order.ID,
 		OrderSum:  orderSum,
+		NetSum:    netSum,
+		TaxSum:    taxSum,
+		RefundSum: refundSum,
 		Status:    data.Order.Status.String(),
+		Version:   data.Order.Version,
 		SecretKey: data.Order.SecretKey,
 		Details: GetOrderOutDetails{
 			ClientName:      data.Order.ClientName,
@@ -69,19 +94,45 @@ func (ctrl *Controller) GetOrderHandler(c *fiber.Ctx) error {
 			ClientEmail:     data.Order.ClientEmail,
 			ClientPhone:     data.Order.ClientPhone,
 			DeliveryAddress: data.Order.DeliveryAddress,
+			DeliveryZone:    data.Order.DeliveryZone,
 		},
-		Products: make([]GetOrderOutProduct, len(data.Products)),
+		Products:     make([]GetOrderOutProduct, len(data.Products)),
+		NextStatuses: nextStatusesToOut(data.Order.Status),
 	}
 
 	for i, product := range data.Products {
 		price, _ := product.Price.Float64()
+		taxRate, _ := product.TaxRate.Float64()
+		netSum, _ := product.NetSum.Float64()
+		taxSum, _ := product.TaxSum.Float64()
 
 		out.Products[i] = GetOrderOutProduct{
-			ID:       product.ID,
-			Quantity: product.Quantity,
-			Price:    price,
+			ID:        product.ID,
+			VariantID: product.VariantID,
+			Quantity:  product.Quantity,
+			Price:     price,
+			TaxRate:   taxRate,
+			NetSum:    netSum,
+			TaxSum:    taxSum,
+
+			Name:         product.Name,
+			SKU:          product.SKU,
+			ImagePreview: product.ImagePreviewURL,
 		}
 	}
 
+	c.Set(fiber.HeaderETag, versionToETag(data.Order.Version))
+
 	return c.JSON(out)
 }
+
+func nextStatusesToOut(status domain.OrderStatus) []string {
+	nextStatuses := status.NextStatuses(false)
+
+	out := make([]string, len(nextStatuses))
+	for i, item := range nextStatuses {
+		out[i] = item.String()
+	}
+
+	return out
+}
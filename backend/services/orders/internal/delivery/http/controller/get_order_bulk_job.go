@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+type OrderBulkJobOut struct {
+	ID           int64                 `json:"id"`
+	Status       string                `json:"status"`
+	TargetStatus string                `json:"target_status"`
+	Total        int32                 `json:"total"`
+	Processed    int32                 `json:"processed"`
+	Succeeded    int32                 `json:"succeeded"`
+	Failed       int32                 `json:"failed"`
+	Error        string                `json:"error,omitempty"`
+	Items        []OrderBulkJobOutItem `json:"items"`
+	CreatedAt    time.Time             `json:"created_at"`
+	FinishedAt   *time.Time            `json:"finished_at"`
+}
+
+type OrderBulkJobOutItem struct {
+	OrderID     int64  `json:"order_id"`
+	IsProcessed bool   `json:"is_processed"`
+	IsOk        bool   `json:"is_ok"`
+	Error       string `json:"error,omitempty"`
+}
+
+// @Summary Получить задачу массовой операции с заказами
+// @Security BearerAuth
+// @Tags orders
+// @Produce  json
+// @Param id path int true "Job ID"
+// @Success 200 {object} OrderBulkJobOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /orders/bulk/jobs/{id} [get]
+func (ctrl *Controller) GetOrderBulkJobHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	jobID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	data, err := ctrl.orderBulkJobUC.FindFullByID(c.Context(), int64(jobID))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(orderBulkJobToOut(data))
+}
+
+func orderBulkJobToOut(data *usecase.OrderBulkJobFullOut) OrderBulkJobOut {
+	out := OrderBulkJobOut{
+		ID:           data.Job.ID,
+		Status:       data.Job.Status.String(),
+		TargetStatus: data.Job.TargetStatus.String(),
+		Total:        data.Job.Total,
+		Processed:    data.Job.Processed,
+		Succeeded:    data.Job.Processed - data.Job.Failed,
+		Failed:       data.Job.Failed,
+		Error:        data.Job.Error,
+		Items:        make([]OrderBulkJobOutItem, len(data.Items)),
+		CreatedAt:    data.Job.CreatedAt,
+		FinishedAt:   data.Job.FinishedAt,
+	}
+
+	for i, item := range data.Items {
+		out.Items[i] = OrderBulkJobOutItem{
+			OrderID:     item.OrderID,
+			IsProcessed: item.IsProcessed,
+			IsOk:        item.IsOk,
+			Error:       item.Error,
+		}
+	}
+
+	return out
+}
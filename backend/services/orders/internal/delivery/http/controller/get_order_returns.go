@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+type OrderReturnOut struct {
+	ID        int64                   `json:"id"`
+	OrderID   int64                   `json:"order_id"`
+	Status    string                  `json:"status"`
+	Reason    string                  `json:"reason"`
+	RefundSum float64                 `json:"refund_sum"`
+	Products  []OrderReturnOutProduct `json:"products"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+type OrderReturnOutProduct struct {
+	ID        int64   `json:"id"`
+	VariantID int64   `json:"variant_id"`
+	Quantity  int32   `json:"quantity"`
+	Price     float64 `json:"price"`
+}
+
+type GetOrderReturnsOut struct {
+	Items []OrderReturnOut `json:"items"`
+}
+
+// @Summary Получить возвраты по заказу
+// @Security BearerAuth
+// @Tags orders
+// @Produce  json
+// @Param id path int true "Order ID"
+// @Success 200 {object} GetOrderReturnsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/{id}/returns [get]
+func (ctrl *Controller) GetOrderReturnsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	orderID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	data, err := ctrl.orderReturnUC.FindFullListByOrderID(c.Context(), int64(orderID))
+	if err != nil {
+		return err
+	}
+
+	out := GetOrderReturnsOut{
+		Items: make([]OrderReturnOut, len(data)),
+	}
+
+	for i, item := range data {
+		out.Items[i] = orderReturnToOut(item)
+	}
+
+	return c.JSON(out)
+}
+
+func orderReturnToOut(data *usecase.OrderReturnFullOut) OrderReturnOut {
+	refundSum, _ := data.Return.RefundSum.Float64()
+
+	out := OrderReturnOut{
+		ID:        data.Return.ID,
+		OrderID:   data.Return.OrderID,
+		Status:    data.Return.Status.String(),
+		Reason:    data.Return.Reason,
+		RefundSum: refundSum,
+		Products:  make([]OrderReturnOutProduct, len(data.Products)),
+		CreatedAt: data.Return.CreatedAt,
+	}
+
+	for i, product := range data.Products {
+		price, _ := product.Price.Float64()
+
+		out.Products[i] = OrderReturnOutProduct{
+			ID:        product.ProductID,
+			VariantID: product.VariantID,
+			Quantity:  product.Quantity,
+			Price:     price,
+		}
+	}
+
+	return out
+}
@@ -36,11 +36,18 @@ func (ctrl *Controller) GetOrderWithSecretKeyHandler(c *fiber.Ctx) error {
 	}
 
 	orderSum, _ := data.Order.OrderSum.Float64()
+	netSum, _ := data.Order.NetSum.Float64()
+	taxSum, _ := data.Order.TaxSum.Float64()
+	refundSum, _ := data.Order.RefundSum.Float64()
 
 	out := &GetOrderOut{
 		ID:        data.Order.ID,
 		OrderSum:  orderSum,
+		NetSum:    netSum,
+		TaxSum:    taxSum,
+		RefundSum: refundSum,
 		Status:    data.Order.Status.String(),
+		Version:   data.Order.Version,
 		SecretKey: data.Order.SecretKey,
 		Details: GetOrderOutDetails{
 			ClientName:      data.Order.ClientName,
@@ -48,17 +55,28 @@ func (ctrl *Controller) GetOrderWithSecretKeyHandler(c *fiber.Ctx) error {
 			ClientEmail:     data.Order.ClientEmail,
 			ClientPhone:     data.Order.ClientPhone,
 			DeliveryAddress: data.Order.DeliveryAddress,
+			DeliveryZone:    data.Order.DeliveryZone,
 		},
 		Products: make([]GetOrderOutProduct, len(data.Products)),
 	}
 
 	for i, product := range data.Products {
 		price, _ := product.Price.Float64()
+		taxRate, _ := product.TaxRate.Float64()
+		netSum, _ := product.NetSum.Float64()
+		taxSum, _ := product.TaxSum.Float64()
 
 		out.Products[i] = GetOrderOutProduct{
 			ID:       product.ID,
 			Quantity: product.Quantity,
 			Price:    price,
+			TaxRate:  taxRate,
+			NetSum:   netSum,
+			TaxSum:   taxSum,
+
+			Name:         product.Name,
+			SKU:          product.SKU,
+			ImagePreview: product.ImagePreviewURL,
 		}
 	}
 
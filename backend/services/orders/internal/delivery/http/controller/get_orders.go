@@ -14,21 +14,35 @@ type GetOrdersOutItem struct {
 	ID        int64              `json:"id"`
 	SecretKey uuid.UUID          `json:"secret_key"`
 	OrderSum  float64            `json:"order_sum"`
+	NetSum    float64            `json:"net_sum"`
+	TaxSum    float64            `json:"tax_sum"`
+	RefundSum float64            `json:"refund_sum"`
 	Status    string             `json:"status"`
+	Version   int64              `json:"version"`
 	Details   GetOrderOutDetails `json:"details"`
+
+	NextStatuses []string `json:"next_statuses"`
 }
 
 type GetOrdersOut struct {
 	Items []GetOrdersOutItem `json:"items"`
-	Total int64              `json:"total"`
+	// Не возвращается, если передан skip_total
+	Total *int64 `json:"total,omitempty"`
+	// Курсор следующей страницы, пустой на последней странице
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
+// Список заказов всегда отсортирован от новых к старым
+const ordersListSort = "-id"
+
 // @Summary Получить список заказов
 // @Security BearerAuth
 // @Tags orders
 // @Produce  json
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
+// @Param cursor query string false "Cursor from next_cursor of the previous page, offset will be ignored"
+// @Param skip_total query bool false "Do not count total"
 // @Success 200 {object} GetOrdersOut
 // @Failure 400 {object} middleware.ErrorJSON
 // @Router /orders [get]
@@ -53,6 +67,20 @@ func (ctrl *Controller) GetOrdersHandler(c *fiber.Ctx) error {
 		offset = 0
 	}
 
+	queryParams := &uctypes.QueryGetListParams{
+		Limit:        uint64(limit),
+		Offset:       uint64(offset),
+		WithoutTotal: c.QueryBool("skip_total", false),
+	}
+
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := uctypes.DecodeListCursor(cursorStr)
+		if err != nil || cursor.Sort != ordersListSort {
+			return e.NewErrorFrom(usecase.ErrOrderListCursorInvalid)
+		}
+		queryParams.Cursor = cursor
+	}
+
 	data, total, err := ctrl.orderUC.FindPagedList(c.Context(), usecase.OrderListOptions{
 		OnlyCreated: lo.ToPtr(true),
 		Sort: &[]usecase.OrderListSort{
@@ -61,34 +89,50 @@ func (ctrl *Controller) GetOrdersHandler(c *fiber.Ctx) error {
 				IsDesc: true,
 			},
 		},
-	}, &uctypes.QueryGetListParams{
-		Limit:  uint64(limit),
-		Offset: uint64(offset),
-	})
+	}, queryParams)
 	if err != nil {
 		return err
 	}
 
 	result := GetOrdersOut{
 		Items: make([]GetOrdersOutItem, len(data)),
-		Total: total,
+	}
+
+	if !queryParams.WithoutTotal {
+		result.Total = &total
+	}
+
+	if len(data) == limit {
+		result.NextCursor = uctypes.ListCursor{
+			Sort: ordersListSort,
+			ID:   data[len(data)-1].ID,
+		}.Encode()
 	}
 
 	for i, item := range data {
 		orderSum, _ := item.OrderSum.Float64()
+		netSum, _ := item.NetSum.Float64()
+		taxSum, _ := item.TaxSum.Float64()
+		refundSum, _ := item.RefundSum.Float64()
 
 		result.Items[i] = GetOrdersOutItem{
 			ID:        item.ID,
 			SecretKey: item.SecretKey,
 			OrderSum:  orderSum,
+			NetSum:    netSum,
+			TaxSum:    taxSum,
+			RefundSum: refundSum,
 			Status:    item.Status.String(),
+			Version:   item.Version,
 			Details: GetOrderOutDetails{
 				ClientName:      item.ClientName,
 				ClientSurname:   item.ClientSurname,
 				ClientEmail:     item.ClientEmail,
 				ClientPhone:     item.ClientPhone,
 				DeliveryAddress: item.DeliveryAddress,
+				DeliveryZone:    item.DeliveryZone,
 			},
+			NextStatuses: nextStatusesToOut(item.Status),
 		}
 	}
 
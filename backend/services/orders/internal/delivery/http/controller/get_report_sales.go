@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+)
+
+type GetReportSalesOutItem struct {
+	Period      string  `json:"period"`
+	OrdersCount int64   `json:"orders_count"`
+	Revenue     float64 `json:"revenue"`
+	Tax         float64 `json:"tax"`
+	AvgOrderSum float64 `json:"avg_order_sum"`
+}
+
+type GetReportSalesOut struct {
+	Items       []GetReportSalesOutItem `json:"items"`
+	OrdersCount int64                   `json:"orders_count"`
+	Revenue     float64                 `json:"revenue"`
+	Tax         float64                 `json:"tax"`
+	AvgOrderSum float64                 `json:"avg_order_sum"`
+}
+
+// @Summary Отчет по выручке и среднему чеку за период
+// @Security BearerAuth
+// @Tags reports
+// @Produce  json
+// @Param date_from query string false "Date from (YYYY-MM-DD), default: 30 days ago"
+// @Param date_to query string false "Date to (YYYY-MM-DD), inclusive, default: today"
+// @Param granularity query string false "Granularity" Enums(day, week, month)
+// @Success 200 {object} GetReportSalesOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/reports/sales [get]
+func (ctrl *Controller) GetReportSalesHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	period, err := ctrl.parseReportPeriod(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := ctrl.reportUC.Sales(c.Context(), period)
+	if err != nil {
+		return err
+	}
+
+	revenue, _ := data.Revenue.Float64()
+	tax, _ := data.Tax.Float64()
+	avgOrderSum, _ := data.AvgOrderSum.Float64()
+
+	out := GetReportSalesOut{
+		Items:       make([]GetReportSalesOutItem, len(data.Items)),
+		OrdersCount: data.OrdersCount,
+		Revenue:     revenue,
+		Tax:         tax,
+		AvgOrderSum: avgOrderSum,
+	}
+
+	for i, item := range data.Items {
+		revenue, _ := item.Revenue.Float64()
+		tax, _ := item.Tax.Float64()
+		avgOrderSum, _ := item.AvgOrderSum.Float64()
+
+		out.Items[i] = GetReportSalesOutItem{
+			Period:      item.Period.Format(reportDateLayout),
+			OrdersCount: item.OrdersCount,
+			Revenue:     revenue,
+			Tax:         tax,
+			AvgOrderSum: avgOrderSum,
+		}
+	}
+
+	return c.JSON(out)
+}
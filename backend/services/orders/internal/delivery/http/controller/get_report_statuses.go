@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+)
+
+type GetReportStatusesOutItem struct {
+	Status      string  `json:"status"`
+	OrdersCount int64   `json:"orders_count"`
+	OrdersSum   float64 `json:"orders_sum"`
+}
+
+type GetReportStatusesOut struct {
+	Items []GetReportStatusesOutItem `json:"items"`
+}
+
+// @Summary Отчет по количеству заказов в разрезе статусов
+// @Security BearerAuth
+// @Tags reports
+// @Produce  json
+// @Param date_from query string false "Date from (YYYY-MM-DD), default: 30 days ago"
+// @Param date_to query string false "Date to (YYYY-MM-DD), inclusive, default: today"
+// @Success 200 {object} GetReportStatusesOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/reports/statuses [get]
+func (ctrl *Controller) GetReportStatusesHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	period, err := ctrl.parseReportPeriod(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := ctrl.reportUC.OrdersByStatus(c.Context(), period)
+	if err != nil {
+		return err
+	}
+
+	out := GetReportStatusesOut{
+		Items: make([]GetReportStatusesOutItem, len(data)),
+	}
+
+	for i, item := range data {
+		ordersSum, _ := item.OrdersSum.Float64()
+
+		out.Items[i] = GetReportStatusesOutItem{
+			Status:      item.Status.String(),
+			OrdersCount: item.OrdersCount,
+			OrdersSum:   ordersSum,
+		}
+	}
+
+	return c.JSON(out)
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+type GetReportTopProductsOutItem struct {
+	ProductID   int64   `json:"product_id"`
+	Name        string  `json:"name"`
+	Quantity    int64   `json:"quantity"`
+	Revenue     float64 `json:"revenue"`
+	OrdersCount int64   `json:"orders_count"`
+}
+
+type GetReportTopProductsOut struct {
+	Items []GetReportTopProductsOutItem `json:"items"`
+}
+
+// @Summary Отчет по самым продаваемым товарам
+// @Security BearerAuth
+// @Tags reports
+// @Produce  json
+// @Param date_from query string false "Date from (YYYY-MM-DD), default: 30 days ago"
+// @Param date_to query string false "Date to (YYYY-MM-DD), inclusive, default: today"
+// @Param sort query string false "Sort" Enums(quantity, revenue)
+// @Param limit query int false "Limit"
+// @Success 200 {object} GetReportTopProductsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/reports/top-products [get]
+func (ctrl *Controller) GetReportTopProductsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	period, err := ctrl.parseReportPeriod(c)
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", 10)
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	sortField := usecase.ReportTopProductsSortFieldQuantity
+	switch c.Query("sort") {
+	case "", "quantity":
+	case "revenue":
+		sortField = usecase.ReportTopProductsSortFieldRevenue
+	default:
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid sort")
+	}
+
+	data, err := ctrl.reportUC.TopProducts(c.Context(), period, sortField, uint64(limit))
+	if err != nil {
+		return err
+	}
+
+	out := GetReportTopProductsOut{
+		Items: make([]GetReportTopProductsOutItem, len(data)),
+	}
+
+	for i, item := range data {
+		revenue, _ := item.Revenue.Float64()
+
+		out.Items[i] = GetReportTopProductsOutItem{
+			ProductID:   item.ProductID,
+			Name:        item.Name,
+			Quantity:    item.Quantity,
+			Revenue:     revenue,
+			OrdersCount: item.OrdersCount,
+		}
+	}
+
+	return c.JSON(out)
+}
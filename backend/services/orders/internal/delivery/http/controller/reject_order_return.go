@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+)
+
+// @Summary Отклонить возврат
+// @Security BearerAuth
+// @Tags orders
+// @Param return_id path int true "Return ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/returns/{return_id}/reject [put]
+func (ctrl *Controller) RejectOrderReturnHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	returnID, err := c.ParamsInt("return_id")
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.orderReturnUC.Reject(c.Context(), int64(returnID))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
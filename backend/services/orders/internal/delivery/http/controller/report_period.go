@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+const reportDateLayout = "2006-01-02"
+
+// По умолчанию отчет строится за последние 30 дней
+const reportDefaultDays = 30
+
+type ReportPeriodIn struct {
+	DateFrom    string `query:"date_from" validate:"omitempty,datetime=2006-01-02"`
+	DateTo      string `query:"date_to" validate:"omitempty,datetime=2006-01-02"`
+	Granularity string `query:"granularity" validate:"omitempty,oneof=day week month"`
+}
+
+func (ctrl *Controller) ReportPeriodValidate(in *ReportPeriodIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+func (ctrl *Controller) parseReportPeriod(c *fiber.Ctx) (usecase.ReportPeriodIn, error) {
+	in := &ReportPeriodIn{}
+
+	if err := c.QueryParser(in); err != nil {
+		return usecase.ReportPeriodIn{}, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse query")
+	}
+
+	ok, errMsg := ctrl.ReportPeriodValidate(in)
+	if !ok {
+		return usecase.ReportPeriodIn{}, e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	now := time.Now()
+
+	out := usecase.ReportPeriodIn{
+		DateFrom:    now.AddDate(0, 0, -reportDefaultDays+1),
+		DateTo:      now,
+		Granularity: usecase.ReportGranularityDay,
+	}
+
+	if in.DateFrom != "" {
+		dateFrom, err := time.Parse(reportDateLayout, in.DateFrom)
+		if err != nil {
+			return usecase.ReportPeriodIn{}, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("invalid date_from")
+		}
+		out.DateFrom = dateFrom
+	}
+
+	if in.DateTo != "" {
+		dateTo, err := time.Parse(reportDateLayout, in.DateTo)
+		if err != nil {
+			return usecase.ReportPeriodIn{}, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("invalid date_to")
+		}
+		out.DateTo = dateTo
+	}
+
+	if in.Granularity != "" {
+		out.Granularity = usecase.ReportGranularityMap[in.Granularity]
+	}
+
+	return out, nil
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/validation"
+)
+
+type SetCartItemIn struct {
+	ProductID int64 `json:"product_id" validate:"gte=1"`
+	// Вариант товара, 0 - товар без вариантов
+	VariantID int64 `json:"variant_id" validate:"gte=0"`
+	// 0 - убрать товар из корзины
+	Quantity int32 `json:"quantity" validate:"gte=0"`
+}
+
+func (ctrl *Controller) SetCartItemHandlerValidate(in *SetCartItemIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Установить количество товара в корзине
+// @Tags cart
+// @Accept  json
+// @Produce  json
+// @Param token path string true "Cart token"
+// @Param request body SetCartItemIn true "JSON"
+// @Success 200 {object} CartOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /orders/cart/{token}/items [put]
+func (ctrl *Controller) SetCartItemHandler(c *fiber.Ctx) error {
+
+	token, err := uuid.Parse(c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	in := &SetCartItemIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.SetCartItemHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	data, err := ctrl.cartUC.SetItem(c.Context(), token, cartAccountID(c), in.ProductID, in.VariantID, in.Quantity)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(cartFullToOut(data))
+}
@@ -25,8 +25,12 @@ func (ctrl *Controller) SetOrderStatusHandlerValidate(in *SetOrderStatusIn) (isO
 // @Accept  json
 // @Param request body SetOrderStatusIn true "JSON"
 // @Param id path int true "Order ID"
+// @Param If-Match header string true "Order version from ETag"
 // @Success 200 {string} string "OK"
+// @Header 200 {string} ETag "New order version"
 // @Failure 400 {object} middleware.ErrorJSON
+// @Failure 412 {object} middleware.ErrorJSON
+// @Failure 428 {object} middleware.ErrorJSON
 // @Router /orders/{id}/status [put]
 func (ctrl *Controller) SetOrderStatusHandler(c *fiber.Ctx) error {
 
@@ -57,10 +61,17 @@ func (ctrl *Controller) SetOrderStatusHandler(c *fiber.Ctx) error {
 		return e.ErrBadRequest
 	}
 
-	err = ctrl.orderUC.SetStatus(c.Context(), int64(orderID), status)
+	version, err := parseIfMatch(c)
 	if err != nil {
 		return err
 	}
 
+	order, err := ctrl.orderUC.SetStatus(c.Context(), int64(orderID), status, version)
+	if err != nil {
+		return versionMismatchToHTTP(err)
+	}
+
+	c.Set(fiber.HeaderETag, versionToETag(order.Version))
+
 	return c.SendStatus(fiber.StatusOK)
 }
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+)
+
+type SetOrdersBulkStatusIn struct {
+	IDs    *[]int64                     `json:"ids" validate:"omitempty,min=1,dive,gte=1"`
+	Filter *SetOrdersBulkStatusInFilter `json:"filter"`
+	Status string                       `json:"status" validate:"required"`
+}
+
+type SetOrdersBulkStatusInFilter struct {
+	Statuses []string `json:"statuses"`
+	DateFrom string   `json:"date_from" validate:"omitempty,datetime=2006-01-02"`
+	DateTo   string   `json:"date_to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (ctrl *Controller) SetOrdersBulkStatusHandlerValidate(in *SetOrdersBulkStatusIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Поменять статус нескольким заказам
+// @Description Небольшие пачки обрабатываются сразу (200), большие - в фоне (202), прогресс доступен по /orders/bulk/jobs/{id}
+// @Security BearerAuth
+// @Tags orders
+// @Accept  json
+// @Produce  json
+// @Param request body SetOrdersBulkStatusIn true "JSON"
+// @Success 200 {object} OrderBulkJobOut
+// @Success 202 {object} OrderBulkJobOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /orders/bulk/status [post]
+func (ctrl *Controller) SetOrdersBulkStatusHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	in := &SetOrdersBulkStatusIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.SetOrdersBulkStatusHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	status, ok := domain.OrderStatusMap[in.Status]
+	if !ok {
+		return e.ErrBadRequest
+	}
+
+	input := usecase.OrderBulkSetStatusIn{
+		IDs:    in.IDs,
+		Status: status,
+	}
+
+	if in.Filter != nil {
+		filter, err := parseOrdersBulkFilter(in.Filter)
+		if err != nil {
+			return err
+		}
+		input.Filter = filter
+	}
+
+	data, err := ctrl.orderBulkJobUC.CreateSetStatus(c.Context(), input)
+	if err != nil {
+		return err
+	}
+
+	if !data.Job.IsFinished() {
+		c.Status(fiber.StatusAccepted)
+	}
+
+	return c.JSON(orderBulkJobToOut(data))
+}
+
+func parseOrdersBulkFilter(in *SetOrdersBulkStatusInFilter) (*usecase.OrderBulkFilterIn, error) {
+	out := &usecase.OrderBulkFilterIn{}
+
+	if len(in.Statuses) > 0 {
+		statuses := make([]domain.OrderStatus, 0, len(in.Statuses))
+		for _, item := range in.Statuses {
+			status, ok := domain.OrderStatusMap[item]
+			if !ok {
+				return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid filter status")
+			}
+			statuses = append(statuses, status)
+		}
+		out.Statuses = &statuses
+	}
+
+	if in.DateFrom != "" {
+		dateFrom, err := time.Parse(reportDateLayout, in.DateFrom)
+		if err != nil {
+			return nil, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("invalid date_from")
+		}
+		out.CreatedFrom = &dateFrom
+	}
+
+	// Дата окончания включается в период целиком
+	if in.DateTo != "" {
+		dateTo, err := time.Parse(reportDateLayout, in.DateTo)
+		if err != nil {
+			return nil, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("invalid date_to")
+		}
+		dateTo = dateTo.AddDate(0, 0, 1)
+		out.CreatedTo = &dateTo
+	}
+
+	return out, nil
+}
@@ -20,12 +20,15 @@ type UpdateOrderInDetails struct {
 	ClientEmail     string `json:"client_email" validate:"required,email"`
 	ClientPhone     string `json:"client_phone" validate:"required,min=1,max=20"`
 	DeliveryAddress string `json:"delivery_address" validate:"required,min=1,max=150"`
+	// Зона доставки, по ней выбирается склад, пустая - склады по приоритету
+	DeliveryZone string `json:"delivery_zone" validate:"max=100"`
 }
 
 type UpdateOrderInProduct struct {
-	ID       int64   `json:"id" validate:"gte=0"`
-	Quantity int32   `json:"quantity" validate:"gte=1"`
-	Price    float64 `json:"price" validate:"gte=0"`
+	ID        int64   `json:"id" validate:"gte=0"`
+	VariantID int64   `json:"variant_id" validate:"gte=0"`
+	Quantity  int32   `json:"quantity" validate:"gte=1"`
+	Price     float64 `json:"price" validate:"gte=0"`
 }
 
 func (ctrl *Controller) UpdateOrderHandlerValidate(in *UpdateOrderIn) (isOk bool, errMsg []string) {
@@ -41,8 +44,12 @@ func (ctrl *Controller) UpdateOrderHandlerValidate(in *UpdateOrderIn) (isOk bool
 // @Accept  json
 // @Param request body UpdateOrderIn true "JSON"
 // @Param id path int true "Order ID"
+// @Param If-Match header string true "Order version from ETag"
 // @Success 200 {string} string "OK"
+// @Header 200 {string} ETag "New order version"
 // @Failure 400 {object} middleware.ErrorJSON
+// @Failure 412 {object} middleware.ErrorJSON
+// @Failure 428 {object} middleware.ErrorJSON
 // @Router /orders/{id} [put]
 func (ctrl *Controller) UpdateOrderHandler(c *fiber.Ctx) error {
 
@@ -68,29 +75,39 @@ func (ctrl *Controller) UpdateOrderHandler(c *fiber.Ctx) error {
 		return err
 	}
 
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
 	updateIn := usecase.OrderUpdateIn{
+		Version: version,
 		Details: usecase.OrderDataDetailsIn{
 			ClientName:      in.Details.ClientName,
 			ClientSurname:   in.Details.ClientSurname,
 			ClientEmail:     in.Details.ClientEmail,
 			ClientPhone:     in.Details.ClientPhone,
 			DeliveryAddress: in.Details.DeliveryAddress,
+			DeliveryZone:    in.Details.DeliveryZone,
 		},
 		Products: make([]usecase.OrderProductWithPrice, len(in.Products)),
 	}
 
 	for i, item := range in.Products {
 		updateIn.Products[i] = usecase.OrderProductWithPrice{
-			ID:       item.ID,
-			Quantity: item.Quantity,
-			Price:    decimal.NewFromFloat(item.Price),
+			ID:        item.ID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+			Price:     decimal.NewFromFloat(item.Price),
 		}
 	}
 
-	err = ctrl.orderUC.Update(c.Context(), int64(orderID), updateIn)
+	order, err := ctrl.orderUC.Update(c.Context(), int64(orderID), updateIn)
 	if err != nil {
-		return err
+		return versionMismatchToHTTP(err)
 	}
 
+	c.Set(fiber.HeaderETag, versionToETag(order.Version))
+
 	return c.SendStatus(fiber.StatusOK)
 }
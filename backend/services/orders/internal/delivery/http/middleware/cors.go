@@ -10,5 +10,7 @@ import (
 func Cors(corsAllowOrigins []string) func(*fiber.Ctx) error {
 	return cors.New(cors.Config{
 		AllowOrigins: strings.Join(corsAllowOrigins, ", "),
+		// Версия сущности для If-Match
+		ExposeHeaders: fiber.HeaderETag,
 	})
 }
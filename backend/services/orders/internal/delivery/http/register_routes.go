@@ -22,10 +22,26 @@ func RegisterRoutes(app *fiber.App, config config.Config, ctrl *controller.Contr
 		serviceGroup.Get("/swagger/*", swagger.HandlerDefault)
 	}
 
+	serviceGroup.Post("/cart", ctrl.CreateCartHandler)
+	serviceGroup.Get("/cart/:token<guid>", ctrl.GetCartHandler)
+	serviceGroup.Put("/cart/:token<guid>/items", ctrl.SetCartItemHandler)
+	serviceGroup.Delete("/cart/:token<guid>/items", ctrl.ClearCartHandler)
+	serviceGroup.Post("/cart/:token<guid>/checkout", ctrl.CheckoutCartHandler)
 	serviceGroup.Post("/", ctrl.CreateOrderHandler)
 	serviceGroup.Put("/:id<min(1)>", ctrl.UpdateOrderHandler)
 	serviceGroup.Put("/:id<min(1)>/status", ctrl.SetOrderStatusHandler)
+	serviceGroup.Post("/:id<min(1)>/reserve/extend", ctrl.ExtendOrderStockReserveHandler)
+	serviceGroup.Post("/bulk/status", ctrl.SetOrdersBulkStatusHandler)
+	serviceGroup.Get("/bulk/jobs/:id<min(1)>", ctrl.GetOrderBulkJobHandler)
+	serviceGroup.Post("/:id<min(1)>/returns", ctrl.CreateOrderReturnHandler)
+	serviceGroup.Get("/:id<min(1)>/returns", ctrl.GetOrderReturnsHandler)
+	serviceGroup.Put("/returns/:return_id<min(1)>/approve", ctrl.ApproveOrderReturnHandler)
+	serviceGroup.Put("/returns/:return_id<min(1)>/reject", ctrl.RejectOrderReturnHandler)
 	serviceGroup.Get("/", ctrl.GetOrdersHandler)
+	serviceGroup.Get("/reports/sales", ctrl.GetReportSalesHandler)
+	serviceGroup.Get("/reports/sales/export", ctrl.ExportReportSalesHandler)
+	serviceGroup.Get("/reports/statuses", ctrl.GetReportStatusesHandler)
+	serviceGroup.Get("/reports/top-products", ctrl.GetReportTopProductsHandler)
 	serviceGroup.Get("/:id<min(1)>", ctrl.GetOrderHandler)
 	serviceGroup.Get("/:id<min(1)>/:secret_key<guid>", ctrl.GetOrderWithSecretKeyHandler)
 }
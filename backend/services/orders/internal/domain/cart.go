@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Корзина покупателя. ID корзины - токен, по которому к ней обращается анонимный покупатель
+type Cart struct {
+	ID uuid.UUID
+	// Аккаунт покупателя, nil - анонимная корзина
+	AccountID *uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewCart(accountID *uuid.UUID) *Cart {
+	return &Cart{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Корзина аккаунта доступна только этому аккаунту, анонимная - любому, кто знает токен
+func (c *Cart) IsAccessibleBy(accountID *uuid.UUID) bool {
+	if c.AccountID == nil {
+		return true
+	}
+
+	return accountID != nil && *c.AccountID == *accountID
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/shopspring/decimal"
+)
+
+var ErrCartItemQuantityLess1 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid quantity")
+var ErrCartItemPriceLess0 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid price")
+
+type CartItem struct {
+	CartID    uuid.UUID
+	ProductID int64
+	// Вариант товара, 0 - товар без вариантов
+	VariantID int64
+	Quantity  int32
+	// Последняя показанная покупателю цена, по ней определяется изменение цены
+	Price decimal.Decimal
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewCartItem(cartID uuid.UUID, productID int64, variantID int64, quantity int32, price decimal.Decimal) (*CartItem, error) {
+	item := &CartItem{
+		CartID:    cartID,
+		ProductID: productID,
+		VariantID: variantID,
+		CreatedAt: time.Now(),
+	}
+
+	err := item.SetQuantity(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = item.SetPrice(price)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (ci *CartItem) SetQuantity(quantity int32) error {
+	if quantity < 1 {
+		return ErrCartItemQuantityLess1
+	}
+	ci.Quantity = quantity
+
+	return nil
+}
+
+func (ci *CartItem) SetPrice(price decimal.Decimal) error {
+	if price.LessThan(decimal.Zero) {
+		return ErrCartItemPriceLess0
+	}
+	ci.Price = price
+
+	return nil
+}
+
+// Стоимость позиции по последней показанной цене
+func (ci *CartItem) Sum() decimal.Decimal {
+	return ci.Price.Mul(decimal.NewFromInt(int64(ci.Quantity)))
+}
@@ -10,52 +10,25 @@ import (
 
 var ErrOrderCantSetStatus = e.NewErrorFrom(e.ErrBadRequest).SetMessage("cant set status")
 var ErrOrderSumLess1 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid sum")
-
-type OrderStatus int
-
-const (
-	OrderStatusNew      OrderStatus = 0
-	OrderStatusCreated  OrderStatus = 2
-	OrderStatusInWork   OrderStatus = 3
-	OrderStatusFinished OrderStatus = 10
-	OrderStatusCanceled OrderStatus = 99
-)
-
-func (s OrderStatus) String() string {
-	switch s {
-	case OrderStatusNew:
-		return "new"
-	case OrderStatusCreated:
-		return "created"
-	case OrderStatusInWork:
-		return "in_work"
-	case OrderStatusFinished:
-		return "finished"
-	case OrderStatusCanceled:
-		return "canceled"
-	default:
-		return ""
-	}
-}
-
-var OrderStatusMap = map[string]OrderStatus{
-	"new":      OrderStatusNew,
-	"created":  OrderStatusCreated,
-	"in_work":  OrderStatusInWork,
-	"finished": OrderStatusFinished,
-	"canceled": OrderStatusCanceled,
-}
+var ErrOrderRefundSumInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("refund sum exceeds order sum")
+var ErrOrderVersionMismatch = e.NewErrorFrom(e.ErrConflict).SetMessage("order was changed by another request")
 
 type Order struct {
 	ID              int64
 	Status          OrderStatus
 	OrderSum        decimal.Decimal
+	NetSum          decimal.Decimal
+	TaxSum          decimal.Decimal
+	RefundSum       decimal.Decimal
 	SecretKey       uuid.UUID
 	ClientName      string
 	ClientSurname   string
 	ClientEmail     string
 	ClientPhone     string
 	DeliveryAddress string
+	// Зона доставки, по ней выбирается склад для брони товаров
+	DeliveryZone string
+	Version      int64
 
 	CreatedAt time.Time
 	UpdatedAt *time.Time
@@ -67,32 +40,31 @@ func NewOrder(id int64) *Order {
 		ID:        id,
 		Status:    OrderStatusNew,
 		SecretKey: uuid.New(),
+		Version:   1,
 		CreatedAt: time.Now(),
 	}
 }
 
+// Версия растет при каждом изменении заказа через API и используется для оптимистической блокировки
+func (p *Order) CheckVersion(version int64) error {
+	if p.Version != version {
+		return ErrOrderVersionMismatch
+	}
+
+	return nil
+}
+
+func (p *Order) IncVersion() {
+	p.Version++
+}
+
 func (p *Order) SetStatus(status OrderStatus) error {
-	switch status {
-	case OrderStatusNew:
-		if p.Status != OrderStatusNew {
-			return ErrOrderCantSetStatus
-		}
-	case OrderStatusCreated:
-		if p.Status != OrderStatusNew {
-			return ErrOrderCantSetStatus
-		}
-	case OrderStatusInWork:
-		if p.Status != OrderStatusCreated {
-			return ErrOrderCantSetStatus
-		}
-	case OrderStatusFinished:
-		if p.Status != OrderStatusInWork {
-			return ErrOrderCantSetStatus
-		}
-	case OrderStatusCanceled:
-		if p.Status == OrderStatusFinished {
-			return ErrOrderCantSetStatus
-		}
+	if p.Status == status {
+		return nil
+	}
+
+	if _, ok := FindOrderStatusTransition(p.Status, status); !ok {
+		return ErrOrderCantSetStatus
 	}
 
 	p.Status = status
@@ -109,3 +81,41 @@ func (p *Order) SetOrderSum(value decimal.Decimal) error {
 
 	return nil
 }
+
+// Пересчитывает суммы заказа по его строкам
+func (p *Order) SetSumsFromProducts(products []*OrderProduct) error {
+	orderSum := decimal.Zero
+	netSum := decimal.Zero
+	taxSum := decimal.Zero
+
+	for _, product := range products {
+		orderSum = orderSum.Add(product.GrossSum())
+		netSum = netSum.Add(product.NetSum)
+		taxSum = taxSum.Add(product.TaxSum)
+	}
+
+	err := p.SetOrderSum(orderSum)
+	if err != nil {
+		return err
+	}
+
+	p.NetSum = netSum
+	p.TaxSum = taxSum
+
+	return nil
+}
+
+// Сумма, которую еще можно вернуть клиенту
+func (p *Order) RefundAvailable() decimal.Decimal {
+	return p.OrderSum.Sub(p.RefundSum)
+}
+
+func (p *Order) AddRefundSum(value decimal.Decimal) error {
+	if value.LessThan(decimal.Zero) || value.GreaterThan(p.RefundAvailable()) {
+		return ErrOrderRefundSumInvalid
+	}
+
+	p.RefundSum = p.RefundSum.Add(value)
+
+	return nil
+}
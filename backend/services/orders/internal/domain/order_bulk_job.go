@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"time"
+)
+
+type OrderBulkJobStatus int
+
+const (
+	OrderBulkJobStatusPending    OrderBulkJobStatus = 0
+	OrderBulkJobStatusProcessing OrderBulkJobStatus = 1
+	OrderBulkJobStatusCompleted  OrderBulkJobStatus = 10
+	OrderBulkJobStatusFailed     OrderBulkJobStatus = 20
+)
+
+var OrderBulkJobStatuses = map[OrderBulkJobStatus]string{
+	OrderBulkJobStatusPending:    "pending",
+	OrderBulkJobStatusProcessing: "processing",
+	OrderBulkJobStatusCompleted:  "completed",
+}
+
+func (s OrderBulkJobStatus) String() string {
+	return OrderBulkJobStatuses[s]
+}
+
+// Задача на массовую смену статуса заказов
+type OrderBulkJob struct {
+	ID           int64
+	Status       OrderBulkJobStatus
+	TargetStatus OrderStatus
+	Total        int32
+	Processed    int32
+	Failed       int32
+	// Срок блокировки задачи обработчиком
+	LockedUntil *time.Time
+	// Причина сбоя задачи
+	Error string
+
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+func NewOrderBulkJob(targetStatus OrderStatus, total int32) *OrderBulkJob {
+	return &OrderBulkJob{
+		Status:       OrderBulkJobStatusPending,
+		TargetStatus: targetStatus,
+		Total:        total,
+		CreatedAt:    time.Now(),
+	}
+}
+
+func (j *OrderBulkJob) IsFinished() bool {
+	return j.Status == OrderBulkJobStatusCompleted || j.Status == OrderBulkJobStatusFailed
+}
+
+func (j *OrderBulkJob) Start(lockedUntil time.Time) {
+	j.Status = OrderBulkJobStatusProcessing
+	j.LockedUntil = &lockedUntil
+}
+
+// Обработчик продлевает блокировку, пока обрабатывает задачу
+func (j *OrderBulkJob) ProlongLock(lockedUntil time.Time) {
+	j.LockedUntil = &lockedUntil
+}
+
+// Учитывает результат обработки одного заказа
+func (j *OrderBulkJob) AddResult(isOk bool) {
+	j.Processed++
+	if !isOk {
+		j.Failed++
+	}
+}
+
+func (j *OrderBulkJob) Finish() {
+	j.Status = OrderBulkJobStatusCompleted
+	j.LockedUntil = nil
+	now := time.Now()
+	j.FinishedAt = &now
+}
+
+// Задача прервана ошибкой, необработанные заказы остаются необработанными
+func (j *OrderBulkJob) Fail(err error) {
+	j.Status = OrderBulkJobStatusFailed
+	j.LockedUntil = nil
+	j.Error = truncateOrderBulkJobError(err.Error())
+	now := time.Now()
+	j.FinishedAt = &now
+}
+
+const OrderBulkJobItemErrorMaxLen = 1000
+
+// Результат обработки одного заказа в задаче
+type OrderBulkJobItem struct {
+	JobID       int64
+	OrderID     int64
+	IsProcessed bool
+	IsOk        bool
+	Error       string
+}
+
+func NewOrderBulkJobItem(jobID int64, orderID int64) *OrderBulkJobItem {
+	return &OrderBulkJobItem{
+		JobID:   jobID,
+		OrderID: orderID,
+	}
+}
+
+func (i *OrderBulkJobItem) SetResult(err error) {
+	i.IsProcessed = true
+	i.IsOk = err == nil
+	i.Error = ""
+
+	if err != nil {
+		i.Error = truncateOrderBulkJobError(err.Error())
+	}
+}
+
+func truncateOrderBulkJobError(value string) string {
+	if runes := []rune(value); len(runes) > OrderBulkJobItemErrorMaxLen {
+		return string(runes[:OrderBulkJobItemErrorMaxLen])
+	}
+
+	return value
+}
@@ -9,19 +9,31 @@ import (
 
 var ErrOrderProductQuantityLess1 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid quantity")
 var ErrOrderProductPriceLess1 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid price")
+var ErrOrderProductInvalidTaxRate = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid tax rate")
 
 type OrderProduct struct {
 	ProductID int64
+	// Вариант товара, 0 - товар без вариантов
+	VariantID int64
 	OrderID   int64
 	Quantity  int32
 	Price     decimal.Decimal
+	TaxRate   decimal.Decimal
+	NetSum    decimal.Decimal
+	TaxSum    decimal.Decimal
+
+	// Данные товара на момент оформления, не меняются при редактировании или удалении товара
+	Name            string
+	SKU             string
+	ImagePreviewURL string
 
 	CreatedAt time.Time
 }
 
-func NewOrderProduct(orderID int64, productID int64, quantity int32, price decimal.Decimal) (*OrderProduct, error) {
+func NewOrderProduct(orderID int64, productID int64, variantID int64, quantity int32, price decimal.Decimal, taxRate decimal.Decimal) (*OrderProduct, error) {
 	item := &OrderProduct{
 		ProductID: productID,
+		VariantID: variantID,
 		OrderID:   orderID,
 		CreatedAt: time.Now(),
 	}
@@ -36,6 +48,11 @@ func NewOrderProduct(orderID int64, productID int64, quantity int32, price decim
 		return nil, err
 	}
 
+	err = item.SetTaxRate(taxRate)
+	if err != nil {
+		return nil, err
+	}
+
 	return item, nil
 }
 
@@ -44,6 +61,7 @@ func (op *OrderProduct) SetQuantity(quantity int32) error {
 		return ErrOrderProductQuantityLess1
 	}
 	op.Quantity = quantity
+	op.calcTax()
 
 	return nil
 }
@@ -53,6 +71,47 @@ func (op *OrderProduct) SetPrice(price decimal.Decimal) error {
 		return ErrOrderProductPriceLess1
 	}
 	op.Price = price
+	op.calcTax()
 
 	return nil
 }
+
+func (op *OrderProduct) SetTaxRate(rate decimal.Decimal) error {
+	if rate.LessThan(decimal.Zero) || rate.GreaterThan(decimal.NewFromInt(100)) {
+		return ErrOrderProductInvalidTaxRate
+	}
+	op.TaxRate = rate
+	op.calcTax()
+
+	return nil
+}
+
+func (op *OrderProduct) SetSnapshot(name string, sku string, imagePreviewURL string) {
+	op.Name = name
+	op.SKU = sku
+	op.ImagePreviewURL = imagePreviewURL
+}
+
+// Стоимость строки с НДС
+func (op *OrderProduct) GrossSum() decimal.Decimal {
+	return op.Price.Mul(decimal.NewFromInt(int64(op.Quantity)))
+}
+
+// Цены включают НДС, выделяем налог из стоимости строки
+func (op *OrderProduct) calcTax() {
+	gross := op.GrossSum()
+
+	op.TaxSum = CalcIncludedTax(gross, op.TaxRate)
+	op.NetSum = gross.Sub(op.TaxSum)
+}
+
+// Сумма НДС, включенная в сумму gross, по ставке rate (в процентах)
+func CalcIncludedTax(gross decimal.Decimal, rate decimal.Decimal) decimal.Decimal {
+	if rate.IsZero() {
+		return decimal.Zero
+	}
+
+	hundred := decimal.NewFromInt(100)
+
+	return gross.Mul(rate).Div(hundred.Add(rate)).Round(2)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/m11ano/e"
+	"github.com/shopspring/decimal"
+)
+
+var ErrOrderReturnCantSetStatus = e.NewErrorFrom(e.ErrBadRequest).SetMessage("cant set return status")
+var ErrOrderReturnRefundSumLess0 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid refund sum")
+
+type OrderReturnStatus int
+
+const (
+	OrderReturnStatusPending    OrderReturnStatus = 0
+	OrderReturnStatusProcessing OrderReturnStatus = 1
+	OrderReturnStatusCompleted  OrderReturnStatus = 10
+	OrderReturnStatusRejected   OrderReturnStatus = 99
+)
+
+var OrderReturnStatuses = map[OrderReturnStatus]string{
+	OrderReturnStatusPending:    "pending",
+	OrderReturnStatusProcessing: "processing",
+	OrderReturnStatusCompleted:  "completed",
+	OrderReturnStatusRejected:   "rejected",
+}
+
+// Допустимые переходы между статусами возврата
+var orderReturnStatusTransitions = map[OrderReturnStatus][]OrderReturnStatus{
+	OrderReturnStatusPending:    {OrderReturnStatusProcessing, OrderReturnStatusRejected},
+	OrderReturnStatusProcessing: {OrderReturnStatusCompleted, OrderReturnStatusPending},
+}
+
+func (s OrderReturnStatus) String() string {
+	return OrderReturnStatuses[s]
+}
+
+type OrderReturn struct {
+	ID        int64
+	OrderID   int64
+	Status    OrderReturnStatus
+	Reason    string
+	RefundSum decimal.Decimal
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewOrderReturn(orderID int64, reason string) *OrderReturn {
+	return &OrderReturn{
+		OrderID:   orderID,
+		Status:    OrderReturnStatusPending,
+		Reason:    reason,
+		RefundSum: decimal.Zero,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (r *OrderReturn) SetStatus(status OrderReturnStatus) error {
+	if r.Status == status {
+		return nil
+	}
+
+	for _, next := range orderReturnStatusTransitions[r.Status] {
+		if next == status {
+			r.Status = status
+			return nil
+		}
+	}
+
+	return ErrOrderReturnCantSetStatus
+}
+
+func (r *OrderReturn) SetRefundSum(value decimal.Decimal) error {
+	if value.LessThan(decimal.Zero) {
+		return ErrOrderReturnRefundSumLess0
+	}
+
+	r.RefundSum = value
+
+	return nil
+}
+
+type OrderReturnProduct struct {
+	ReturnID  int64
+	ProductID int64
+	VariantID int64
+	Quantity  int32
+	Price     decimal.Decimal
+
+	CreatedAt time.Time
+}
+
+func NewOrderReturnProduct(returnID int64, productID int64, variantID int64, quantity int32, price decimal.Decimal) (*OrderReturnProduct, error) {
+	item := &OrderReturnProduct{
+		ReturnID:  returnID,
+		ProductID: productID,
+		VariantID: variantID,
+		Price:     price,
+		CreatedAt: time.Now(),
+	}
+
+	if quantity < 1 {
+		return nil, ErrOrderProductQuantityLess1
+	}
+	item.Quantity = quantity
+
+	return item, nil
+}
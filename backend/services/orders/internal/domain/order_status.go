@@ -0,0 +1,152 @@
+package domain
+
+type OrderStatus int
+
+const (
+	OrderStatusNew       OrderStatus = 0
+	OrderStatusCreated   OrderStatus = 2
+	OrderStatusInWork    OrderStatus = 3
+	OrderStatusPaid      OrderStatus = 4
+	OrderStatusShipped   OrderStatus = 5
+	OrderStatusDelivered OrderStatus = 6
+	OrderStatusFinished  OrderStatus = 10
+	OrderStatusReturned  OrderStatus = 20
+	OrderStatusCanceled  OrderStatus = 99
+)
+
+// Что происходит с зарезервированными под заказ товарами при переходе
+type OrderStockAction int
+
+const (
+	// Резерв не меняется
+	OrderStockActionNone OrderStockAction = iota
+	// Резерв возвращается на склад
+	OrderStockActionRelease
+	// Резерв списывается со склада окончательно
+	OrderStockActionConsume
+)
+
+type OrderStatusInfo struct {
+	Name string
+	// Можно ли менять состав и данные заказа
+	IsEditable bool
+	// Можно ли оформить возврат по заказу
+	AllowReturns bool
+	// Товары заказа забронированы на складе
+	HoldsStock bool
+	// Покупатель может оставить отзывы на товары заказа
+	AllowReviews bool
+}
+
+type OrderStatusTransition struct {
+	From        OrderStatus
+	To          OrderStatus
+	StockAction OrderStockAction
+	// Переход выполняется только системой, через API его выполнить нельзя
+	IsSystem bool
+}
+
+var OrderStatuses = map[OrderStatus]OrderStatusInfo{
+	OrderStatusNew:       {Name: "new", IsEditable: true, HoldsStock: true},
+	OrderStatusCreated:   {Name: "created", IsEditable: true, HoldsStock: true},
+	OrderStatusPaid:      {Name: "paid", IsEditable: true, HoldsStock: true},
+	OrderStatusInWork:    {Name: "in_work", IsEditable: true, HoldsStock: true},
+	OrderStatusShipped:   {Name: "shipped", HoldsStock: true},
+	OrderStatusDelivered: {Name: "delivered", HoldsStock: true},
+	OrderStatusFinished:  {Name: "finished", AllowReturns: true, AllowReviews: true},
+	OrderStatusReturned:  {Name: "returned"},
+	OrderStatusCanceled:  {Name: "canceled"},
+}
+
+// Таблица допустимых переходов между статусами заказа
+var OrderStatusTransitions = []OrderStatusTransition{
+	{From: OrderStatusNew, To: OrderStatusCreated, IsSystem: true},
+	{From: OrderStatusNew, To: OrderStatusCanceled, StockAction: OrderStockActionRelease, IsSystem: true},
+
+	{From: OrderStatusCreated, To: OrderStatusPaid},
+	{From: OrderStatusCreated, To: OrderStatusInWork},
+	{From: OrderStatusCreated, To: OrderStatusCanceled, StockAction: OrderStockActionRelease},
+
+	{From: OrderStatusPaid, To: OrderStatusInWork},
+	{From: OrderStatusPaid, To: OrderStatusCanceled, StockAction: OrderStockActionRelease},
+
+	{From: OrderStatusInWork, To: OrderStatusShipped},
+	{From: OrderStatusInWork, To: OrderStatusFinished, StockAction: OrderStockActionConsume},
+	{From: OrderStatusInWork, To: OrderStatusCanceled, StockAction: OrderStockActionRelease},
+
+	{From: OrderStatusShipped, To: OrderStatusDelivered},
+	{From: OrderStatusShipped, To: OrderStatusReturned, StockAction: OrderStockActionRelease},
+
+	{From: OrderStatusDelivered, To: OrderStatusFinished, StockAction: OrderStockActionConsume},
+	{From: OrderStatusDelivered, To: OrderStatusReturned, StockAction: OrderStockActionRelease},
+
+	{From: OrderStatusFinished, To: OrderStatusReturned},
+}
+
+var OrderStatusMap = map[string]OrderStatus{}
+
+func init() {
+	for status, info := range OrderStatuses {
+		OrderStatusMap[info.Name] = status
+	}
+}
+
+func (s OrderStatus) String() string {
+	return OrderStatuses[s].Name
+}
+
+func (s OrderStatus) IsEditable() bool {
+	return OrderStatuses[s].IsEditable
+}
+
+func (s OrderStatus) AllowReturns() bool {
+	return OrderStatuses[s].AllowReturns
+}
+
+func (s OrderStatus) AllowReviews() bool {
+	return OrderStatuses[s].AllowReviews
+}
+
+func (s OrderStatus) HoldsStock() bool {
+	return OrderStatuses[s].HoldsStock
+}
+
+// Товары заказа в этом статусе уже списаны со склада
+func (s OrderStatus) IsStockConsumed() bool {
+	for _, transition := range OrderStatusTransitions {
+		if transition.To == s && transition.StockAction == OrderStockActionConsume {
+			return true
+		}
+	}
+
+	return false
+}
+
+func FindOrderStatusTransition(from OrderStatus, to OrderStatus) (OrderStatusTransition, bool) {
+	for _, transition := range OrderStatusTransitions {
+		if transition.From == from && transition.To == to {
+			return transition, true
+		}
+	}
+
+	return OrderStatusTransition{}, false
+}
+
+// Статусы, в которые можно перевести заказ из текущего
+func (s OrderStatus) NextStatuses(withSystem bool) []OrderStatus {
+	result := []OrderStatus{}
+
+	for _, transition := range OrderStatusTransitions {
+		if transition.From != s {
+			continue
+		}
+
+		if transition.IsSystem && !withSystem {
+			continue
+		}
+
+		result = append(result, transition.To)
+	}
+
+	return result
+}
@@ -42,6 +42,20 @@ type Config struct {
 			} `yaml:"products"`
 		} `yaml:"clients"`
 	} `yaml:"grpc"`
+	Tax struct {
+		// Ставка НДС в процентах для товаров без собственной ставки
+		DefaultRate string `yaml:"default_rate" env:"TAX_DEFAULT_RATE" env-default:"20"`
+	} `yaml:"tax"`
+	Reports struct {
+		Timezone string `yaml:"timezone" env:"REPORTS_TIMEZONE" env-default:"UTC"`
+	} `yaml:"reports"`
+	Bulk struct {
+		// Сколько заказов обрабатывается синхронно, больше - в фоне
+		SyncLimit int `yaml:"sync_limit" env:"BULK_SYNC_LIMIT" env-default:"20"`
+		MaxOrders int `yaml:"max_orders" env:"BULK_MAX_ORDERS" env-default:"1000"`
+		// Срок блокировки задачи обработчиком, по истечении задачу подхватит другой экземпляр сервиса
+		LockSeconds int `yaml:"lock_seconds" env:"BULK_LOCK_SECONDS" env-default:"300"`
+	} `yaml:"bulk"`
 	Secrets struct {
 		JWT string `yaml:"jwt" env:"SECRETS_JWT" env-default:""`
 	} `yaml:"secrets"`
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/pkg/dbhelper"
+)
+
+const (
+	cartTable = "cart"
+)
+
+type DBCart struct {
+	ID        uuid.UUID  `db:"id"`
+	AccountID *uuid.UUID `db:"account_id"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+var (
+	cartTableFields = []string{}
+	cartDBSchema    = &DBCart{}
+)
+
+func init() {
+	cartTableFields = dbhelper.ExtractDBFields(cartDBSchema)
+}
+
+type Cart struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewCart(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *Cart {
+	return &Cart{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Cart) dbToDomain(db *DBCart) *domain.Cart {
+	return &domain.Cart{
+		ID:        db.ID,
+		AccountID: db.AccountID,
+
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
+	}
+}
+
+func (r *Cart) buildWhereForList(listOptions usecase.CartListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.IDs != nil {
+		where = append(where, squirrel.Eq{"id": *listOptions.IDs})
+	}
+
+	if listOptions.AccountID != nil {
+		where = append(where, squirrel.Eq{"account_id": *listOptions.AccountID})
+	}
+
+	return where
+}
+
+func (r *Cart) FindList(ctx context.Context, listOptions usecase.CartListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Cart, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(cartTableFields...).From(cartTable).Where(where).OrderBy("id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBCart{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.Cart, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *Cart) FindOneByID(ctx context.Context, id uuid.UUID, queryParams *uctypes.QueryGetOneParams) (*domain.Cart, error) {
+	q := r.qb.Select(cartTableFields...).From(cartTable).Where(squirrel.Eq{"id": id})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBCart{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+func (r *Cart) Create(ctx context.Context, item *domain.Cart) error {
+	dataMap, err := dbhelper.StructToDBMap(item, cartDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(cartTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+// Создание корзины аккаунта. Если у аккаунта корзина уже есть, новая не создается и возвращается false
+func (r *Cart) CreateForAccount(ctx context.Context, item *domain.Cart) (bool, error) {
+	dataMap, err := dbhelper.StructToDBMap(item, cartDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return false, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(cartTable).SetMap(dataMap).Suffix("ON CONFLICT (account_id) WHERE account_id IS NOT NULL DO NOTHING").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return false, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	tag, err := r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return false, convErr
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
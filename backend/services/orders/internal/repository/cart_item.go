@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/pkg/dbhelper"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	cartItemTable = "cart_item"
+)
+
+type DBCartItem struct {
+	CartID    uuid.UUID       `db:"cart_id"`
+	ProductID int64           `db:"product_id"`
+	VariantID int64           `db:"variant_id"`
+	Quantity  int32           `db:"quantity"`
+	Price     decimal.Decimal `db:"price"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+var (
+	cartItemTableFields = []string{}
+	cartItemDBSchema    = &DBCartItem{}
+)
+
+func init() {
+	cartItemTableFields = dbhelper.ExtractDBFields(cartItemDBSchema)
+}
+
+type CartItem struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewCartItem(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *CartItem {
+	return &CartItem{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *CartItem) dbToDomain(db *DBCartItem) *domain.CartItem {
+	return &domain.CartItem{
+		CartID:    db.CartID,
+		ProductID: db.ProductID,
+		VariantID: db.VariantID,
+		Quantity:  db.Quantity,
+		Price:     db.Price,
+
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
+	}
+}
+
+func (r *CartItem) buildWhereForList(listOptions usecase.CartItemListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.CartID != nil {
+		where = append(where, squirrel.Eq{"cart_id": *listOptions.CartID})
+	}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.VariantID != nil {
+		where = append(where, squirrel.Eq{"variant_id": *listOptions.VariantID})
+	}
+
+	return where
+}
+
+func (r *CartItem) FindList(ctx context.Context, listOptions usecase.CartItemListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.CartItem, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(cartItemTableFields...).From(cartItemTable).Where(where).OrderBy("created_at ASC", "product_id ASC", "variant_id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBCartItem{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.CartItem, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *CartItem) Create(ctx context.Context, item *domain.CartItem) error {
+	dataMap, err := dbhelper.StructToDBMap(item, cartItemDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(cartItemTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *CartItem) Update(ctx context.Context, item *domain.CartItem) error {
+	dataMap, err := dbhelper.StructToDBMap(item, cartItemDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "cart_id")
+	delete(dataMap, "product_id")
+	delete(dataMap, "variant_id")
+	delete(dataMap, "created_at")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Update(cartItemTable).Where(squirrel.Eq{"cart_id": item.CartID, "product_id": item.ProductID, "variant_id": item.VariantID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *CartItem) DeleteByList(ctx context.Context, listOptions usecase.CartItemListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(cartItemTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -28,12 +28,17 @@ type DBOrder struct {
 	ID              int64              `db:"id"`
 	Status          domain.OrderStatus `db:"status"`
 	OrderSum        decimal.Decimal    `db:"order_sum"`
+	NetSum          decimal.Decimal    `db:"net_sum"`
+	TaxSum          decimal.Decimal    `db:"tax_sum"`
+	RefundSum       decimal.Decimal    `db:"refund_sum"`
 	SecretKey       uuid.UUID          `db:"secret_key"`
 	ClientName      string             `db:"client_name"`
 	ClientSurname   string             `db:"client_surname"`
 	ClientEmail     string             `db:"client_email"`
 	ClientPhone     string             `db:"client_phone"`
 	DeliveryAddress string             `db:"delivery_address"`
+	DeliveryZone    string             `db:"delivery_zone"`
+	Version         int64              `db:"version"`
 
 	CreatedAt time.Time  `db:"created_at"`
 	UpdatedAt *time.Time `db:"updated_at"`
@@ -70,12 +75,17 @@ func (r *Order) dbToDomain(db *DBOrder) *domain.Order {
 		ID:              db.ID,
 		Status:          db.Status,
 		OrderSum:        db.OrderSum,
+		NetSum:          db.NetSum,
+		TaxSum:          db.TaxSum,
+		RefundSum:       db.RefundSum,
 		SecretKey:       db.SecretKey,
 		ClientName:      db.ClientName,
 		ClientSurname:   db.ClientSurname,
 		ClientEmail:     db.ClientEmail,
 		ClientPhone:     db.ClientPhone,
 		DeliveryAddress: db.DeliveryAddress,
+		DeliveryZone:    db.DeliveryZone,
+		Version:         db.Version,
 
 		CreatedAt: db.CreatedAt,
 		UpdatedAt: db.UpdatedAt,
@@ -98,6 +108,18 @@ func (r *Order) buildWhereForList(listOptions usecase.OrderListOptions, withDele
 		where = append(where, squirrel.NotEq{"status": domain.OrderStatusNew})
 	}
 
+	if listOptions.Statuses != nil {
+		where = append(where, squirrel.Eq{"status": *listOptions.Statuses})
+	}
+
+	if listOptions.CreatedFrom != nil {
+		where = append(where, squirrel.GtOrEq{"created_at": *listOptions.CreatedFrom})
+	}
+
+	if listOptions.CreatedTo != nil {
+		where = append(where, squirrel.Lt{"created_at": *listOptions.CreatedTo})
+	}
+
 	return where
 }
 
@@ -128,6 +150,19 @@ func (r *Order) buildSortForList(listOptions usecase.OrderListOptions) []string
 	return sort
 }
 
+// Условие выборки записей после курсора, заказы сортируются только по id
+func (r *Order) buildCursorWhere(listOptions usecase.OrderListOptions, cursor *uctypes.ListCursor) (squirrel.Sqlizer, error) {
+	if listOptions.Sort == nil || len(*listOptions.Sort) == 0 || (*listOptions.Sort)[0].Field != usecase.OrderListSortFieldID {
+		return nil, usecase.ErrOrderListCursorNotSupported
+	}
+
+	if (*listOptions.Sort)[0].IsDesc {
+		return squirrel.Lt{"id": cursor.ID}, nil
+	}
+
+	return squirrel.Gt{"id": cursor.ID}, nil
+}
+
 func (r *Order) buildPartUpdate(updateData usecase.OrderPartUpdateData) map[string]any {
 	result := make(map[string]any)
 
@@ -176,7 +211,13 @@ func (r *Order) FindList(ctx context.Context, listOptions usecase.OrderListOptio
 			q = q.Limit(queryParams.Limit)
 		}
 
-		if queryParams.Offset > 0 {
+		if queryParams.Cursor != nil {
+			cursorWhere, err := r.buildCursorWhere(listOptions, queryParams.Cursor)
+			if err != nil {
+				return nil, err
+			}
+			q = q.Where(cursorWhere)
+		} else if queryParams.Offset > 0 {
 			q = q.Offset(queryParams.Offset)
 		}
 	}
@@ -239,7 +280,13 @@ func (r *Order) FindPagedList(ctx context.Context, listOptions usecase.OrderList
 			q = q.Limit(queryParams.Limit)
 		}
 
-		if queryParams.Offset > 0 {
+		if queryParams.Cursor != nil {
+			cursorWhere, err := r.buildCursorWhere(listOptions, queryParams.Cursor)
+			if err != nil {
+				return nil, 0, err
+			}
+			q = q.Where(cursorWhere)
+		} else if queryParams.Offset > 0 {
 			q = q.Offset(queryParams.Offset)
 		}
 	}
@@ -284,17 +331,19 @@ func (r *Order) FindPagedList(ctx context.Context, listOptions usecase.OrderList
 		return nil
 	})
 
-	g.Go(func() error {
-		row := r.txc.DefaultTrOrDB(gCtx, r.db).QueryRow(gCtx, queryTotal, argsTotal...)
-		if err := row.Scan(&total); err != nil {
-			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
-			if !errIsConv {
-				r.logger.ErrorContext(ctx, "scan total", slog.Any("error", err))
+	if queryParams == nil || !queryParams.WithoutTotal {
+		g.Go(func() error {
+			row := r.txc.DefaultTrOrDB(gCtx, r.db).QueryRow(gCtx, queryTotal, argsTotal...)
+			if err := row.Scan(&total); err != nil {
+				errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+				if !errIsConv {
+					r.logger.ErrorContext(ctx, "scan total", slog.Any("error", err))
+				}
+				return convErr
 			}
-			return convErr
-		}
-		return nil
-	})
+			return nil
+		})
+	}
 
 	if err := g.Wait(); err != nil {
 		return nil, 0, err
@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/pkg/dbhelper"
+)
+
+const (
+	orderBulkJobTable = "order_bulk_job"
+)
+
+type DBOrderBulkJob struct {
+	ID           int64                     `db:"id"`
+	Status       domain.OrderBulkJobStatus `db:"status"`
+	TargetStatus domain.OrderStatus        `db:"target_status"`
+	Total        int32                     `db:"total"`
+	Processed    int32                     `db:"processed"`
+	Failed       int32                     `db:"failed"`
+	LockedUntil  *time.Time                `db:"locked_until"`
+	Error        string                    `db:"error"`
+
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+}
+
+var (
+	orderBulkJobTableFields = []string{}
+	orderBulkJobDBSchema    = &DBOrderBulkJob{}
+)
+
+func init() {
+	orderBulkJobTableFields = dbhelper.ExtractDBFields(orderBulkJobDBSchema)
+}
+
+type OrderBulkJob struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewOrderBulkJob(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *OrderBulkJob {
+	return &OrderBulkJob{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *OrderBulkJob) dbToDomain(db *DBOrderBulkJob) *domain.OrderBulkJob {
+	return &domain.OrderBulkJob{
+		ID:           db.ID,
+		Status:       db.Status,
+		TargetStatus: db.TargetStatus,
+		Total:        db.Total,
+		Processed:    db.Processed,
+		Failed:       db.Failed,
+		LockedUntil:  db.LockedUntil,
+		Error:        db.Error,
+
+		CreatedAt:  db.CreatedAt,
+		UpdatedAt:  db.UpdatedAt,
+		FinishedAt: db.FinishedAt,
+	}
+}
+
+func (r *OrderBulkJob) buildWhereForList(listOptions usecase.OrderBulkJobListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.IDs != nil {
+		where = append(where, squirrel.Eq{"id": *listOptions.IDs})
+	}
+
+	if listOptions.Statuses != nil {
+		where = append(where, squirrel.Eq{"status": *listOptions.Statuses})
+	}
+
+	return where
+}
+
+func (r *OrderBulkJob) FindList(ctx context.Context, listOptions usecase.OrderBulkJobListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.OrderBulkJob, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(orderBulkJobTableFields...).From(orderBulkJobTable).Where(where).OrderBy("id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBOrderBulkJob{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.OrderBulkJob, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *OrderBulkJob) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.OrderBulkJob, error) {
+	q := r.qb.Select(orderBulkJobTableFields...).From(orderBulkJobTable).Where(squirrel.Eq{"id": id})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBOrderBulkJob{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+func (r *OrderBulkJob) Create(ctx context.Context, item *domain.OrderBulkJob) error {
+	dataMap, err := dbhelper.StructToDBMap(item, orderBulkJobDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(orderBulkJobTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *OrderBulkJob) Update(ctx context.Context, item *domain.OrderBulkJob) error {
+	dataMap, err := dbhelper.StructToDBMap(item, orderBulkJobDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "created_at")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Update(orderBulkJobTable).Where(squirrel.Eq{"id": item.ID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+// Атомарный захват задачи обработчиком: ожидающей задачи или задачи, обработчик которой перестал продлевать блокировку.
+// id nil - первая подходящая задача. Если захватывать нечего, возвращается ErrNotFound
+func (r *OrderBulkJob) Claim(ctx context.Context, id *int64, lockedUntil time.Time) (*domain.OrderBulkJob, error) {
+	where := squirrel.And{
+		squirrel.Or{
+			squirrel.Eq{"status": domain.OrderBulkJobStatusPending},
+			squirrel.And{
+				squirrel.Eq{"status": domain.OrderBulkJobStatusProcessing},
+				squirrel.Expr("(locked_until IS NULL OR locked_until < now())"),
+			},
+		},
+	}
+
+	if id != nil {
+		where = append(where, squirrel.Eq{"id": *id})
+	}
+
+	// Подзапрос собирается без нумерованных плейсхолдеров, их расставляет внешний запрос
+	subQuery, subArgs, err := squirrel.Select("id").From(orderBulkJobTable).Where(where).OrderBy("id ASC").Limit(1).Suffix("FOR UPDATE SKIP LOCKED").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Update(orderBulkJobTable).
+		Set("status", domain.OrderBulkJobStatusProcessing).
+		Set("locked_until", lockedUntil).
+		Where(squirrel.Expr("id = ("+subQuery+")", subArgs...)).
+		Suffix("RETURNING " + strings.Join(orderBulkJobTableFields, ", ")).
+		ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBOrderBulkJob{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
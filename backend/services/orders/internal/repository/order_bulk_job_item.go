@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/pkg/dbhelper"
+)
+
+const (
+	orderBulkJobItemTable = "order_bulk_job_item"
+)
+
+type DBOrderBulkJobItem struct {
+	JobID       int64  `db:"job_id"`
+	OrderID     int64  `db:"order_id"`
+	IsProcessed bool   `db:"is_processed"`
+	IsOk        bool   `db:"is_ok"`
+	Error       string `db:"error"`
+}
+
+var (
+	orderBulkJobItemTableFields = []string{}
+	orderBulkJobItemDBSchema    = &DBOrderBulkJobItem{}
+)
+
+func init() {
+	orderBulkJobItemTableFields = dbhelper.ExtractDBFields(orderBulkJobItemDBSchema)
+}
+
+type OrderBulkJobItem struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewOrderBulkJobItem(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *OrderBulkJobItem {
+	return &OrderBulkJobItem{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *OrderBulkJobItem) dbToDomain(db *DBOrderBulkJobItem) *domain.OrderBulkJobItem {
+	return &domain.OrderBulkJobItem{
+		JobID:       db.JobID,
+		OrderID:     db.OrderID,
+		IsProcessed: db.IsProcessed,
+		IsOk:        db.IsOk,
+		Error:       db.Error,
+	}
+}
+
+func (r *OrderBulkJobItem) buildWhereForList(listOptions usecase.OrderBulkJobItemListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.JobID != nil {
+		where = append(where, squirrel.Eq{"job_id": *listOptions.JobID})
+	}
+
+	if listOptions.IsProcessed != nil {
+		where = append(where, squirrel.Eq{"is_processed": *listOptions.IsProcessed})
+	}
+
+	return where
+}
+
+func (r *OrderBulkJobItem) FindList(ctx context.Context, listOptions usecase.OrderBulkJobItemListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.OrderBulkJobItem, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(orderBulkJobItemTableFields...).From(orderBulkJobItemTable).Where(where).OrderBy("order_id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBOrderBulkJobItem{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.OrderBulkJobItem, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *OrderBulkJobItem) Create(ctx context.Context, item *domain.OrderBulkJobItem) error {
+	dataMap, err := dbhelper.StructToDBMap(item, orderBulkJobItemDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(orderBulkJobItemTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *OrderBulkJobItem) Update(ctx context.Context, item *domain.OrderBulkJobItem) error {
+	dataMap, err := dbhelper.StructToDBMap(item, orderBulkJobItemDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "job_id")
+	delete(dataMap, "order_id")
+
+	query, args, err := r.qb.Update(orderBulkJobItemTable).Where(squirrel.Eq{"job_id": item.JobID, "order_id": item.OrderID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -24,12 +24,26 @@ const (
 type DBOrderProduct struct {
 	OrderID   int64           `db:"order_id"`
 	ProductID int64           `db:"product_id"`
+	VariantID int64           `db:"variant_id"`
 	Quantity  int32           `db:"quantity"`
 	Price     decimal.Decimal `db:"price"`
+	TaxRate   decimal.Decimal `db:"tax_rate"`
+	NetSum    decimal.Decimal `db:"net_sum"`
+	TaxSum    decimal.Decimal `db:"tax_sum"`
+
+	Name            string `db:"name"`
+	SKU             string `db:"sku"`
+	ImagePreviewURL string `db:"image_preview_url"`
 
 	CreatedAt time.Time `db:"created_at"`
 }
 
+type DBOrderProductCoPurchase struct {
+	ProductID        int64 `db:"product_id"`
+	RelatedProductID int64 `db:"related_product_id"`
+	OrdersCount      int64 `db:"orders_count"`
+}
+
 var (
 	orderProductTableFields = []string{}
 	orderProductDBSchema    = &DBOrderProduct{}
@@ -58,9 +72,18 @@ func NewOrderProduct(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter)
 func (r *OrderProduct) dbToDomain(db *DBOrderProduct) *domain.OrderProduct {
 	return &domain.OrderProduct{
 		ProductID: db.ProductID,
+		VariantID: db.VariantID,
 		OrderID:   db.OrderID,
 		Quantity:  db.Quantity,
 		Price:     db.Price,
+		TaxRate:   db.TaxRate,
+		NetSum:    db.NetSum,
+		TaxSum:    db.TaxSum,
+
+		Name:            db.Name,
+		SKU:             db.SKU,
+		ImagePreviewURL: db.ImagePreviewURL,
+
 		CreatedAt: db.CreatedAt,
 	}
 }
@@ -182,3 +205,69 @@ func (r *OrderProduct) DeleteByList(ctx context.Context, listOptions usecase.Ord
 
 	return nil
 }
+
+func (r *OrderProduct) FindCoPurchases(ctx context.Context, options usecase.OrderProductCoPurchaseOptions) ([]*usecase.OrderProductCoPurchase, error) {
+
+	// Подзапрос собирается без нумерованных плейсхолдеров, их расставляет внешний запрос
+	pairs := squirrel.Select(
+		"a.product_id AS product_id",
+		"b.product_id AS related_product_id",
+		"COUNT(DISTINCT a.order_id) AS orders_count",
+		"ROW_NUMBER() OVER (PARTITION BY a.product_id ORDER BY COUNT(DISTINCT a.order_id) DESC, b.product_id ASC) AS pair_rank",
+	).
+		From(orderProductTable+" a").
+		Join(orderProductTable+" b ON b.order_id = a.order_id AND b.product_id <> a.product_id").
+		Join(orderTable+" o ON o.id = a.order_id").
+		Where(squirrel.And{
+			squirrel.Eq{"a.product_id": options.ProductIDs},
+			squirrel.Expr("o.deleted_at IS NULL"),
+			squirrel.Eq{"o.status": options.Statuses},
+			squirrel.GtOrEq{"o.created_at": options.CreatedFrom},
+		}).
+		GroupBy("a.product_id", "b.product_id").
+		Having("COUNT(DISTINCT a.order_id) >= ?", options.MinOrders)
+
+	q := r.qb.Select("product_id", "related_product_id", "orders_count").FromSelect(pairs, "pairs").OrderBy("product_id ASC", "pair_rank ASC")
+
+	if options.LimitPerProduct > 0 {
+		q = q.Where(squirrel.LtOrEq{"pair_rank": options.LimitPerProduct})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBOrderProductCoPurchase{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*usecase.OrderProductCoPurchase, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, &usecase.OrderProductCoPurchase{
+			ProductID:        dbItem.ProductID,
+			RelatedProductID: dbItem.RelatedProductID,
+			OrdersCount:      dbItem.OrdersCount,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/pkg/dbhelper"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	orderReturnTable = "order_return"
+)
+
+type DBOrderReturn struct {
+	ID        int64                    `db:"id"`
+	OrderID   int64                    `db:"order_id"`
+	Status    domain.OrderReturnStatus `db:"status"`
+	Reason    string                   `db:"reason"`
+	RefundSum decimal.Decimal          `db:"refund_sum"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+var (
+	orderReturnTableFields = []string{}
+	orderReturnDBSchema    = &DBOrderReturn{}
+)
+
+func init() {
+	orderReturnTableFields = dbhelper.ExtractDBFields(orderReturnDBSchema)
+}
+
+type OrderReturn struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewOrderReturn(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *OrderReturn {
+	return &OrderReturn{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *OrderReturn) dbToDomain(db *DBOrderReturn) *domain.OrderReturn {
+	return &domain.OrderReturn{
+		ID:        db.ID,
+		OrderID:   db.OrderID,
+		Status:    db.Status,
+		Reason:    db.Reason,
+		RefundSum: db.RefundSum,
+
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
+	}
+}
+
+func (r *OrderReturn) buildWhereForList(listOptions usecase.OrderReturnListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.IDs != nil {
+		where = append(where, squirrel.Eq{"id": *listOptions.IDs})
+	}
+
+	if listOptions.OrderID != nil {
+		where = append(where, squirrel.Eq{"order_id": *listOptions.OrderID})
+	}
+
+	if listOptions.Statuses != nil {
+		where = append(where, squirrel.Eq{"status": *listOptions.Statuses})
+	}
+
+	return where
+}
+
+func (r *OrderReturn) FindList(ctx context.Context, listOptions usecase.OrderReturnListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.OrderReturn, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(orderReturnTableFields...).From(orderReturnTable).Where(where).OrderBy("id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBOrderReturn{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.OrderReturn, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *OrderReturn) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.OrderReturn, error) {
+	q := r.qb.Select(orderReturnTableFields...).From(orderReturnTable).Where(squirrel.Eq{"id": id})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBOrderReturn{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+func (r *OrderReturn) Create(ctx context.Context, item *domain.OrderReturn) error {
+	dataMap, err := dbhelper.StructToDBMap(item, orderReturnDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(orderReturnTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *OrderReturn) Update(ctx context.Context, item *domain.OrderReturn) error {
+	dataMap, err := dbhelper.StructToDBMap(item, orderReturnDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "order_id")
+	delete(dataMap, "created_at")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Update(orderReturnTable).Where(squirrel.Eq{"id": item.ID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/pkg/dbhelper"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	orderReturnProductTable = "order_return_product"
+)
+
+type DBOrderReturnProduct struct {
+	ReturnID  int64           `db:"return_id"`
+	ProductID int64           `db:"product_id"`
+	VariantID int64           `db:"variant_id"`
+	Quantity  int32           `db:"quantity"`
+	Price     decimal.Decimal `db:"price"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	orderReturnProductTableFields = []string{}
+	orderReturnProductDBSchema    = &DBOrderReturnProduct{}
+)
+
+func init() {
+	orderReturnProductTableFields = dbhelper.ExtractDBFields(orderReturnProductDBSchema)
+}
+
+type OrderReturnProduct struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewOrderReturnProduct(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *OrderReturnProduct {
+	return &OrderReturnProduct{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *OrderReturnProduct) dbToDomain(db *DBOrderReturnProduct) *domain.OrderReturnProduct {
+	return &domain.OrderReturnProduct{
+		ProductID: db.ProductID,
+		VariantID: db.VariantID,
+		ReturnID:  db.ReturnID,
+		Quantity:  db.Quantity,
+		Price:     db.Price,
+		CreatedAt: db.CreatedAt,
+	}
+}
+
+func (r *OrderReturnProduct) buildWhereForList(listOptions usecase.OrderReturnProductListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ReturnID != nil {
+		where = append(where, squirrel.Eq{"return_id": *listOptions.ReturnID})
+	}
+
+	if listOptions.ReturnIDs != nil {
+		where = append(where, squirrel.Eq{"return_id": *listOptions.ReturnIDs})
+	}
+
+	return where
+}
+
+func (r *OrderReturnProduct) FindList(ctx context.Context, listOptions usecase.OrderReturnProductListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.OrderReturnProduct, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(orderReturnProductTableFields...).From(orderReturnProductTable).Where(where)
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBOrderReturnProduct{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.OrderReturnProduct, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *OrderReturnProduct) Create(ctx context.Context, item *domain.OrderReturnProduct) error {
+	dataMap, err := dbhelper.StructToDBMap(item, orderReturnProductDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(orderReturnProductTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *OrderReturnProduct) DeleteByList(ctx context.Context, listOptions usecase.OrderReturnProductListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(orderReturnProductTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase"
+	"github.com/shopspring/decimal"
+)
+
+type DBReportSalesItem struct {
+	Period      time.Time       `db:"period"`
+	OrdersCount int64           `db:"orders_count"`
+	Revenue     decimal.Decimal `db:"revenue"`
+	Tax         decimal.Decimal `db:"tax"`
+}
+
+type DBReportSalesLine struct {
+	OrderID        int64              `db:"order_id"`
+	OrderCreatedAt time.Time          `db:"order_created_at"`
+	OrderStatus    domain.OrderStatus `db:"order_status"`
+	ProductID      int64              `db:"product_id"`
+	VariantID      int64              `db:"variant_id"`
+	SKU            string             `db:"sku"`
+	Name           string             `db:"name"`
+	Quantity       int32              `db:"quantity"`
+	Price          decimal.Decimal    `db:"price"`
+	TaxRate        decimal.Decimal    `db:"tax_rate"`
+	NetSum         decimal.Decimal    `db:"net_sum"`
+	TaxSum         decimal.Decimal    `db:"tax_sum"`
+}
+
+type DBReportStatusItem struct {
+	Status      domain.OrderStatus `db:"status"`
+	OrdersCount int64              `db:"orders_count"`
+	OrdersSum   decimal.Decimal    `db:"orders_sum"`
+}
+
+type DBReportTopProductItem struct {
+	ProductID   int64           `db:"product_id"`
+	Quantity    int64           `db:"quantity"`
+	Revenue     decimal.Decimal `db:"revenue"`
+	OrdersCount int64           `db:"orders_count"`
+}
+
+type Report struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewReport(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *Report {
+	return &Report{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+var reportGranularityMap = map[usecase.ReportGranularity]string{
+	usecase.ReportGranularityDay:   "day",
+	usecase.ReportGranularityWeek:  "week",
+	usecase.ReportGranularityMonth: "month",
+}
+
+func (r *Report) buildWhere(options usecase.ReportOptions, tableAlias string) squirrel.And {
+	where := squirrel.And{
+		squirrel.Expr(tableAlias + ".deleted_at IS NULL"),
+		squirrel.GtOrEq{tableAlias + ".created_at": options.DateFrom},
+		squirrel.Lt{tableAlias + ".created_at": options.DateTo},
+	}
+
+	if options.Statuses != nil {
+		where = append(where, squirrel.Eq{tableAlias + ".status": *options.Statuses})
+	}
+
+	return where
+}
+
+func (r *Report) FindSalesByPeriod(ctx context.Context, options usecase.ReportOptions) ([]*usecase.ReportSalesItem, error) {
+	granularity, ok := reportGranularityMap[options.Granularity]
+	if !ok {
+		return nil, e.NewErrorFrom(e.ErrInternal).SetMessage("unknown granularity")
+	}
+
+	location := time.UTC
+	if options.Location != nil {
+		location = options.Location
+	}
+
+	q := r.qb.Select().
+		Column(squirrel.Expr("date_trunc(?, o.created_at AT TIME ZONE ?) AS period", granularity, location.String())).
+		Column("COUNT(*) AS orders_count").
+		// Выручка за вычетом возвратов
+		Column("COALESCE(SUM(o.order_sum - o.refund_sum), 0) AS revenue").
+		// НДС пропорционально уменьшается на долю возврата
+		Column("COALESCE(SUM(CASE WHEN o.order_sum > 0 THEN ROUND(o.tax_sum * (o.order_sum - o.refund_sum) / o.order_sum, 2) ELSE 0 END), 0) AS tax").
+		From(orderTable + " o").
+		Where(r.buildWhere(options, "o")).
+		GroupBy("period").
+		OrderBy("period ASC")
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBReportSalesItem{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*usecase.ReportSalesItem, 0, len(dbData))
+	for _, dbItem := range dbData {
+		// date_trunc возвращает timestamp без зоны, переносим его в зону отчета
+		period := time.Date(dbItem.Period.Year(), dbItem.Period.Month(), dbItem.Period.Day(), 0, 0, 0, 0, location)
+
+		result = append(result, &usecase.ReportSalesItem{
+			Period:      period,
+			OrdersCount: dbItem.OrdersCount,
+			Revenue:     dbItem.Revenue,
+			Tax:         dbItem.Tax,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *Report) FindOrdersByStatus(ctx context.Context, options usecase.ReportOptions) ([]*usecase.ReportStatusItem, error) {
+	q := r.qb.Select("o.status AS status", "COUNT(*) AS orders_count", "COALESCE(SUM(o.order_sum), 0) AS orders_sum").
+		From(orderTable + " o").
+		Where(r.buildWhere(options, "o")).
+		GroupBy("o.status").
+		OrderBy("o.status ASC")
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBReportStatusItem{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*usecase.ReportStatusItem, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, &usecase.ReportStatusItem{
+			Status:      dbItem.Status,
+			OrdersCount: dbItem.OrdersCount,
+			OrdersSum:   dbItem.OrdersSum,
+		})
+	}
+
+	return result, nil
+}
+
+var reportTopProductsSortFieldMap = map[usecase.ReportTopProductsSortField]string{
+	usecase.ReportTopProductsSortFieldQuantity: "quantity DESC, revenue DESC",
+	usecase.ReportTopProductsSortFieldRevenue:  "revenue DESC, quantity DESC",
+}
+
+func (r *Report) FindTopProducts(ctx context.Context, options usecase.ReportOptions, sortField usecase.ReportTopProductsSortField, limit uint64) ([]*usecase.ReportTopProductItem, error) {
+	sort, ok := reportTopProductsSortFieldMap[sortField]
+	if !ok {
+		sort = reportTopProductsSortFieldMap[usecase.ReportTopProductsSortFieldQuantity]
+	}
+
+	q := r.qb.Select(
+		"op.product_id AS product_id",
+		"SUM(op.quantity) AS quantity",
+		"SUM(op.price * op.quantity) AS revenue",
+		"COUNT(DISTINCT op.order_id) AS orders_count",
+	).
+		From(orderProductTable+" op").
+		Join(orderTable+" o ON o.id = op.order_id").
+		Where(r.buildWhere(options, "o")).
+		GroupBy("op.product_id").
+		OrderBy(sort, "op.product_id ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBReportTopProductItem{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*usecase.ReportTopProductItem, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, &usecase.ReportTopProductItem{
+			ProductID:   dbItem.ProductID,
+			Quantity:    dbItem.Quantity,
+			Revenue:     dbItem.Revenue,
+			OrdersCount: dbItem.OrdersCount,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *Report) FindSalesLines(ctx context.Context, options usecase.ReportOptions) ([]*usecase.ReportSalesLine, error) {
+	q := r.qb.Select(
+		"o.id AS order_id",
+		"o.created_at AS order_created_at",
+		"o.status AS order_status",
+		"op.product_id AS product_id",
+		"op.variant_id AS variant_id",
+		"op.sku AS sku",
+		"op.name AS name",
+		"op.quantity AS quantity",
+		"op.price AS price",
+		"op.tax_rate AS tax_rate",
+		"op.net_sum AS net_sum",
+		"op.tax_sum AS tax_sum",
+	).
+		From(orderProductTable+" op").
+		Join(orderTable+" o ON o.id = op.order_id").
+		Where(r.buildWhere(options, "o")).
+		OrderBy("o.created_at ASC", "o.id ASC", "op.product_id ASC", "op.variant_id ASC")
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBReportSalesLine{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*usecase.ReportSalesLine, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, &usecase.ReportSalesLine{
+			OrderID:        dbItem.OrderID,
+			OrderCreatedAt: dbItem.OrderCreatedAt,
+			OrderStatus:    dbItem.OrderStatus,
+			ProductID:      dbItem.ProductID,
+			VariantID:      dbItem.VariantID,
+			SKU:            dbItem.SKU,
+			Name:           dbItem.Name,
+			Quantity:       dbItem.Quantity,
+			Price:          dbItem.Price,
+			TaxRate:        dbItem.TaxRate,
+			NetSum:         dbItem.NetSum,
+			TaxSum:         dbItem.TaxSum,
+		})
+	}
+
+	return result, nil
+}
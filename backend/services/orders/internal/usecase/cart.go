@@ -0,0 +1,460 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	productscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/products"
+	productsgcl "github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/clients/grpc/products"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+var ErrCartEmpty = e.NewErrorFrom(e.ErrBadRequest).SetMessage("cart is empty")
+var ErrCartProductUnavailable = e.NewErrorFrom(e.ErrBadRequest).SetMessage("product is unavailable")
+var ErrCartChanged = e.NewErrorFrom(e.ErrConflict).SetMessage("cart prices or availability were changed")
+
+type CartListOptions struct {
+	IDs       *[]uuid.UUID
+	AccountID *uuid.UUID
+}
+
+// Позиция корзины, сверенная с актуальными данными товара
+type CartLineOut struct {
+	Item *domain.CartItem
+	// Снимок данных товара, у недоступного товара может быть пустым
+	Name            string
+	SKU             string
+	ImagePreviewURL string
+	// Актуальная цена, Item.Price уже обновлена до нее
+	Price decimal.Decimal
+	// Цена, которую покупатель видел раньше, если она изменилась
+	OldPrice       *decimal.Decimal
+	StockAvailable int32
+	// Товар снят с продажи, удален или закончился
+	IsUnavailable bool
+	// Остатка меньше, чем количество в корзине
+	IsNotEnoughStock bool
+}
+
+func (l CartLineOut) IsPriceChanged() bool {
+	return l.OldPrice != nil
+}
+
+func (l CartLineOut) HasChanges() bool {
+	return l.IsPriceChanged() || l.IsUnavailable || l.IsNotEnoughStock
+}
+
+type CartFullOut struct {
+	Cart  *domain.Cart
+	Lines []CartLineOut
+	// Сумма доступных для заказа позиций
+	Sum decimal.Decimal
+}
+
+func (c *CartFullOut) HasChanges() bool {
+	return lo.SomeBy(c.Lines, func(item CartLineOut) bool {
+		return item.HasChanges()
+	})
+}
+
+// Описание изменений для покупателя
+func (c *CartFullOut) ChangesDetails() []string {
+	details := []string{}
+
+	for _, line := range c.Lines {
+		name := line.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", line.Item.ProductID)
+		}
+
+		switch {
+		case line.IsUnavailable:
+			details = append(details, fmt.Sprintf(`Товар "%s" недоступен для заказа`, name))
+		case line.IsNotEnoughStock:
+			details = append(details, fmt.Sprintf(`Доступный для заказа остаток по товару "%s": %d шт.`, name, line.StockAvailable))
+		}
+
+		if line.IsPriceChanged() {
+			details = append(details, fmt.Sprintf(`Цена товара "%s" изменилась: %s -> %s`, name, line.OldPrice.StringFixed(2), line.Price.StringFixed(2)))
+		}
+	}
+
+	return details
+}
+
+//go:generate mockery --name=Cart --output=../../tests/mocks --case=underscore
+type Cart interface {
+	Create(ctx context.Context, accountID *uuid.UUID) (cart *domain.Cart, err error)
+	FindOneFullByID(ctx context.Context, id uuid.UUID, accountID *uuid.UUID) (out *CartFullOut, err error)
+	SetItem(ctx context.Context, id uuid.UUID, accountID *uuid.UUID, productID int64, variantID int64, quantity int32) (out *CartFullOut, err error)
+	Clear(ctx context.Context, id uuid.UUID, accountID *uuid.UUID) (err error)
+	Checkout(ctx context.Context, id uuid.UUID, accountID *uuid.UUID, details OrderDataDetailsIn) (order *domain.Order, err error)
+}
+
+//go:generate mockery --name=CartRepository --output=../../tests/mocks --case=underscore
+type CartRepository interface {
+	FindList(ctx context.Context, listOptions CartListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Cart, err error)
+	FindOneByID(ctx context.Context, id uuid.UUID, queryParams *uctypes.QueryGetOneParams) (cart *domain.Cart, err error)
+	Create(ctx context.Context, item *domain.Cart) (err error)
+	CreateForAccount(ctx context.Context, item *domain.Cart) (isCreated bool, err error)
+}
+
+type CartInpl struct {
+	logger      *slog.Logger
+	config      config.Config
+	repo        CartRepository
+	txManager   *manager.Manager
+	productsGCl *productsgcl.ClientConn
+	cartItemUC  CartItem
+	orderUC     Order
+}
+
+func NewCartInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo CartRepository, productsGCl *productsgcl.ClientConn, cartItemUC CartItem, orderUC Order) *CartInpl {
+	uc := &CartInpl{
+		logger:      logger,
+		config:      config,
+		txManager:   txManager,
+		repo:        repo,
+		productsGCl: productsGCl,
+		cartItemUC:  cartItemUC,
+		orderUC:     orderUC,
+	}
+	return uc
+}
+
+// У аккаунта одна корзина, повторный вызов возвращает ее же. Анонимному покупателю всегда создается новая корзина
+func (uc *CartInpl) Create(ctx context.Context, accountID *uuid.UUID) (*domain.Cart, error) {
+	cart := domain.NewCart(accountID)
+
+	if accountID == nil {
+		err := uc.repo.Create(ctx, cart)
+		if err != nil {
+			return nil, err
+		}
+
+		return cart, nil
+	}
+
+	// Параллельные запросы аккаунта не должны падать на уникальном индексе, поэтому при конфликте перечитываем существующую корзину
+	isCreated, err := uc.repo.CreateForAccount(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	if isCreated {
+		return cart, nil
+	}
+
+	carts, err := uc.repo.FindList(ctx, CartListOptions{
+		AccountID: accountID,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(carts) == 0 {
+		return nil, e.ErrNotFound
+	}
+
+	return carts[0], nil
+}
+
+// Чужая корзина аккаунта выглядит для покупателя как несуществующая
+func (uc *CartInpl) findAccessible(ctx context.Context, id uuid.UUID, accountID *uuid.UUID, queryParams *uctypes.QueryGetOneParams) (*domain.Cart, error) {
+	cart, err := uc.repo.FindOneByID(ctx, id, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cart.IsAccessibleBy(accountID) {
+		return nil, e.ErrNotFound
+	}
+
+	return cart, nil
+}
+
+func (uc *CartInpl) FindOneFullByID(ctx context.Context, id uuid.UUID, accountID *uuid.UUID) (*CartFullOut, error) {
+	cart, err := uc.findAccessible(ctx, id, accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.revalidate(ctx, cart, false)
+}
+
+// Сверка позиций корзины с актуальными ценами и остатками.
+// При чтении корзины новые цены только показываются, сохраняются они при изменении корзины и при оформлении заказа
+func (uc *CartInpl) revalidate(ctx context.Context, cart *domain.Cart, savePrices bool) (*CartFullOut, error) {
+	items, err := uc.cartItemUC.FindList(ctx, CartItemListOptions{
+		CartID: &cart.ID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &CartFullOut{
+		Cart:  cart,
+		Lines: make([]CartLineOut, 0, len(items)),
+		Sum:   decimal.Zero,
+	}
+
+	if len(items) == 0 {
+		return out, nil
+	}
+
+	productIDs := lo.Uniq(lo.Map(items, func(item *domain.CartItem, _ int) int64 {
+		return item.ProductID
+	}))
+
+	products, err := uc.productsGCl.Client.GetProductsByIds(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		line := CartLineOut{
+			Item:  item,
+			Price: item.Price,
+		}
+
+		product, isFound := lo.Find(products, func(product *productscl.ProductListItem) bool {
+			return product.ID == item.ProductID
+		})
+
+		if isFound {
+			line.Name = product.Name
+			line.SKU = product.SKU
+			line.ImagePreviewURL = product.ImagePreviewFileURL
+		}
+
+		if !isFound || !product.IsPublished || product.DeletedAt != nil {
+			line.IsUnavailable = true
+			out.Lines = append(out.Lines, line)
+			continue
+		}
+
+		unit, err := resolveOrderProductUnit(product, item.VariantID)
+		if err != nil {
+			line.IsUnavailable = true
+			out.Lines = append(out.Lines, line)
+			continue
+		}
+
+		line.Name = unit.Name
+		line.SKU = unit.SKU
+		line.StockAvailable = unit.StockAvailable
+
+		if unit.StockAvailable < 1 {
+			line.IsUnavailable = true
+		} else if unit.StockAvailable < item.Quantity {
+			line.IsNotEnoughStock = true
+		}
+
+		if !unit.Price.Equal(item.Price) {
+			line.OldPrice = lo.ToPtr(item.Price)
+			line.Price = unit.Price
+
+			err = item.SetPrice(unit.Price)
+			if err != nil {
+				return nil, err
+			}
+
+			if savePrices {
+				err = uc.cartItemUC.Update(ctx, item)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if !line.IsUnavailable {
+			out.Sum = out.Sum.Add(item.Sum())
+		}
+
+		out.Lines = append(out.Lines, line)
+	}
+
+	return out, nil
+}
+
+// Установка количества товара в корзине, количество 0 убирает товар из корзины
+func (uc *CartInpl) SetItem(ctx context.Context, id uuid.UUID, accountID *uuid.UUID, productID int64, variantID int64, quantity int32) (*CartFullOut, error) {
+	if quantity < 0 {
+		return nil, domain.ErrCartItemQuantityLess1
+	}
+
+	cart, err := uc.findAccessible(ctx, id, accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if quantity == 0 {
+		err = uc.cartItemUC.Delete(ctx, cart.ID, productID, variantID)
+		if err != nil {
+			return nil, err
+		}
+
+		return uc.revalidate(ctx, cart, true)
+	}
+
+	products, err := uc.productsGCl.Client.GetProductsByIds(ctx, []int64{productID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(products) != 1 || !products[0].IsPublished || products[0].DeletedAt != nil {
+		return nil, ErrCartProductUnavailable
+	}
+
+	unit, err := resolveOrderProductUnit(products[0], variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if unit.StockAvailable < quantity {
+		return nil, e.NewErrorFrom(ErrOrderInvalidProductsQuantity).AddDetails([]string{
+			fmt.Sprintf(`Доступный для заказа остаток по товару "%s": %d шт.`, unit.Name, unit.StockAvailable),
+		})
+	}
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		items, err := uc.cartItemUC.FindList(ctx, CartItemListOptions{
+			CartID:    &cart.ID,
+			ProductID: &productID,
+			VariantID: &variantID,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			item, err := domain.NewCartItem(cart.ID, productID, variantID, quantity, unit.Price)
+			if err != nil {
+				return err
+			}
+
+			return uc.cartItemUC.Create(ctx, item)
+		}
+
+		item := items[0]
+
+		err = item.SetQuantity(quantity)
+		if err != nil {
+			return err
+		}
+
+		// Покупатель добавляет товар, видя актуальную цену
+		err = item.SetPrice(unit.Price)
+		if err != nil {
+			return err
+		}
+
+		return uc.cartItemUC.Update(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.revalidate(ctx, cart, true)
+}
+
+func (uc *CartInpl) Clear(ctx context.Context, id uuid.UUID, accountID *uuid.UUID) error {
+	cart, err := uc.findAccessible(ctx, id, accountID, nil)
+	if err != nil {
+		return err
+	}
+
+	return uc.cartItemUC.DeleteByCartID(ctx, cart.ID)
+}
+
+// Оформление заказа из корзины. Если цены или наличие изменились, заказ не создается:
+// новые цены сохраняются в корзину, покупатель должен увидеть изменения и подтвердить оформление повторно.
+// Позиции забираются из корзины под блокировкой до создания заказа, поэтому повторная отправка получит пустую корзину
+func (uc *CartInpl) Checkout(ctx context.Context, id uuid.UUID, accountID *uuid.UUID, details OrderDataDetailsIn) (*domain.Order, error) {
+	var full *CartFullOut
+	var isChanged bool
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		cart, err := uc.findAccessible(ctx, id, accountID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		full, err = uc.revalidate(ctx, cart, true)
+		if err != nil {
+			return err
+		}
+
+		if len(full.Lines) == 0 {
+			return ErrCartEmpty
+		}
+
+		// Транзакция фиксируется, чтобы сохранить новые цены
+		isChanged = full.HasChanges()
+		if isChanged {
+			return nil
+		}
+
+		return uc.cartItemUC.DeleteByCartID(ctx, cart.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if isChanged {
+		return nil, e.NewErrorFrom(ErrCartChanged).AddDetails(full.ChangesDetails())
+	}
+
+	products := lo.Map(full.Lines, func(line CartLineOut, _ int) OrderProductIn {
+		return OrderProductIn{
+			ID:        line.Item.ProductID,
+			VariantID: line.Item.VariantID,
+			Quantity:  line.Item.Quantity,
+		}
+	})
+
+	order, err := uc.orderUC.Create(ctx, OrderCreateIn{
+		Details:  details,
+		Products: products,
+	})
+	if err != nil {
+		uc.restoreItems(ctx, full)
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Возврат позиций в корзину, если заказ не удалось создать. Ошибка только логируется,
+// чтобы покупатель получил исходную ошибку оформления
+func (uc *CartInpl) restoreItems(ctx context.Context, full *CartFullOut) {
+	ctx = context.WithoutCancel(ctx)
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		for _, line := range full.Lines {
+			err := uc.cartItemUC.Create(ctx, line.Item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "cant restore cart items after failed checkout", slog.String("cart_id", full.Cart.ID.String()), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+)
+
+type CartItemListOptions struct {
+	CartID    *uuid.UUID
+	ProductID *int64
+	VariantID *int64
+}
+
+//go:generate mockery --name=CartItem --output=../../tests/mocks --case=underscore
+type CartItem interface {
+	FindList(ctx context.Context, listOptions CartItemListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.CartItem, err error)
+	Create(ctx context.Context, item *domain.CartItem) (err error)
+	Update(ctx context.Context, item *domain.CartItem) (err error)
+	Delete(ctx context.Context, cartID uuid.UUID, productID int64, variantID int64) (err error)
+	DeleteByCartID(ctx context.Context, cartID uuid.UUID) (err error)
+}
+
+//go:generate mockery --name=CartItemRepository --output=../../tests/mocks --case=underscore
+type CartItemRepository interface {
+	FindList(ctx context.Context, listOptions CartItemListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.CartItem, err error)
+	Create(ctx context.Context, item *domain.CartItem) (err error)
+	Update(ctx context.Context, item *domain.CartItem) (err error)
+	DeleteByList(ctx context.Context, listOptions CartItemListOptions) (err error)
+}
+
+type CartItemInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      CartItemRepository
+	txManager *manager.Manager
+}
+
+func NewCartItemInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo CartItemRepository) *CartItemInpl {
+	uc := &CartItemInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *CartItemInpl) FindList(ctx context.Context, listOptions CartItemListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.CartItem, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *CartItemInpl) Create(ctx context.Context, item *domain.CartItem) error {
+	return uc.repo.Create(ctx, item)
+}
+
+func (uc *CartItemInpl) Update(ctx context.Context, item *domain.CartItem) error {
+	return uc.repo.Update(ctx, item)
+}
+
+func (uc *CartItemInpl) Delete(ctx context.Context, cartID uuid.UUID, productID int64, variantID int64) error {
+	return uc.repo.DeleteByList(ctx, CartItemListOptions{
+		CartID:    &cartID,
+		ProductID: &productID,
+		VariantID: &variantID,
+	})
+}
+
+func (uc *CartItemInpl) DeleteByCartID(ctx context.Context, cartID uuid.UUID) error {
+	return uc.repo.DeleteByList(ctx, CartItemListOptions{
+		CartID: &cartID,
+	})
+}
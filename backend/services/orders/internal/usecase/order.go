@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -21,6 +23,11 @@ import (
 
 var ErrOrderInvalidProducts = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid products")
 var ErrOrderInvalidProductsQuantity = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid products quantity")
+var ErrOrderNotEditable = e.NewErrorFrom(e.ErrBadRequest).SetMessage("order cant be changed in current status")
+var ErrOrderProductVariantRequired = e.NewErrorFrom(e.ErrBadRequest).SetMessage("product variant required")
+var ErrOrderListCursorNotSupported = e.NewErrorFrom(e.ErrBadRequest).SetMessage("cursor is not supported for this sort")
+var ErrOrderListCursorInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid cursor")
+var ErrOrderStockNotHeld = e.NewErrorFrom(e.ErrBadRequest).SetMessage("order does not hold stock in current status")
 
 type OrderPartUpdateData struct {
 	ClientName      *string
@@ -44,6 +51,9 @@ type OrderListSort struct {
 type OrderListOptions struct {
 	IDs         *[]int64
 	OnlyCreated *bool
+	Statuses    *[]domain.OrderStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
 	Sort        *[]OrderListSort
 }
 
@@ -53,6 +63,8 @@ type OrderCreateIn struct {
 }
 
 type OrderUpdateIn struct {
+	// Версия заказа, которую видел клиент
+	Version  int64
 	Details  OrderDataDetailsIn
 	Products []OrderProductWithPrice
 }
@@ -63,17 +75,43 @@ type OrderDataDetailsIn struct {
 	ClientEmail     string
 	ClientPhone     string
 	DeliveryAddress string
+	DeliveryZone    string
 }
 
 type OrderProductIn struct {
-	ID       int64
-	Quantity int32
+	ID int64
+	// Вариант товара, 0 - товар без вариантов
+	VariantID int64
+	Quantity  int32
+}
+
+func (p OrderProductIn) key() orderProductKey {
+	return orderProductKey{ProductID: p.ID, VariantID: p.VariantID}
 }
 
 type OrderProductWithPrice struct {
-	ID       int64
-	Quantity int32
-	Price    decimal.Decimal
+	ID        int64
+	VariantID int64
+	Quantity  int32
+	Price     decimal.Decimal
+	TaxRate   decimal.Decimal
+	// Снимок данных товара
+	Name            string
+	SKU             string
+	ImagePreviewURL string
+	// Заполняются при чтении заказа
+	NetSum decimal.Decimal
+	TaxSum decimal.Decimal
+}
+
+func (p OrderProductWithPrice) key() orderProductKey {
+	return orderProductKey{ProductID: p.ID, VariantID: p.VariantID}
+}
+
+// Позиция заказа - товар и его вариант
+type orderProductKey struct {
+	ProductID int64
+	VariantID int64
 }
 
 type OrderOneFullOut struct {
@@ -85,6 +123,9 @@ type SetOrderCompositionIn struct {
 	OrderID  int64
 	Products *[]OrderProductWithPrice
 	Status   *domain.OrderStatus
+	// Версия заказа, которую видел клиент. Если задана, изменение применяется только к ней и увеличивает ее
+	Version *int64
+	Details *OrderDataDetailsIn
 }
 
 //go:generate mockery --name=Order --output=../../tests/mocks --case=underscore
@@ -93,10 +134,13 @@ type Order interface {
 	FindList(ctx context.Context, listOptions OrderListOptions, queryParams *uctypes.QueryGetListParams) (out []*domain.Order, err error)
 	FindOneFullByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (out *OrderOneFullOut, err error)
 	Create(ctx context.Context, input OrderCreateIn) (order *domain.Order, err error)
-	Update(ctx context.Context, orderID int64, input OrderUpdateIn) (err error)
+	Update(ctx context.Context, orderID int64, input OrderUpdateIn) (order *domain.Order, err error)
 	SetOrderComposition(ctx context.Context, input SetOrderCompositionIn) (err error)
 	RemoveOrderIfNew(ctx context.Context, orderID int64) (err error)
-	SetStatus(ctx context.Context, orderID int64, status domain.OrderStatus) (err error)
+	SetStatus(ctx context.Context, orderID int64, status domain.OrderStatus, version int64) (order *domain.Order, err error)
+	AddRefund(ctx context.Context, orderID int64, refundSum decimal.Decimal, isFullReturn bool) (err error)
+	ExtendStockReserve(ctx context.Context, orderID int64) (expiresAt *time.Time, err error)
+	ExpireStockReserve(ctx context.Context, orderID int64) (canRelease bool, err error)
 }
 
 //go:generate mockery --name=OrderRepository --output=../../tests/mocks --case=underscore
@@ -119,9 +163,20 @@ type OrderInpl struct {
 	productsGCl    *productsgcl.ClientConn
 	productsTCl    productstc.Client
 	orderProductUC OrderProduct
+	defaultTaxRate decimal.Decimal
 }
 
-func NewOrderInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo OrderRepository, productsGCl *productsgcl.ClientConn, productsTCl productstc.Client, orderProductUC OrderProduct) *OrderInpl {
+func NewOrderInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo OrderRepository, productsGCl *productsgcl.ClientConn, productsTCl productstc.Client, orderProductUC OrderProduct) (*OrderInpl, error) {
+	// Неверная ставка молча обнулила бы НДС во всех заказах, поэтому сервис с ней не запускаем
+	defaultTaxRate, err := decimal.NewFromString(config.Tax.DefaultRate)
+	if err != nil {
+		return nil, fmt.Errorf("cant parse tax.default_rate %q: %w", config.Tax.DefaultRate, err)
+	}
+
+	if defaultTaxRate.IsNegative() || defaultTaxRate.GreaterThan(decimal.NewFromInt(100)) {
+		return nil, fmt.Errorf("tax.default_rate %q must be between 0 and 100", config.Tax.DefaultRate)
+	}
+
 	uc := &OrderInpl{
 		logger:         logger,
 		config:         config,
@@ -130,8 +185,18 @@ func NewOrderInpl(logger *slog.Logger, config config.Config, txManager *manager.
 		productsGCl:    productsGCl,
 		productsTCl:    productsTCl,
 		orderProductUC: orderProductUC,
+		defaultTaxRate: defaultTaxRate,
 	}
-	return uc
+	return uc, nil
+}
+
+// Ставка НДС товара, если у товара нет своей - ставка по умолчанию
+func (uc *OrderInpl) productTaxRate(product *productscl.ProductListItem) decimal.Decimal {
+	if product.TaxRate != nil {
+		return *product.TaxRate
+	}
+
+	return uc.defaultTaxRate
 }
 
 func (uc *OrderInpl) FindPagedList(ctx context.Context, listOptions OrderListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Order, int64, error) {
@@ -174,9 +239,17 @@ func (uc *OrderInpl) FindOneFullByID(ctx context.Context, id int64, queryParams
 
 	for i, product := range products {
 		out.Products[i] = OrderProductWithPrice{
-			ID:       product.ProductID,
-			Quantity: product.Quantity,
-			Price:    product.Price,
+			ID:        product.ProductID,
+			VariantID: product.VariantID,
+			Quantity:  product.Quantity,
+			Price:     product.Price,
+			TaxRate:   product.TaxRate,
+			NetSum:    product.NetSum,
+			TaxSum:    product.TaxSum,
+
+			Name:            product.Name,
+			SKU:             product.SKU,
+			ImagePreviewURL: product.ImagePreviewURL,
 		}
 	}
 
@@ -185,16 +258,18 @@ func (uc *OrderInpl) FindOneFullByID(ctx context.Context, id int64, queryParams
 
 func (uc *OrderInpl) Create(ctx context.Context, input OrderCreateIn) (*domain.Order, error) {
 
-	productIDs := make([]int64, len(input.Products))
-	for i, item := range input.Products {
-		productIDs[i] = item.ID
-	}
-	productIDs = lo.Uniq(productIDs)
+	keys := lo.Uniq(lo.Map(input.Products, func(item OrderProductIn, _ int) orderProductKey {
+		return item.key()
+	}))
 
-	if len(productIDs) == 0 || len(productIDs) != len(input.Products) {
+	if len(keys) == 0 || len(keys) != len(input.Products) {
 		return nil, ErrOrderInvalidProducts
 	}
 
+	productIDs := lo.Uniq(lo.Map(keys, func(item orderProductKey, _ int) int64 {
+		return item.ProductID
+	}))
+
 	products, err := uc.productsGCl.Client.GetProductsByIds(ctx, productIDs)
 	if err != nil {
 		return nil, err
@@ -215,13 +290,14 @@ func (uc *OrderInpl) Create(ctx context.Context, input OrderCreateIn) (*domain.O
 	order.ClientEmail = input.Details.ClientEmail
 	order.ClientPhone = input.Details.ClientPhone
 	order.DeliveryAddress = input.Details.DeliveryAddress
+	order.DeliveryZone = input.Details.DeliveryZone
 
 	err = uc.repo.Create(ctx, order)
 	if err != nil {
 		return nil, err
 	}
 
-	orderSum := decimal.Zero
+	orderProducts := make([]*domain.OrderProduct, 0, len(input.Products))
 	for _, product := range input.Products {
 		productItem, ok := lo.Find(products, func(item *productscl.ProductListItem) bool {
 			return item.ID == product.ID
@@ -230,20 +306,27 @@ func (uc *OrderInpl) Create(ctx context.Context, input OrderCreateIn) (*domain.O
 			return nil, e.ErrInternal
 		}
 
-		orderProduct, err := domain.NewOrderProduct(order.ID, product.ID, product.Quantity, productItem.Price)
+		unit, err := resolveOrderProductUnit(productItem, product.VariantID)
 		if err != nil {
 			return nil, err
 		}
 
+		orderProduct, err := domain.NewOrderProduct(order.ID, product.ID, product.VariantID, product.Quantity, unit.Price, uc.productTaxRate(productItem))
+		if err != nil {
+			return nil, err
+		}
+
+		orderProduct.SetSnapshot(unit.Name, unit.SKU, productItem.ImagePreviewFileURL)
+
 		err = uc.orderProductUC.Create(ctx, orderProduct)
 		if err != nil {
 			return nil, err
 		}
 
-		orderSum = orderSum.Add(productItem.Price.Mul(decimal.NewFromInt(int64(product.Quantity))))
+		orderProducts = append(orderProducts, orderProduct)
 	}
 
-	err = order.SetOrderSum(orderSum)
+	err = order.SetSumsFromProducts(orderProducts)
 	if err != nil {
 		return nil, err
 	}
@@ -266,10 +349,20 @@ func (uc *OrderInpl) Create(ctx context.Context, input OrderCreateIn) (*domain.O
 			return nil, e.ErrInternal
 		}
 
+		unit, err := resolveOrderProductUnit(productItem, item.VariantID)
+		if err != nil {
+			return nil, err
+		}
+
 		ordersList[i] = productstc.OrderProductsItem{
-			ProductID: item.ID,
-			Quantity:  item.Quantity,
-			Price:     productItem.Price,
+			ProductID:       item.ID,
+			VariantID:       item.VariantID,
+			Quantity:        item.Quantity,
+			Price:           unit.Price,
+			TaxRate:         uc.productTaxRate(productItem),
+			Name:            unit.Name,
+			SKU:             unit.SKU,
+			ImagePreviewURL: productItem.ImagePreviewFileURL,
 		}
 	}
 
@@ -277,6 +370,7 @@ func (uc *OrderInpl) Create(ctx context.Context, input OrderCreateIn) (*domain.O
 		OrderID:       order.ID,
 		OrderProducts: &ordersList,
 		OrderStatus:   lo.ToPtr(domain.OrderStatusCreated.String()),
+		DeliveryZone:  order.DeliveryZone,
 	}
 
 	err = uc.productsTCl.SetOrderProductsAndStatus(ctxWithTimeout, flowIn)
@@ -295,89 +389,198 @@ func (uc *OrderInpl) Create(ctx context.Context, input OrderCreateIn) (*domain.O
 	return order, nil
 }
 
-func (uc *OrderInpl) Update(ctx context.Context, orderID int64, input OrderUpdateIn) error {
+func (uc *OrderInpl) Update(ctx context.Context, orderID int64, input OrderUpdateIn) (*domain.Order, error) {
 
-	productIDs := make([]int64, len(input.Products))
-	for i, item := range input.Products {
-		productIDs[i] = item.ID
-	}
-	productIDs = lo.Uniq(productIDs)
+	keys := lo.Uniq(lo.Map(input.Products, func(item OrderProductWithPrice, _ int) orderProductKey {
+		return item.key()
+	}))
 
-	if len(productIDs) == 0 || len(productIDs) != len(input.Products) {
-		return ErrOrderInvalidProducts
+	if len(keys) == 0 || len(keys) != len(input.Products) {
+		return nil, ErrOrderInvalidProducts
 	}
 
+	productIDs := lo.Uniq(lo.Map(keys, func(item orderProductKey, _ int) int64 {
+		return item.ProductID
+	}))
+
 	products, err := uc.productsGCl.Client.GetProductsByIds(ctx, productIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(products) != len(productIDs) {
-		return ErrOrderInvalidProducts
+		return nil, ErrOrderInvalidProducts
 	}
 
 	for _, product := range products {
 		if !product.IsPublished {
-			return ErrOrderInvalidProducts
+			return nil, ErrOrderInvalidProducts
 		}
 	}
 
+	// Предварительная проверка, чтобы не запускать воркфлоу для устаревшей версии или нередактируемого заказа
 	order, err := uc.repo.FindOneByID(ctx, orderID, nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = order.CheckVersion(input.Version)
+	if err != nil {
+		return nil, err
 	}
 
-	if order.Status == domain.OrderStatusCanceled {
-		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("order is canceled")
+	if !order.Status.IsEditable() {
+		return nil, ErrOrderNotEditable
 	}
 
-	if order.Status == domain.OrderStatusFinished {
-		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("order is finished")
+	// Для товаров, которые уже были в заказе, сохраняем их снимок
+	curOrderProducts, err := uc.orderProductUC.FindList(ctx, OrderProductListOptions{
+		OrderID: lo.ToPtr(order.ID),
+	}, nil)
+	if err != nil {
+		return nil, err
 	}
 
+	curOrderProductsMap := lo.KeyBy(curOrderProducts, func(item *domain.OrderProduct) orderProductKey {
+		return orderProductKey{ProductID: item.ProductID, VariantID: item.VariantID}
+	})
+
 	//Запускаем воркфлоу
 	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	ordersList := make([]productstc.OrderProductsItem, len(input.Products))
 	for i, item := range input.Products {
+		productItem, ok := lo.Find(products, func(product *productscl.ProductListItem) bool {
+			return product.ID == item.ID
+		})
+		if !ok {
+			return nil, e.ErrInternal
+		}
+
+		unit, err := resolveOrderProductUnit(productItem, item.VariantID)
+		if err != nil {
+			return nil, err
+		}
+
 		ordersList[i] = productstc.OrderProductsItem{
-			ProductID: item.ID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
+			ProductID:       item.ID,
+			VariantID:       item.VariantID,
+			Quantity:        item.Quantity,
+			Price:           item.Price,
+			TaxRate:         uc.productTaxRate(productItem),
+			Name:            unit.Name,
+			SKU:             unit.SKU,
+			ImagePreviewURL: productItem.ImagePreviewFileURL,
+		}
+
+		if curOrderProduct, ok := curOrderProductsMap[item.key()]; ok && curOrderProduct.Name != "" {
+			ordersList[i].Name = curOrderProduct.Name
+			ordersList[i].SKU = curOrderProduct.SKU
+			ordersList[i].ImagePreviewURL = curOrderProduct.ImagePreviewURL
 		}
 	}
 
+	// Состав, данные и новую версию сохраняет микросервис заказов из воркфлоу в одной транзакции с проверкой версии.
+	// Если версия устарела, воркфлоу вернет бронь в прежнее состояние
 	flowIn := productstc.SetOrderProductsAndStatusIn{
 		OrderID:       order.ID,
 		OrderProducts: &ordersList,
+		DeliveryZone:  input.Details.DeliveryZone,
+		OrderVersion:  lo.ToPtr(input.Version),
+		OrderDetails: &productstc.OrderDetails{
+			ClientName:      input.Details.ClientName,
+			ClientSurname:   input.Details.ClientSurname,
+			ClientEmail:     input.Details.ClientEmail,
+			ClientPhone:     input.Details.ClientPhone,
+			DeliveryAddress: input.Details.DeliveryAddress,
+			DeliveryZone:    input.Details.DeliveryZone,
+		},
 	}
 
 	err = uc.productsTCl.SetOrderProductsAndStatus(ctxWithTimeout, flowIn)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return e.NewErrorFrom(e.ErrServiceUnavailable)
+			return nil, e.NewErrorFrom(e.ErrServiceUnavailable)
 		}
 
 		if errors.Is(err, productstc.ErrSetOrderProductsAndStatusCantReserve) {
-			return ErrOrderInvalidProductsQuantity
+			return nil, ErrOrderInvalidProductsQuantity
 		}
 
-		return err
+		if errors.Is(err, productstc.ErrSetOrderProductsAndStatusVersionMismatch) {
+			return nil, domain.ErrOrderVersionMismatch
+		}
+
+		return nil, err
 	}
 
-	err = uc.repo.PartUpdateByID(ctx, OrderPartUpdateData{
-		ClientName:      lo.ToPtr(input.Details.ClientName),
-		ClientSurname:   lo.ToPtr(input.Details.ClientSurname),
-		ClientEmail:     lo.ToPtr(input.Details.ClientEmail),
-		ClientPhone:     lo.ToPtr(input.Details.ClientPhone),
-		DeliveryAddress: lo.ToPtr(input.Details.DeliveryAddress),
-	}, order.ID)
-	if err != nil {
-		return err
+	return uc.repo.FindOneByID(ctx, orderID, nil)
+}
+
+// Действие с бронью товаров для воркфлоу по действию перехода статуса
+func orderStockActionToFlow(action domain.OrderStockAction) productstc.OrderStockAction {
+	switch action {
+	case domain.OrderStockActionRelease:
+		return productstc.OrderStockActionRelease
+	case domain.OrderStockActionConsume:
+		return productstc.OrderStockActionConsume
+	default:
+		return productstc.OrderStockActionNone
 	}
+}
 
-	return nil
+// Товар или его вариант, который попадает в заказ
+type orderProductUnit struct {
+	Price          decimal.Decimal
+	StockAvailable int32
+	Name           string
+	SKU            string
+}
+
+// Товар с вариантами продается только вариантами, у варианта свои цена, остаток и артикул
+func resolveOrderProductUnit(product *productscl.ProductListItem, variantID int64) (orderProductUnit, error) {
+	if variantID == 0 {
+		if len(product.Variants) > 0 {
+			return orderProductUnit{}, e.NewErrorFrom(ErrOrderProductVariantRequired).SetMessage(fmt.Sprintf("product variant required in #%d product", product.ID))
+		}
+
+		return orderProductUnit{
+			Price:          product.Price,
+			StockAvailable: product.StockAvailable,
+			Name:           product.Name,
+			SKU:            product.SKU,
+		}, nil
+	}
+
+	variant, ok := product.FindVariant(variantID)
+	if !ok {
+		return orderProductUnit{}, ErrOrderInvalidProducts
+	}
+
+	unit := orderProductUnit{
+		Price:          variant.Price,
+		StockAvailable: variant.StockAvailable,
+		Name:           product.Name,
+		SKU:            variant.SKU,
+	}
+
+	if unit.SKU == "" {
+		unit.SKU = product.SKU
+	}
+
+	optionNames := lo.Keys(variant.Options)
+	sort.Strings(optionNames)
+
+	optionValues := lo.Map(optionNames, func(name string, _ int) string {
+		return variant.Options[name]
+	})
+
+	if len(optionValues) > 0 {
+		unit.Name = fmt.Sprintf("%s (%s)", product.Name, strings.Join(optionValues, ", "))
+	}
+
+	return unit, nil
 }
 
 func (uc *OrderInpl) checkStockAvailable(products []*productscl.ProductListItem, orderProducts []OrderProductIn) error {
@@ -396,8 +599,13 @@ func (uc *OrderInpl) checkStockAvailable(products []*productscl.ProductListItem,
 			return ErrOrderInvalidProducts
 		}
 
-		if product.StockAvailable < orderProduct.Quantity {
-			details = append(details, fmt.Sprintf(`Доступный для заказа остаток по товару "%s": %d шт.`, product.Name, product.StockAvailable))
+		unit, err := resolveOrderProductUnit(product, orderProduct.VariantID)
+		if err != nil {
+			return err
+		}
+
+		if unit.StockAvailable < orderProduct.Quantity {
+			details = append(details, fmt.Sprintf(`Доступный для заказа остаток по товару "%s": %d шт.`, unit.Name, unit.StockAvailable))
 		}
 	}
 
@@ -422,51 +630,72 @@ func (uc *OrderInpl) SetOrderComposition(ctx context.Context, input SetOrderComp
 			return err
 		}
 
-		if order.Status == domain.OrderStatusCanceled || order.Status == domain.OrderStatusFinished {
-			return e.NewErrorFrom(e.ErrBadRequest).SetMessage("order is canceled or finished")
+		if input.Version != nil {
+			err = order.CheckVersion(*input.Version)
+			if err != nil {
+				return err
+			}
 		}
 
-		skeepProducts := false
+		isEditable := order.Status.IsEditable()
+
+		if input.Details != nil {
+			if !isEditable {
+				return ErrOrderNotEditable
+			}
+
+			order.ClientName = input.Details.ClientName
+			order.ClientSurname = input.Details.ClientSurname
+			order.ClientEmail = input.Details.ClientEmail
+			order.ClientPhone = input.Details.ClientPhone
+			order.DeliveryAddress = input.Details.DeliveryAddress
+			order.DeliveryZone = input.Details.DeliveryZone
+		}
 
 		if input.Status != nil {
 			err = order.SetStatus(*input.Status)
 			if err != nil {
 				return err
 			}
-
-			if order.Status == domain.OrderStatusCanceled {
-				skeepProducts = true
-			}
 		}
 
-		if input.Products != nil && !skeepProducts {
+		if input.Products != nil {
+			if !isEditable {
+				return ErrOrderNotEditable
+			}
 
 			err = uc.orderProductUC.DeleteByOrderID(ctx, input.OrderID)
 			if err != nil {
 				return err
 			}
 
-			orderSum := decimal.Zero
+			orderProducts := make([]*domain.OrderProduct, 0, len(*input.Products))
 			for _, item := range *input.Products {
-				orderProduct, err := domain.NewOrderProduct(input.OrderID, item.ID, item.Quantity, item.Price)
+				orderProduct, err := domain.NewOrderProduct(input.OrderID, item.ID, item.VariantID, item.Quantity, item.Price, item.TaxRate)
 				if err != nil {
 					return err
 				}
 
+				orderProduct.SetSnapshot(item.Name, item.SKU, item.ImagePreviewURL)
+
 				err = uc.orderProductUC.Create(ctx, orderProduct)
 				if err != nil {
 					return err
 				}
 
-				orderSum = orderSum.Add(item.Price.Mul(decimal.NewFromInt(int64(item.Quantity))))
+				orderProducts = append(orderProducts, orderProduct)
 			}
 
-			err = order.SetOrderSum(orderSum)
+			err = order.SetSumsFromProducts(orderProducts)
 			if err != nil {
 				return err
 			}
 		}
 
+		if input.Version != nil {
+			order.IncVersion()
+		}
+
 		err = uc.repo.Update(ctx, order)
 		if err != nil {
 			return err
@@ -516,41 +745,185 @@ func (uc *OrderInpl) RemoveOrderIfNew(ctx context.Context, orderID int64) error
 	return nil
 }
 
-func (uc *OrderInpl) SetStatus(ctx context.Context, orderID int64, status domain.OrderStatus) error {
+func (uc *OrderInpl) SetStatus(ctx context.Context, orderID int64, status domain.OrderStatus, version int64) (*domain.Order, error) {
 
-	if status == domain.OrderStatusNew {
-		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("cant set status")
-	}
+	var order *domain.Order
+	var transition domain.OrderStatusTransition
+	isChanged := false
+
+	// Заказ только проверяем под блокировкой, статус и новую версию сохранит воркфлоу
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		order, err = uc.repo.FindOneByID(ctx, orderID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = order.CheckVersion(version)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == status {
+			return nil
+		}
 
-	order, err := uc.repo.FindOneByID(ctx, orderID, &uctypes.QueryGetOneParams{
-		ForUpdate: true,
+		var ok bool
+		transition, ok = domain.FindOrderStatusTransition(order.Status, status)
+		if !ok || transition.IsSystem {
+			nextStatuses := lo.Map(order.Status.NextStatuses(false), func(item domain.OrderStatus, _ int) string {
+				return item.String()
+			})
+			return e.NewErrorFrom(domain.ErrOrderCantSetStatus).AddDetails(nextStatuses)
+		}
+
+		// Срок брони зависит от статуса, поэтому бронь продлеваем до смены статуса.
+		// Если бронь уже истекла, статус не меняем: заказ не должен уйти дальше без товаров на складе
+		if transition.StockAction == domain.OrderStockActionNone && status.HoldsStock() {
+			_, err = uc.productsGCl.Client.ExtendOrderBlockByOrderID(ctx, order.ID, status.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		isChanged = true
+
+		return nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if order.Status == status {
-		return nil
+	if !isChanged {
+		return order, nil
 	}
 
-	err = order.SetStatus(status)
+	//Запускаем воркфлоу и ждем результат, чтобы вернуть версию уже сохраненного статуса.
+	//С бронью воркфлоу поступает по действию перехода
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	flowIn := productstc.SetOrderProductsAndStatusIn{
+		OrderID:      order.ID,
+		OrderStatus:  lo.ToPtr(status.String()),
+		StockAction:  orderStockActionToFlow(transition.StockAction),
+		OrderVersion: lo.ToPtr(version),
+	}
+
+	err = uc.productsTCl.SetOrderProductsAndStatus(ctxWithTimeout, flowIn)
 	if err != nil {
-		return err
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, e.NewErrorFrom(e.ErrServiceUnavailable)
+		}
+
+		if errors.Is(err, productstc.ErrSetOrderProductsAndStatusVersionMismatch) {
+			return nil, domain.ErrOrderVersionMismatch
+		}
+
+		return nil, err
 	}
 
-	//Запускаем воркфлоу и не ждем результат
-	flowIn := productstc.SetOrderProductsAndStatusIn{
-		NotWait:     true,
-		OrderID:     order.ID,
-		OrderStatus: lo.ToPtr(order.Status.String()),
+	return uc.repo.FindOneByID(ctx, orderID, nil)
+}
+
+// Продление брони товаров, например если заказ дольше обычного ожидает оплату
+func (uc *OrderInpl) ExtendStockReserve(ctx context.Context, orderID int64) (*time.Time, error) {
+	order, err := uc.repo.FindOneByID(ctx, orderID, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	//Если заказ отменен, то отменяем списание товаров
-	if order.Status == domain.OrderStatusCanceled {
-		flowIn.OrderProducts = lo.ToPtr([]productstc.OrderProductsItem{})
+	if !order.Status.HoldsStock() {
+		return nil, ErrOrderStockNotHeld
+	}
+
+	return uc.productsGCl.Client.ExtendOrderBlockByOrderID(ctx, order.ID, order.Status.String())
+}
+
+// Бронь товаров заказа истекла в микросервисе товаров. Заказ, который еще можно отменить, отменяется,
+// чтобы он не ушел дальше без товаров на складе. Отгруженный или выполненный заказ бронь сохраняет
+func (uc *OrderInpl) ExpireStockReserve(ctx context.Context, orderID int64) (bool, error) {
+	canRelease := false
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		order, err := uc.repo.FindOneByID(ctx, orderID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			// Бронь без заказа никому не нужна
+			if errors.Is(err, e.ErrNotFound) {
+				canRelease = true
+				return nil
+			}
+			return err
+		}
+
+		if !order.Status.HoldsStock() {
+			canRelease = !order.Status.IsStockConsumed()
+			return nil
+		}
+
+		if _, ok := domain.FindOrderStatusTransition(order.Status, domain.OrderStatusCanceled); !ok {
+			return nil
+		}
+
+		// Бронь вернет на склад микросервис товаров, поэтому статус сохраняем без воркфлоу
+		err = order.SetStatus(domain.OrderStatusCanceled)
+		if err != nil {
+			return err
+		}
+
+		order.IncVersion()
+
+		err = uc.repo.Update(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		canRelease = true
+
+		uc.logger.InfoContext(ctx, "order canceled by expired stock reserve", slog.Int64("order_id", order.ID))
+
+		return nil
+	})
+	if err != nil {
+		return false, err
 	}
 
-	err = uc.productsTCl.SetOrderProductsAndStatus(ctx, flowIn)
+	return canRelease, nil
+}
+
+func (uc *OrderInpl) AddRefund(ctx context.Context, orderID int64, refundSum decimal.Decimal, isFullReturn bool) error {
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		order, err := uc.repo.FindOneByID(ctx, orderID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = order.AddRefundSum(refundSum)
+		if err != nil {
+			return err
+		}
+
+		// Товары уже возвращены на склад воркфлоу возврата, резерв не трогаем
+		if isFullReturn {
+			err = order.SetStatus(domain.OrderStatusReturned)
+			if err != nil {
+				return err
+			}
+		}
+
+		order.IncVersion()
+
+		return uc.repo.Update(ctx, order)
+	})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+var ErrOrderBulkJobNoSource = e.NewErrorFrom(e.ErrBadRequest).SetMessage("ids or filter must be set")
+var ErrOrderBulkJobNoOrders = e.NewErrorFrom(e.ErrBadRequest).SetMessage("no orders found")
+var ErrOrderBulkJobTooManyOrders = e.NewErrorFrom(e.ErrBadRequest).SetMessage("too many orders")
+
+type OrderBulkJobListOptions struct {
+	IDs      *[]int64
+	Statuses *[]domain.OrderBulkJobStatus
+}
+
+type OrderBulkFilterIn struct {
+	Statuses    *[]domain.OrderStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+type OrderBulkSetStatusIn struct {
+	IDs    *[]int64
+	Filter *OrderBulkFilterIn
+	Status domain.OrderStatus
+}
+
+type OrderBulkJobFullOut struct {
+	Job   *domain.OrderBulkJob
+	Items []*domain.OrderBulkJobItem
+}
+
+//go:generate mockery --name=OrderBulkJob --output=../../tests/mocks --case=underscore
+type OrderBulkJob interface {
+	FindFullByID(ctx context.Context, id int64) (out *OrderBulkJobFullOut, err error)
+	CreateSetStatus(ctx context.Context, input OrderBulkSetStatusIn) (out *OrderBulkJobFullOut, err error)
+	ResumeUnfinished(ctx context.Context) (err error)
+	Stop(ctx context.Context) (err error)
+}
+
+//go:generate mockery --name=OrderBulkJobRepository --output=../../tests/mocks --case=underscore
+type OrderBulkJobRepository interface {
+	FindList(ctx context.Context, listOptions OrderBulkJobListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderBulkJob, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.OrderBulkJob, err error)
+	Create(ctx context.Context, item *domain.OrderBulkJob) (err error)
+	Update(ctx context.Context, item *domain.OrderBulkJob) (err error)
+	Claim(ctx context.Context, id *int64, lockedUntil time.Time) (item *domain.OrderBulkJob, err error)
+}
+
+type OrderBulkJobInpl struct {
+	logger             *slog.Logger
+	config             config.Config
+	repo               OrderBulkJobRepository
+	txManager          *manager.Manager
+	orderUC            Order
+	orderBulkJobItemUC OrderBulkJobItem
+	// Фоновая обработка живет до остановки сервиса
+	bgCtx    context.Context
+	bgCancel context.CancelFunc
+	bgWG     sync.WaitGroup
+}
+
+func NewOrderBulkJobInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo OrderBulkJobRepository, orderUC Order, orderBulkJobItemUC OrderBulkJobItem) *OrderBulkJobInpl {
+	uc := &OrderBulkJobInpl{
+		logger:             logger,
+		config:             config,
+		txManager:          txManager,
+		repo:               repo,
+		orderUC:            orderUC,
+		orderBulkJobItemUC: orderBulkJobItemUC,
+	}
+	uc.bgCtx, uc.bgCancel = context.WithCancel(context.Background())
+	return uc
+}
+
+func (uc *OrderBulkJobInpl) lockedUntil() time.Time {
+	return time.Now().Add(time.Duration(uc.config.Bulk.LockSeconds) * time.Second)
+}
+
+func (uc *OrderBulkJobInpl) FindFullByID(ctx context.Context, id int64) (*OrderBulkJobFullOut, error) {
+	job, err := uc.repo.FindOneByID(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.orderBulkJobItemUC.FindList(ctx, OrderBulkJobItemListOptions{
+		JobID: &job.ID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OrderBulkJobFullOut{
+		Job:   job,
+		Items: items,
+	}, nil
+}
+
+// Список заказов, к которым применяется операция
+func (uc *OrderBulkJobInpl) resolveOrderIDs(ctx context.Context, input OrderBulkSetStatusIn) ([]int64, error) {
+	// Пустой фильтр затронул бы все заказы, такое не допускаем
+	isFilterEmpty := input.Filter == nil || (input.Filter.Statuses == nil && input.Filter.CreatedFrom == nil && input.Filter.CreatedTo == nil)
+	if input.IDs == nil && isFilterEmpty {
+		return nil, ErrOrderBulkJobNoSource
+	}
+
+	listOptions := OrderListOptions{
+		OnlyCreated: lo.ToPtr(true),
+		Sort: &[]OrderListSort{
+			{Field: OrderListSortFieldID},
+		},
+	}
+
+	if input.IDs != nil {
+		ids := lo.Uniq(*input.IDs)
+		listOptions.IDs = &ids
+	}
+
+	if input.Filter != nil {
+		listOptions.Statuses = input.Filter.Statuses
+		listOptions.CreatedFrom = input.Filter.CreatedFrom
+		listOptions.CreatedTo = input.Filter.CreatedTo
+	}
+
+	// Берем на один больше лимита, чтобы понять, что лимит превышен
+	orders, err := uc.orderUC.FindList(ctx, listOptions, &uctypes.QueryGetListParams{
+		Limit: uint64(uc.config.Bulk.MaxOrders) + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return nil, ErrOrderBulkJobNoOrders
+	}
+
+	if len(orders) > uc.config.Bulk.MaxOrders {
+		return nil, e.NewErrorFrom(ErrOrderBulkJobTooManyOrders).AddDetails([]string{
+			"max orders: " + strconv.Itoa(uc.config.Bulk.MaxOrders),
+		})
+	}
+
+	return lo.Map(orders, func(item *domain.Order, _ int) int64 {
+		return item.ID
+	}), nil
+}
+
+func (uc *OrderBulkJobInpl) CreateSetStatus(ctx context.Context, input OrderBulkSetStatusIn) (*OrderBulkJobFullOut, error) {
+	orderIDs, err := uc.resolveOrderIDs(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	job := domain.NewOrderBulkJob(input.Status, int32(len(orderIDs)))
+	items := make([]*domain.OrderBulkJobItem, 0, len(orderIDs))
+
+	// Небольшие пачки обрабатываем сразу, поэтому задача создается уже захваченной этим запросом
+	isSync := len(orderIDs) <= uc.config.Bulk.SyncLimit
+	if isSync {
+		job.Start(uc.lockedUntil())
+	}
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		err := uc.repo.Create(ctx, job)
+		if err != nil {
+			return err
+		}
+
+		for _, orderID := range orderIDs {
+			item := domain.NewOrderBulkJobItem(job.ID, orderID)
+
+			err := uc.orderBulkJobItemUC.Create(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			items = append(items, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if isSync {
+		err = uc.processOrFail(ctx, job, items)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		uc.runInBackground(func(ctx context.Context) {
+			uc.claimAndProcess(ctx, &job.ID)
+		})
+	}
+
+	return &OrderBulkJobFullOut{
+		Job:   job,
+		Items: items,
+	}, nil
+}
+
+// Продолжает задачи, прерванные остановкой или сбоем экземпляра сервиса, и периодически проверяет,
+// не осталось ли задач с истекшей блокировкой. Задачи захватываются атомарно, поэтому при нескольких
+// экземплярах сервиса каждую задачу обрабатывает только один из них
+func (uc *OrderBulkJobInpl) ResumeUnfinished(_ context.Context) error {
+	uc.runInBackground(func(ctx context.Context) {
+		ticker := time.NewTicker(time.Duration(uc.config.Bulk.LockSeconds) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			for uc.claimAndProcess(ctx, nil) {
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+
+	return nil
+}
+
+// Останавливает фоновую обработку и ждет ее завершения. Прерванные задачи остаются заблокированными
+// до истечения срока, после чего их продолжит другой экземпляр сервиса
+func (uc *OrderBulkJobInpl) Stop(ctx context.Context) error {
+	uc.bgCancel()
+
+	done := make(chan struct{})
+	go func() {
+		uc.bgWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (uc *OrderBulkJobInpl) runInBackground(fn func(ctx context.Context)) {
+	uc.bgWG.Add(1)
+
+	go func() {
+		defer uc.bgWG.Done()
+
+		defer func() {
+			if r := recover(); r != nil {
+				uc.logger.Error("panic in order bulk job", slog.Any("error", r), slog.Any("trackeback", string(debug.Stack())))
+			}
+		}()
+
+		fn(uc.bgCtx)
+	}()
+}
+
+// Захватывает задачу и обрабатывает ее необработанные заказы. Возвращает false, если захватывать нечего
+func (uc *OrderBulkJobInpl) claimAndProcess(ctx context.Context, jobID *int64) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	job, err := uc.repo.Claim(ctx, jobID, uc.lockedUntil())
+	if err != nil {
+		if !errors.Is(err, e.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "claiming order bulk job", slog.Any("error", err))
+		}
+		return false
+	}
+
+	items, err := uc.orderBulkJobItemUC.FindList(ctx, OrderBulkJobItemListOptions{
+		JobID:       &job.ID,
+		IsProcessed: lo.ToPtr(false),
+	}, nil)
+	if err == nil {
+		err = uc.processOrFail(ctx, job, items)
+	}
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "processing order bulk job", slog.Int64("job_id", job.ID), slog.Any("error", err))
+	}
+
+	return true
+}
+
+// Ошибка обработки завершает задачу статусом failed. При остановке сервиса задача не завершается,
+// ее продолжит другой экземпляр после истечения блокировки
+func (uc *OrderBulkJobInpl) processOrFail(ctx context.Context, job *domain.OrderBulkJob, items []*domain.OrderBulkJobItem) error {
+	err := uc.process(ctx, job, items)
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+
+	job.Fail(err)
+
+	updErr := uc.repo.Update(context.WithoutCancel(ctx), job)
+	if updErr != nil {
+		uc.logger.ErrorContext(ctx, "cant mark order bulk job as failed", slog.Int64("job_id", job.ID), slog.Any("error", updErr))
+	}
+
+	return err
+}
+
+// Применяет статус к каждому заказу по отдельности, ошибка по одному заказу не прерывает задачу
+func (uc *OrderBulkJobInpl) process(ctx context.Context, job *domain.OrderBulkJob, items []*domain.OrderBulkJobItem) error {
+	for _, item := range items {
+		if item.IsProcessed {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		result := uc.setOrderStatus(ctx, item.OrderID, job.TargetStatus)
+
+		// Результат, полученный при остановке, не сохраняем: заказ будет обработан повторно
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		item.SetResult(result)
+
+		err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+			err := uc.orderBulkJobItemUC.Update(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			job.AddResult(item.IsOk)
+			job.ProlongLock(uc.lockedUntil())
+
+			return uc.repo.Update(ctx, job)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	job.Finish()
+
+	return uc.repo.Update(ctx, job)
+}
+
+func (uc *OrderBulkJobInpl) setOrderStatus(ctx context.Context, orderID int64, status domain.OrderStatus) error {
+	orders, err := uc.orderUC.FindList(ctx, OrderListOptions{
+		IDs: &[]int64{orderID},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(orders) == 0 {
+		return e.ErrNotFound
+	}
+
+	// Массовая операция не знает версию заказа у клиента, поэтому берем текущую.
+	// SetStatus дожидается воркфлоу, поэтому заказ считается обработанным, только когда статус сохранен
+	_, err = uc.orderUC.SetStatus(ctx, orderID, status, orders[0].Version)
+
+	return err
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+)
+
+type OrderBulkJobItemListOptions struct {
+	JobID       *int64
+	IsProcessed *bool
+}
+
+//go:generate mockery --name=OrderBulkJobItem --output=../../tests/mocks --case=underscore
+type OrderBulkJobItem interface {
+	FindList(ctx context.Context, listOptions OrderBulkJobItemListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderBulkJobItem, err error)
+	Create(ctx context.Context, item *domain.OrderBulkJobItem) (err error)
+	Update(ctx context.Context, item *domain.OrderBulkJobItem) (err error)
+}
+
+//go:generate mockery --name=OrderBulkJobItemRepository --output=../../tests/mocks --case=underscore
+type OrderBulkJobItemRepository interface {
+	FindList(ctx context.Context, listOptions OrderBulkJobItemListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderBulkJobItem, err error)
+	Create(ctx context.Context, item *domain.OrderBulkJobItem) (err error)
+	Update(ctx context.Context, item *domain.OrderBulkJobItem) (err error)
+}
+
+type OrderBulkJobItemInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      OrderBulkJobItemRepository
+	txManager *manager.Manager
+}
+
+func NewOrderBulkJobItemInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo OrderBulkJobItemRepository) *OrderBulkJobItemInpl {
+	uc := &OrderBulkJobItemInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *OrderBulkJobItemInpl) FindList(ctx context.Context, listOptions OrderBulkJobItemListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.OrderBulkJobItem, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *OrderBulkJobItemInpl) Create(ctx context.Context, item *domain.OrderBulkJobItem) error {
+	return uc.repo.Create(ctx, item)
+}
+
+func (uc *OrderBulkJobItemInpl) Update(ctx context.Context, item *domain.OrderBulkJobItem) error {
+	return uc.repo.Update(ctx, item)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
@@ -15,11 +16,30 @@ type OrderProductListOptions struct {
 	OrderID   *int64
 }
 
+type OrderProductCoPurchaseOptions struct {
+	ProductIDs []int64
+	// Учитываются заказы, созданные не раньше
+	CreatedFrom time.Time
+	Statuses    []domain.OrderStatus
+	// Минимальное количество заказов, в которых товары купили вместе
+	MinOrders int64
+	// 0 - без ограничения
+	LimitPerProduct uint64
+}
+
+// Пара товаров, купленных в одних заказах
+type OrderProductCoPurchase struct {
+	ProductID        int64
+	RelatedProductID int64
+	OrdersCount      int64
+}
+
 //go:generate mockery --name=OrderProduct --output=../../tests/mocks --case=underscore
 type OrderProduct interface {
 	FindList(ctx context.Context, listOptions OrderProductListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderProduct, err error)
 	Create(ctx context.Context, item *domain.OrderProduct) (err error)
 	DeleteByOrderID(ctx context.Context, orderID int64) (err error)
+	FindCoPurchases(ctx context.Context, productIDs []int64, createdFrom time.Time, minOrders int64, limitPerProduct uint64) (items []*OrderProductCoPurchase, err error)
 }
 
 //go:generate mockery --name=OrderProductRepository --output=../../tests/mocks --case=underscore
@@ -27,6 +47,7 @@ type OrderProductRepository interface {
 	FindList(ctx context.Context, listOptions OrderProductListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderProduct, err error)
 	Create(ctx context.Context, item *domain.OrderProduct) (err error)
 	DeleteByList(ctx context.Context, listOptions OrderProductListOptions) (err error)
+	FindCoPurchases(ctx context.Context, options OrderProductCoPurchaseOptions) (items []*OrderProductCoPurchase, err error)
 }
 
 type OrderProductRepositoryInpl struct {
@@ -59,3 +80,19 @@ func (uc *OrderProductRepositoryInpl) DeleteByOrderID(ctx context.Context, order
 		OrderID: &orderID,
 	})
 }
+
+// Товары, которые чаще всего покупают вместе с указанными, по убыванию количества заказов.
+// Учитываются те же заказы, что и в выручке: отмененные и возвращенные не считаются
+func (uc *OrderProductRepositoryInpl) FindCoPurchases(ctx context.Context, productIDs []int64, createdFrom time.Time, minOrders int64, limitPerProduct uint64) ([]*OrderProductCoPurchase, error) {
+	if len(productIDs) == 0 {
+		return []*OrderProductCoPurchase{}, nil
+	}
+
+	return uc.repo.FindCoPurchases(ctx, OrderProductCoPurchaseOptions{
+		ProductIDs:      productIDs,
+		CreatedFrom:     createdFrom,
+		Statuses:        ReportRevenueStatuses,
+		MinOrders:       max(minOrders, 1),
+		LimitPerProduct: limitPerProduct,
+	})
+}
@@ -0,0 +1,446 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+	productstc "github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products/client"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+var ErrOrderReturnNotAllowed = e.NewErrorFrom(e.ErrBadRequest).SetMessage("returns are not allowed for order in current status")
+var ErrOrderReturnInvalidProducts = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid return products")
+var ErrOrderReturnInvalidRefundSum = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid refund sum")
+
+// Возвраты в этих статусах учитываются при расчете уже возвращенного количества
+var orderReturnActiveStatuses = []domain.OrderReturnStatus{
+	domain.OrderReturnStatusPending,
+	domain.OrderReturnStatusProcessing,
+	domain.OrderReturnStatusCompleted,
+}
+
+type OrderReturnListOptions struct {
+	IDs      *[]int64
+	OrderID  *int64
+	Statuses *[]domain.OrderReturnStatus
+}
+
+type OrderReturnCreateIn struct {
+	Reason   string
+	Products []OrderProductIn
+}
+
+type OrderReturnFullOut struct {
+	Return   *domain.OrderReturn
+	Products []*domain.OrderReturnProduct
+}
+
+//go:generate mockery --name=OrderReturn --output=../../tests/mocks --case=underscore
+type OrderReturn interface {
+	FindFullListByOrderID(ctx context.Context, orderID int64) (out []*OrderReturnFullOut, err error)
+	Create(ctx context.Context, orderID int64, input OrderReturnCreateIn) (out *OrderReturnFullOut, err error)
+	Approve(ctx context.Context, returnID int64, refundSum *decimal.Decimal) (err error)
+	Reject(ctx context.Context, returnID int64) (err error)
+	SetReturnResult(ctx context.Context, returnID int64, isOk bool) (err error)
+}
+
+//go:generate mockery --name=OrderReturnRepository --output=../../tests/mocks --case=underscore
+type OrderReturnRepository interface {
+	FindList(ctx context.Context, listOptions OrderReturnListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderReturn, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.OrderReturn, err error)
+	Create(ctx context.Context, item *domain.OrderReturn) (err error)
+	Update(ctx context.Context, item *domain.OrderReturn) (err error)
+}
+
+type OrderReturnInpl struct {
+	logger               *slog.Logger
+	config               config.Config
+	repo                 OrderReturnRepository
+	txManager            *manager.Manager
+	productsTCl          productstc.Client
+	orderUC              Order
+	orderReturnProductUC OrderReturnProduct
+}
+
+func NewOrderReturnInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo OrderReturnRepository, productsTCl productstc.Client, orderUC Order, orderReturnProductUC OrderReturnProduct) *OrderReturnInpl {
+	uc := &OrderReturnInpl{
+		logger:               logger,
+		config:               config,
+		txManager:            txManager,
+		repo:                 repo,
+		productsTCl:          productsTCl,
+		orderUC:              orderUC,
+		orderReturnProductUC: orderReturnProductUC,
+	}
+	return uc
+}
+
+func (uc *OrderReturnInpl) FindFullListByOrderID(ctx context.Context, orderID int64) ([]*OrderReturnFullOut, error) {
+	returns, err := uc.repo.FindList(ctx, OrderReturnListOptions{
+		OrderID: &orderID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*OrderReturnFullOut, 0, len(returns))
+
+	if len(returns) == 0 {
+		return out, nil
+	}
+
+	returnIDs := lo.Map(returns, func(item *domain.OrderReturn, _ int) int64 {
+		return item.ID
+	})
+
+	products, err := uc.orderReturnProductUC.FindList(ctx, OrderReturnProductListOptions{
+		ReturnIDs: &returnIDs,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	productsByReturnID := lo.GroupBy(products, func(item *domain.OrderReturnProduct) int64 {
+		return item.ReturnID
+	})
+
+	for _, item := range returns {
+		out = append(out, &OrderReturnFullOut{
+			Return:   item,
+			Products: productsByReturnID[item.ID],
+		})
+	}
+
+	return out, nil
+}
+
+// Количество товаров по заказу в возвратах с указанными статусами
+func (uc *OrderReturnInpl) returnedQuantities(ctx context.Context, orderID int64, statuses []domain.OrderReturnStatus) (map[orderProductKey]int32, error) {
+	result := map[orderProductKey]int32{}
+
+	returns, err := uc.repo.FindList(ctx, OrderReturnListOptions{
+		OrderID:  &orderID,
+		Statuses: &statuses,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(returns) == 0 {
+		return result, nil
+	}
+
+	returnIDs := lo.Map(returns, func(item *domain.OrderReturn, _ int) int64 {
+		return item.ID
+	})
+
+	products, err := uc.orderReturnProductUC.FindList(ctx, OrderReturnProductListOptions{
+		ReturnIDs: &returnIDs,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range products {
+		result[orderProductKey{ProductID: item.ProductID, VariantID: item.VariantID}] += item.Quantity
+	}
+
+	return result, nil
+}
+
+func (uc *OrderReturnInpl) Create(ctx context.Context, orderID int64, input OrderReturnCreateIn) (*OrderReturnFullOut, error) {
+
+	keys := lo.Uniq(lo.Map(input.Products, func(item OrderProductIn, _ int) orderProductKey {
+		return item.key()
+	}))
+
+	if len(keys) == 0 || len(keys) != len(input.Products) {
+		return nil, ErrOrderReturnInvalidProducts
+	}
+
+	out := &OrderReturnFullOut{}
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		order, err := uc.orderUC.FindOneFullByID(ctx, orderID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !order.Order.Status.AllowReturns() {
+			return ErrOrderReturnNotAllowed
+		}
+
+		returned, err := uc.returnedQuantities(ctx, orderID, orderReturnActiveStatuses)
+		if err != nil {
+			return err
+		}
+
+		details := []string{}
+		refundSum := decimal.Zero
+		prices := map[orderProductKey]decimal.Decimal{}
+
+		for _, item := range input.Products {
+			orderProduct, ok := lo.Find(order.Products, func(product OrderProductWithPrice) bool {
+				return product.key() == item.key()
+			})
+			if !ok {
+				return ErrOrderReturnInvalidProducts
+			}
+
+			available := orderProduct.Quantity - returned[item.key()]
+			if item.Quantity < 1 || item.Quantity > available {
+				details = append(details, fmt.Sprintf("Доступно к возврату по товару #%d: %d шт.", item.ID, available))
+				continue
+			}
+
+			prices[item.key()] = orderProduct.Price
+			refundSum = refundSum.Add(orderProduct.Price.Mul(decimal.NewFromInt(int64(item.Quantity))))
+		}
+
+		if len(details) > 0 {
+			return e.NewErrorFrom(ErrOrderReturnInvalidProducts).AddDetails(details)
+		}
+
+		orderReturn := domain.NewOrderReturn(orderID, input.Reason)
+
+		err = orderReturn.SetRefundSum(refundSum)
+		if err != nil {
+			return err
+		}
+
+		err = uc.repo.Create(ctx, orderReturn)
+		if err != nil {
+			return err
+		}
+
+		out.Return = orderReturn
+		out.Products = make([]*domain.OrderReturnProduct, 0, len(input.Products))
+
+		for _, item := range input.Products {
+			returnProduct, err := domain.NewOrderReturnProduct(orderReturn.ID, item.ID, item.VariantID, item.Quantity, prices[item.key()])
+			if err != nil {
+				return err
+			}
+
+			err = uc.orderReturnProductUC.Create(ctx, returnProduct)
+			if err != nil {
+				return err
+			}
+
+			out.Products = append(out.Products, returnProduct)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (uc *OrderReturnInpl) Approve(ctx context.Context, returnID int64, refundSum *decimal.Decimal) error {
+
+	var orderReturn *domain.OrderReturn
+	var products []*domain.OrderReturnProduct
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		orderReturn, err = uc.repo.FindOneByID(ctx, returnID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		order, err := uc.orderUC.FindOneFullByID(ctx, orderReturn.OrderID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !order.Order.Status.AllowReturns() {
+			return ErrOrderReturnNotAllowed
+		}
+
+		products, err = uc.orderReturnProductUC.FindList(ctx, OrderReturnProductListOptions{
+			ReturnID: &returnID,
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		if len(products) == 0 {
+			return ErrOrderReturnInvalidProducts
+		}
+
+		itemsSum := decimal.Zero
+		for _, item := range products {
+			itemsSum = itemsSum.Add(item.Price.Mul(decimal.NewFromInt(int64(item.Quantity))))
+		}
+
+		// По умолчанию возвращаем полную стоимость товаров, но можно вернуть меньше
+		refund := itemsSum
+		if refundSum != nil {
+			if refundSum.GreaterThan(itemsSum) {
+				return ErrOrderReturnInvalidRefundSum
+			}
+			refund = *refundSum
+		}
+
+		// Учитываем возвраты, которые еще в обработке
+		processingReturns, err := uc.repo.FindList(ctx, OrderReturnListOptions{
+			OrderID:  &orderReturn.OrderID,
+			Statuses: &[]domain.OrderReturnStatus{domain.OrderReturnStatusProcessing},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		refundAvailable := order.Order.RefundAvailable()
+		for _, item := range processingReturns {
+			refundAvailable = refundAvailable.Sub(item.RefundSum)
+		}
+
+		if refund.GreaterThan(refundAvailable) {
+			return ErrOrderReturnInvalidRefundSum
+		}
+
+		err = orderReturn.SetStatus(domain.OrderReturnStatusProcessing)
+		if err != nil {
+			return err
+		}
+
+		err = orderReturn.SetRefundSum(refund)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Update(ctx, orderReturn)
+	})
+	if err != nil {
+		return err
+	}
+
+	//Запускаем воркфлоу и не ждем результат
+	flowIn := productstc.ProcessOrderReturnIn{
+		NotWait:  true,
+		ReturnID: orderReturn.ID,
+		ReturnProducts: lo.Map(products, func(item *domain.OrderReturnProduct, _ int) productstc.ReturnProductsItem {
+			return productstc.ReturnProductsItem{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Quantity:  item.Quantity,
+			}
+		}),
+	}
+
+	err = uc.productsTCl.ProcessOrderReturn(ctx, flowIn)
+	if err != nil {
+		//Воркфлоу не запустился - возвращаем возврат в ожидание
+		if setErr := uc.SetReturnResult(ctx, orderReturn.ID, false); setErr != nil {
+			uc.logger.ErrorContext(ctx, "cant revert order return to pending", slog.Int64("return_id", orderReturn.ID), slog.Any("error", setErr))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (uc *OrderReturnInpl) Reject(ctx context.Context, returnID int64) error {
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		orderReturn, err := uc.repo.FindOneByID(ctx, returnID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = orderReturn.SetStatus(domain.OrderReturnStatusRejected)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Update(ctx, orderReturn)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (uc *OrderReturnInpl) SetReturnResult(ctx context.Context, returnID int64, isOk bool) error {
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		orderReturn, err := uc.repo.FindOneByID(ctx, returnID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Повторный вызов из воркфлоу ничего не меняет
+		if !isOk {
+			if orderReturn.Status == domain.OrderReturnStatusPending {
+				return nil
+			}
+
+			err = orderReturn.SetStatus(domain.OrderReturnStatusPending)
+			if err != nil {
+				return err
+			}
+
+			return uc.repo.Update(ctx, orderReturn)
+		}
+
+		if orderReturn.Status == domain.OrderReturnStatusCompleted {
+			return nil
+		}
+
+		err = orderReturn.SetStatus(domain.OrderReturnStatusCompleted)
+		if err != nil {
+			return err
+		}
+
+		err = uc.repo.Update(ctx, orderReturn)
+		if err != nil {
+			return err
+		}
+
+		order, err := uc.orderUC.FindOneFullByID(ctx, orderReturn.OrderID, nil)
+		if err != nil {
+			return err
+		}
+
+		returned, err := uc.returnedQuantities(ctx, orderReturn.OrderID, []domain.OrderReturnStatus{domain.OrderReturnStatusCompleted})
+		if err != nil {
+			return err
+		}
+
+		// Если вернули весь заказ - переводим его в статус возврата
+		isFullReturn := lo.EveryBy(order.Products, func(item OrderProductWithPrice) bool {
+			return returned[item.key()] >= item.Quantity
+		})
+
+		return uc.orderUC.AddRefund(ctx, orderReturn.OrderID, orderReturn.RefundSum, isFullReturn)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/usecase/uctypes"
+)
+
+type OrderReturnProductListOptions struct {
+	ReturnID  *int64
+	ReturnIDs *[]int64
+}
+
+//go:generate mockery --name=OrderReturnProduct --output=../../tests/mocks --case=underscore
+type OrderReturnProduct interface {
+	FindList(ctx context.Context, listOptions OrderReturnProductListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderReturnProduct, err error)
+	Create(ctx context.Context, item *domain.OrderReturnProduct) (err error)
+}
+
+//go:generate mockery --name=OrderReturnProductRepository --output=../../tests/mocks --case=underscore
+type OrderReturnProductRepository interface {
+	FindList(ctx context.Context, listOptions OrderReturnProductListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.OrderReturnProduct, err error)
+	Create(ctx context.Context, item *domain.OrderReturnProduct) (err error)
+	DeleteByList(ctx context.Context, listOptions OrderReturnProductListOptions) (err error)
+}
+
+type OrderReturnProductInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      OrderReturnProductRepository
+	txManager *manager.Manager
+}
+
+func NewOrderReturnProductInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo OrderReturnProductRepository) *OrderReturnProductInpl {
+	uc := &OrderReturnProductInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *OrderReturnProductInpl) FindList(ctx context.Context, listOptions OrderReturnProductListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.OrderReturnProduct, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *OrderReturnProductInpl) Create(ctx context.Context, item *domain.OrderReturnProduct) error {
+	return uc.repo.Create(ctx, item)
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/m11ano/e"
+	productscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/products"
+	productsgcl "github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/clients/grpc/products"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/orders/internal/infra/config"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+var ErrReportInvalidPeriod = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid report period")
+var ErrReportPeriodTooLong = e.NewErrorFrom(e.ErrBadRequest).SetMessage("report period is too long for selected granularity")
+
+const reportMaxPoints = 400
+
+type ReportGranularity int
+
+const (
+	ReportGranularityDay ReportGranularity = iota
+	ReportGranularityWeek
+	ReportGranularityMonth
+)
+
+var ReportGranularityMap = map[string]ReportGranularity{
+	"day":   ReportGranularityDay,
+	"week":  ReportGranularityWeek,
+	"month": ReportGranularityMonth,
+}
+
+// Начало периода, в который попадает t (неделя начинается с понедельника)
+func (g ReportGranularity) Truncate(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	switch g {
+	case ReportGranularityWeek:
+		weekday := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-weekday, 0, 0, 0, 0, t.Location())
+	case ReportGranularityMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func (g ReportGranularity) Next(t time.Time) time.Time {
+	switch g {
+	case ReportGranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case ReportGranularityMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// Статусы заказов, которые учитываются в выручке
+var ReportRevenueStatuses = []domain.OrderStatus{
+	domain.OrderStatusCreated,
+	domain.OrderStatusPaid,
+	domain.OrderStatusInWork,
+	domain.OrderStatusShipped,
+	domain.OrderStatusDelivered,
+	domain.OrderStatusFinished,
+}
+
+type ReportPeriodIn struct {
+	DateFrom    time.Time
+	DateTo      time.Time
+	Granularity ReportGranularity
+}
+
+type ReportOptions struct {
+	// Включительно
+	DateFrom time.Time
+	// Не включительно
+	DateTo      time.Time
+	Location    *time.Location
+	Granularity ReportGranularity
+	Statuses    *[]domain.OrderStatus
+}
+
+type ReportTopProductsSortField int
+
+const (
+	ReportTopProductsSortFieldQuantity ReportTopProductsSortField = iota
+	ReportTopProductsSortFieldRevenue
+)
+
+type ReportSalesItem struct {
+	Period      time.Time
+	OrdersCount int64
+	Revenue     decimal.Decimal
+	// НДС в составе выручки
+	Tax         decimal.Decimal
+	AvgOrderSum decimal.Decimal
+}
+
+type ReportSalesOut struct {
+	Items       []*ReportSalesItem
+	OrdersCount int64
+	Revenue     decimal.Decimal
+	// НДС в составе выручки
+	Tax         decimal.Decimal
+	AvgOrderSum decimal.Decimal
+}
+
+// Строка заказа для выгрузки продаж с разбивкой по НДС, цены включают налог
+type ReportSalesLine struct {
+	OrderID        int64
+	OrderCreatedAt time.Time
+	OrderStatus    domain.OrderStatus
+	ProductID      int64
+	VariantID      int64
+	SKU            string
+	Name           string
+	Quantity       int32
+	Price          decimal.Decimal
+	TaxRate        decimal.Decimal
+	NetSum         decimal.Decimal
+	TaxSum         decimal.Decimal
+}
+
+func (l *ReportSalesLine) Sum() decimal.Decimal {
+	return l.NetSum.Add(l.TaxSum)
+}
+
+type ReportStatusItem struct {
+	Status      domain.OrderStatus
+	OrdersCount int64
+	OrdersSum   decimal.Decimal
+}
+
+type ReportTopProductItem struct {
+	ProductID   int64
+	Name        string
+	Quantity    int64
+	Revenue     decimal.Decimal
+	OrdersCount int64
+}
+
+//go:generate mockery --name=Report --output=../../tests/mocks --case=underscore
+type Report interface {
+	Sales(ctx context.Context, period ReportPeriodIn) (out *ReportSalesOut, err error)
+	SalesLines(ctx context.Context, period ReportPeriodIn) (items []*ReportSalesLine, err error)
+	OrdersByStatus(ctx context.Context, period ReportPeriodIn) (items []*ReportStatusItem, err error)
+	TopProducts(ctx context.Context, period ReportPeriodIn, sortField ReportTopProductsSortField, limit uint64) (items []*ReportTopProductItem, err error)
+}
+
+//go:generate mockery --name=ReportRepository --output=../../tests/mocks --case=underscore
+type ReportRepository interface {
+	FindSalesByPeriod(ctx context.Context, options ReportOptions) (items []*ReportSalesItem, err error)
+	FindSalesLines(ctx context.Context, options ReportOptions) (items []*ReportSalesLine, err error)
+	FindOrdersByStatus(ctx context.Context, options ReportOptions) (items []*ReportStatusItem, err error)
+	FindTopProducts(ctx context.Context, options ReportOptions, sortField ReportTopProductsSortField, limit uint64) (items []*ReportTopProductItem, err error)
+}
+
+type ReportInpl struct {
+	logger      *slog.Logger
+	config      config.Config
+	repo        ReportRepository
+	productsGCl *productsgcl.ClientConn
+	location    *time.Location
+}
+
+func NewReportInpl(logger *slog.Logger, config config.Config, repo ReportRepository, productsGCl *productsgcl.ClientConn) *ReportInpl {
+	location, err := time.LoadLocation(config.Reports.Timezone)
+	if err != nil {
+		logger.Warn("cant load reports timezone, using UTC", slog.String("timezone", config.Reports.Timezone), slog.Any("error", err))
+		location = time.UTC
+	}
+
+	uc := &ReportInpl{
+		logger:      logger,
+		config:      config,
+		repo:        repo,
+		productsGCl: productsGCl,
+		location:    location,
+	}
+	return uc
+}
+
+func (uc *ReportInpl) buildOptions(period ReportPeriodIn, statuses []domain.OrderStatus) (ReportOptions, error) {
+	fromY, fromM, fromD := period.DateFrom.Date()
+	toY, toM, toD := period.DateTo.Date()
+
+	options := ReportOptions{
+		DateFrom:    time.Date(fromY, fromM, fromD, 0, 0, 0, 0, uc.location),
+		DateTo:      time.Date(toY, toM, toD+1, 0, 0, 0, 0, uc.location),
+		Location:    uc.location,
+		Granularity: period.Granularity,
+		Statuses:    &statuses,
+	}
+
+	if !options.DateFrom.Before(options.DateTo) {
+		return options, ErrReportInvalidPeriod
+	}
+
+	points := 0
+	for cursor := period.Granularity.Truncate(options.DateFrom); cursor.Before(options.DateTo); cursor = period.Granularity.Next(cursor) {
+		points++
+		if points > reportMaxPoints {
+			return options, ErrReportPeriodTooLong
+		}
+	}
+
+	return options, nil
+}
+
+func (uc *ReportInpl) Sales(ctx context.Context, period ReportPeriodIn) (*ReportSalesOut, error) {
+	options, err := uc.buildOptions(period, ReportRevenueStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.repo.FindSalesByPeriod(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsByPeriod := lo.KeyBy(items, func(item *ReportSalesItem) int64 {
+		return item.Period.Unix()
+	})
+
+	out := &ReportSalesOut{
+		Items: []*ReportSalesItem{},
+	}
+
+	// Заполняем пропуски, чтобы на графике были все периоды
+	for cursor := options.Granularity.Truncate(options.DateFrom); cursor.Before(options.DateTo); cursor = options.Granularity.Next(cursor) {
+		item, ok := itemsByPeriod[cursor.Unix()]
+		if !ok {
+			item = &ReportSalesItem{
+				Period:      cursor,
+				Revenue:     decimal.Zero,
+				Tax:         decimal.Zero,
+				AvgOrderSum: decimal.Zero,
+			}
+		}
+
+		if item.OrdersCount > 0 {
+			item.AvgOrderSum = item.Revenue.Div(decimal.NewFromInt(item.OrdersCount)).Round(2)
+		}
+
+		out.Items = append(out.Items, item)
+		out.OrdersCount += item.OrdersCount
+		out.Revenue = out.Revenue.Add(item.Revenue)
+		out.Tax = out.Tax.Add(item.Tax)
+	}
+
+	if out.OrdersCount > 0 {
+		out.AvgOrderSum = out.Revenue.Div(decimal.NewFromInt(out.OrdersCount)).Round(2)
+	}
+
+	return out, nil
+}
+
+// Строки заказов, учтенных в выручке за период, для выгрузки бухгалтерии
+func (uc *ReportInpl) SalesLines(ctx context.Context, period ReportPeriodIn) ([]*ReportSalesLine, error) {
+	options, err := uc.buildOptions(period, ReportRevenueStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.FindSalesLines(ctx, options)
+}
+
+func (uc *ReportInpl) OrdersByStatus(ctx context.Context, period ReportPeriodIn) ([]*ReportStatusItem, error) {
+	statuses := make([]domain.OrderStatus, 0, len(domain.OrderStatuses))
+	for status := range domain.OrderStatuses {
+		if status != domain.OrderStatusNew {
+			statuses = append(statuses, status)
+		}
+	}
+
+	options, err := uc.buildOptions(period, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.FindOrdersByStatus(ctx, options)
+}
+
+func (uc *ReportInpl) TopProducts(ctx context.Context, period ReportPeriodIn, sortField ReportTopProductsSortField, limit uint64) ([]*ReportTopProductItem, error) {
+	options, err := uc.buildOptions(period, ReportRevenueStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.repo.FindTopProducts(ctx, options, sortField, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	productIDs := make([]int64, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+
+	products, err := uc.productsGCl.Client.GetProductsByIds(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	productsByID := lo.KeyBy(products, func(item *productscl.ProductListItem) int64 {
+		return item.ID
+	})
+
+	for _, item := range items {
+		if product, ok := productsByID[item.ProductID]; ok {
+			item.Name = product.Name
+		}
+	}
+
+	return items, nil
+}
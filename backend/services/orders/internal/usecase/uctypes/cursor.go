@@ -0,0 +1,43 @@
+package uctypes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidListCursor = errors.New("invalid list cursor")
+
+// Курсор постраничной выборки: сортировка, значение первого поля сортировки и ID последней полученной записи
+type ListCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+// Для клиента курсор - непрозрачная строка
+func (c ListCursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeListCursor(value string) (*ListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidListCursor, err)
+	}
+
+	cursor := &ListCursor{}
+
+	err = json.Unmarshal(data, cursor)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidListCursor, err)
+	}
+
+	if cursor.ID < 1 {
+		return nil, ErrInvalidListCursor
+	}
+
+	return cursor, nil
+}
@@ -6,6 +6,10 @@ type QueryGetListParams struct {
 	ForUpdate   bool
 	Limit       uint64
 	Offset      uint64
+	// Выборка после записи из курсора, Offset при этом не используется
+	Cursor *ListCursor
+	// Не считать общее количество записей в постраничной выборке
+	WithoutTotal bool
 }
 
 type QueryGetOneParams struct {
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Индекс для отчетов по периодам
+CREATE INDEX idx_order_item_created_at ON order_item(created_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_order_item_created_at;
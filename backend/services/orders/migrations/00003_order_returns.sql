@@ -0,0 +1,42 @@
+-- +goose Up
+
+-- Сумма возвратов по заказу
+ALTER TABLE order_item ADD COLUMN refund_sum NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (refund_sum >= 0);
+
+-- Таблица возвратов
+CREATE TABLE order_return (
+    id              BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    order_id        BIGINT NOT NULL REFERENCES order_item(id) ON DELETE CASCADE,
+    status          INTEGER NOT NULL DEFAULT 0,
+    reason          VARCHAR(1000) NOT NULL,
+    refund_sum      NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (refund_sum >= 0),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL
+);
+CREATE INDEX idx_order_return_order_id ON order_return(order_id);
+CREATE TRIGGER trigger_set_updated_at_on_order_return
+BEFORE UPDATE ON order_return
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Таблица order_return_product
+CREATE TABLE order_return_product (
+    return_id       BIGINT NOT NULL REFERENCES order_return(id) ON DELETE CASCADE,
+    product_id      BIGINT NOT NULL,
+    price           NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    quantity        INTEGER NOT NULL CHECK (quantity >= 1),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (return_id, product_id)
+);
+
+-- +goose Down
+
+-- Удаление order_return_product
+DROP TABLE IF EXISTS order_return_product;
+
+-- Удаление возвратов
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_order_return ON order_return;
+DROP INDEX IF EXISTS idx_order_return_order_id;
+DROP TABLE IF EXISTS order_return;
+
+ALTER TABLE order_item DROP COLUMN IF EXISTS refund_sum;
@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- Разбивка сумм заказа на НДС, цены товаров включают налог
+ALTER TABLE order_item
+    ADD COLUMN net_sum NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (net_sum >= 0),
+    ADD COLUMN tax_sum NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (tax_sum >= 0);
+
+ALTER TABLE order_product
+    ADD COLUMN tax_rate NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate <= 100),
+    ADD COLUMN net_sum  NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (net_sum >= 0),
+    ADD COLUMN tax_sum  NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (tax_sum >= 0);
+
+-- Для уже оформленных заказов ставка неизвестна, считаем их без НДС
+UPDATE order_product SET net_sum = price * quantity;
+UPDATE order_item SET net_sum = order_sum;
+
+-- +goose Down
+
+ALTER TABLE order_product
+    DROP COLUMN IF EXISTS tax_sum,
+    DROP COLUMN IF EXISTS net_sum,
+    DROP COLUMN IF EXISTS tax_rate;
+
+ALTER TABLE order_item
+    DROP COLUMN IF EXISTS tax_sum,
+    DROP COLUMN IF EXISTS net_sum;
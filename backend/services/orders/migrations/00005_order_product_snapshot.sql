@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Снимок данных товара на момент оформления заказа
+ALTER TABLE order_product
+    ADD COLUMN name              VARCHAR(150) NOT NULL DEFAULT '',
+    ADD COLUMN sku               VARCHAR(64)  NOT NULL DEFAULT '',
+    ADD COLUMN image_preview_url TEXT         NOT NULL DEFAULT '';
+
+-- +goose Down
+
+ALTER TABLE order_product
+    DROP COLUMN IF EXISTS image_preview_url,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS name;
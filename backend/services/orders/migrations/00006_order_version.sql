@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Версия заказа для оптимистической блокировки при редактировании
+ALTER TABLE order_item ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+
+ALTER TABLE order_item DROP COLUMN IF EXISTS version;
@@ -0,0 +1,39 @@
+-- +goose Up
+
+-- Задачи массовой смены статуса заказов
+CREATE TABLE order_bulk_job (
+    id              BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    status          INTEGER NOT NULL DEFAULT 0,
+    target_status   INTEGER NOT NULL,
+    total           INTEGER NOT NULL DEFAULT 0 CHECK (total >= 0),
+    processed       INTEGER NOT NULL DEFAULT 0 CHECK (processed >= 0),
+    failed          INTEGER NOT NULL DEFAULT 0 CHECK (failed >= 0),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL,
+    finished_at     TIMESTAMPTZ NULL
+);
+CREATE INDEX idx_order_bulk_job_status ON order_bulk_job(status);
+CREATE TRIGGER trigger_set_updated_at_on_order_bulk_job
+BEFORE UPDATE ON order_bulk_job
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Результаты по каждому заказу задачи
+CREATE TABLE order_bulk_job_item (
+    job_id          BIGINT NOT NULL REFERENCES order_bulk_job(id) ON DELETE CASCADE,
+    order_id        BIGINT NOT NULL,
+    is_processed    BOOLEAN NOT NULL DEFAULT FALSE,
+    is_ok           BOOLEAN NOT NULL DEFAULT FALSE,
+    error           VARCHAR(1000) NOT NULL DEFAULT '',
+
+    PRIMARY KEY (job_id, order_id)
+);
+
+-- +goose Down
+
+-- Удаление результатов задач
+DROP TABLE IF EXISTS order_bulk_job_item;
+
+-- Удаление задач
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_order_bulk_job ON order_bulk_job;
+DROP INDEX IF EXISTS idx_order_bulk_job_status;
+DROP TABLE IF EXISTS order_bulk_job;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Вариант товара в позиции заказа, 0 - товар без вариантов
+ALTER TABLE order_product ADD COLUMN variant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_product DROP CONSTRAINT order_product_pkey;
+ALTER TABLE order_product ADD PRIMARY KEY (order_id, product_id, variant_id);
+
+-- Вариант товара в позиции возврата
+ALTER TABLE order_return_product ADD COLUMN variant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_return_product DROP CONSTRAINT order_return_product_pkey;
+ALTER TABLE order_return_product ADD PRIMARY KEY (return_id, product_id, variant_id);
+
+-- +goose Down
+
+ALTER TABLE order_return_product DROP CONSTRAINT order_return_product_pkey;
+ALTER TABLE order_return_product ADD PRIMARY KEY (return_id, product_id);
+ALTER TABLE order_return_product DROP COLUMN IF EXISTS variant_id;
+
+ALTER TABLE order_product DROP CONSTRAINT order_product_pkey;
+ALTER TABLE order_product ADD PRIMARY KEY (order_id, product_id);
+ALTER TABLE order_product DROP COLUMN IF EXISTS variant_id;
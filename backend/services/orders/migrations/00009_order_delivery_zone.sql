@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Зона доставки заказа, по ней выбирается склад для брони товаров
+ALTER TABLE order_item ADD COLUMN delivery_zone VARCHAR(100) NOT NULL DEFAULT '';
+
+-- +goose Down
+
+ALTER TABLE order_item DROP COLUMN IF EXISTS delivery_zone;
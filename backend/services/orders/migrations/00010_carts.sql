@@ -0,0 +1,40 @@
+-- +goose Up
+
+-- Корзины: анонимные по токену или привязанные к аккаунту покупателя
+CREATE TABLE cart (
+    id              UUID PRIMARY KEY,
+    account_id      UUID NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX idx_cart_account_id ON cart(account_id) WHERE account_id IS NOT NULL;
+CREATE TRIGGER trigger_set_updated_at_on_cart
+BEFORE UPDATE ON cart
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Позиции корзины, price - последняя показанная покупателю цена
+CREATE TABLE cart_item (
+    cart_id         UUID NOT NULL REFERENCES cart(id) ON DELETE CASCADE,
+    product_id      BIGINT NOT NULL,
+    variant_id      BIGINT NOT NULL DEFAULT 0,
+    quantity        INTEGER NOT NULL CHECK (quantity > 0),
+    price           NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL,
+
+    PRIMARY KEY (cart_id, product_id, variant_id)
+);
+CREATE TRIGGER trigger_set_updated_at_on_cart_item
+BEFORE UPDATE ON cart_item
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- +goose Down
+
+-- Удаление позиций корзин
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_cart_item ON cart_item;
+DROP TABLE IF EXISTS cart_item;
+
+-- Удаление корзин
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_cart ON cart;
+DROP INDEX IF EXISTS idx_cart_account_id;
+DROP TABLE IF EXISTS cart;
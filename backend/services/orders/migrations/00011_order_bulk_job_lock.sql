@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- Блокировка задачи обработчиком: пока срок не истек, задачу не захватит другой экземпляр сервиса
+ALTER TABLE order_bulk_job ADD COLUMN locked_until TIMESTAMPTZ NULL;
+-- Причина, по которой задача завершилась с ошибкой
+ALTER TABLE order_bulk_job ADD COLUMN error VARCHAR(1000) NOT NULL DEFAULT '';
+
+-- +goose Down
+
+ALTER TABLE order_bulk_job DROP COLUMN IF EXISTS error;
+ALTER TABLE order_bulk_job DROP COLUMN IF EXISTS locked_until;
@@ -29,11 +29,32 @@ var App = fx.Options(
 	fx.Provide(ProvideS3Client),
 	fx.Provide(ProvideStorageClient),
 	fx.Provide(ProvideGRPCClientsConns),
+	fx.Provide(ProvideNotifier),
 	// Бизнес логика
 	FileModule,
 	ProductModule,
 	ProductSliderImageModule,
 	ProductOrderBlockModule,
+	ProductReturnRestockModule,
+	ProductOrderConsumptionModule,
+	WarehouseModule,
+	WarehouseStockModule,
+	CategoryModule,
+	ProductCategoryModule,
+	ProductTagModule,
+	CollectionModule,
+	CollectionProductModule,
+	ProductSlugHistoryModule,
+	ProductVariantModule,
+	StockMovementModule,
+	ProductReviewModule,
+	ProductPriceHistoryModule,
+	ProductPriceScheduleModule,
+	ProductDraftModule,
+	LowStockAlertModule,
+	ProductImportModule,
+	ProductRelatedModule,
+	ProductBoughtTogetherModule,
 	// Delivery
 	DeliveryHTTP,
 	DeliveryGRPC,
@@ -25,10 +25,35 @@ var Cron = fx.Options(
 	fx.Provide(ProvidePGXPoolWithTxMgr),
 	fx.Provide(ProvideS3Client),
 	fx.Provide(ProvideStorageClient),
+	fx.Provide(ProvideNotifier),
+	fx.Provide(ProvideGRPCClientsConns),
 	// Бизнес логика
 	FileModule,
+	ProductModule,
+	ProductSliderImageModule,
+	ProductOrderBlockModule,
+	ProductReturnRestockModule,
+	ProductOrderConsumptionModule,
+	WarehouseModule,
+	WarehouseStockModule,
+	CategoryModule,
+	ProductCategoryModule,
+	ProductTagModule,
+	CollectionModule,
+	CollectionProductModule,
+	ProductSlugHistoryModule,
+	ProductVariantModule,
+	StockMovementModule,
+	LowStockAlertModule,
+	ProductReviewModule,
+	ProductPriceHistoryModule,
+	ProductPriceScheduleModule,
+	ProductDraftModule,
+	ProductPurgeModule,
+	ProductRelatedModule,
+	ProductBoughtTogetherModule,
 	// Start && Stop invoke
-	fx.Invoke(func(lc fx.Lifecycle, shutdowner fx.Shutdowner, logger *slog.Logger, config config.Config, dbpool *pgxpool.Pool, s3Client *s3.Client, storageClient storage.Client, fileUsecase usecase.File) {
+	fx.Invoke(func(lc fx.Lifecycle, shutdowner fx.Shutdowner, logger *slog.Logger, config config.Config, dbpool *pgxpool.Pool, s3Client *s3.Client, storageClient storage.Client, fileUsecase usecase.File, productUsecase usecase.Product, lowStockAlertUsecase usecase.LowStockAlert, productPriceScheduleUsecase usecase.ProductPriceSchedule, productPurgeUsecase usecase.ProductPurge, productBoughtTogetherUsecase usecase.ProductBoughtTogether) {
 		lc.Append(fx.Hook{
 			OnStart: func(ctx context.Context) error {
 				err := Pgxv5TestConnection(ctx, dbpool, logger, config.DB.MaxAttempt, config.DB.AttemptSleepSeconds)
@@ -92,6 +117,90 @@ var Cron = fx.Options(
 					return err
 				}
 
+				//Отправка уведомлений о низком остатке
+				_, err = c.AddFunc(config.Cron.Tasks.SendLowStockAlerts.Schedule, func() {
+					defer func() {
+						if r := recover(); r != nil {
+							logger.Error("panic", slog.Any("error", r), slog.Any("trackeback", string(debug.Stack())))
+						}
+					}()
+
+					logger.Info("start task send low stock alerts")
+
+					lowStockAlertUsecase.TaskSendAlerts(context.Background())
+				})
+				if err != nil {
+					return err
+				}
+
+				//Применение и окончание запланированных цен
+				_, err = c.AddFunc(config.Cron.Tasks.ApplyPriceSchedules.Schedule, func() {
+					defer func() {
+						if r := recover(); r != nil {
+							logger.Error("panic", slog.Any("error", r), slog.Any("trackeback", string(debug.Stack())))
+						}
+					}()
+
+					logger.Info("start task apply price schedules")
+
+					productPriceScheduleUsecase.TaskApplySchedules(context.Background())
+				})
+				if err != nil {
+					return err
+				}
+
+				//Снятие просроченных броней товаров под заказы
+				_, err = c.AddFunc(config.Cron.Tasks.ReleaseExpiredOrderBlocks.Schedule, func() {
+					defer func() {
+						if r := recover(); r != nil {
+							logger.Error("panic", slog.Any("error", r), slog.Any("trackeback", string(debug.Stack())))
+						}
+					}()
+
+					logger.Info("start task release expired order blocks")
+
+					productUsecase.TaskReleaseExpiredOrderBlocks(context.Background())
+				})
+				if err != nil {
+					return err
+				}
+
+				//Окончательное удаление товаров после срока хранения в архиве
+				_, err = c.AddFunc(config.Cron.Tasks.PurgeDeletedProducts.Schedule, func() {
+					defer func() {
+						if r := recover(); r != nil {
+							logger.Error("panic", slog.Any("error", r), slog.Any("trackeback", string(debug.Stack())))
+						}
+					}()
+
+					logger.Info("start task purge deleted products")
+
+					now := time.Now()
+					maxDeletedAt := now.Add(-1 * time.Duration(config.Cron.Tasks.PurgeDeletedProducts.AfterDeleteDays) * 24 * time.Hour)
+					productPurgeUsecase.TaskPurgeDeletedProducts(context.Background(), maxDeletedAt)
+				})
+				if err != nil {
+					return err
+				}
+
+				//Пересчет товаров, которые часто покупают вместе
+				_, err = c.AddFunc(config.Cron.Tasks.RefreshBoughtTogether.Schedule, func() {
+					defer func() {
+						if r := recover(); r != nil {
+							logger.Error("panic", slog.Any("error", r), slog.Any("trackeback", string(debug.Stack())))
+						}
+					}()
+
+					logger.Info("start task refresh bought together products")
+
+					now := time.Now()
+					createdFrom := now.Add(-1 * time.Duration(config.Cron.Tasks.RefreshBoughtTogether.PeriodDays) * 24 * time.Hour)
+					productBoughtTogetherUsecase.TaskRefresh(context.Background(), createdFrom)
+				})
+				if err != nil {
+					return err
+				}
+
 				c.Start()
 
 				return nil
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var CategoryModule = fx.Module(
+	"category_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewCategory, fx.As(new(usecase.CategoryRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewCategoryInpl, fx.As(new(usecase.Category))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var CollectionModule = fx.Module(
+	"collection_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewCollection, fx.As(new(usecase.CollectionRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewCollectionInpl, fx.As(new(usecase.Collection))),
+	),
+)
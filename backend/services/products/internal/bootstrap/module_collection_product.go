@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var CollectionProductModule = fx.Module(
+	"collection_product_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewCollectionProduct, fx.As(new(usecase.CollectionProductRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewCollectionProductInpl, fx.As(new(usecase.CollectionProduct))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var LowStockAlertModule = fx.Module(
+	"low_stock_alert_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewLowStockAlert, fx.As(new(usecase.LowStockAlertRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewLowStockAlertInpl, fx.As(new(usecase.LowStockAlert))),
+	),
+)
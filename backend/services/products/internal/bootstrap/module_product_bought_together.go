@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductBoughtTogetherModule = fx.Module(
+	"product_bought_together_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductBoughtTogether, fx.As(new(usecase.ProductBoughtTogetherRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductBoughtTogetherInpl, fx.As(new(usecase.ProductBoughtTogether))),
+	),
+)
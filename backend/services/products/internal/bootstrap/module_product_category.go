@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductCategoryModule = fx.Module(
+	"product_category_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductCategory, fx.As(new(usecase.ProductCategoryRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductCategoryInpl, fx.As(new(usecase.ProductCategory))),
+	),
+)
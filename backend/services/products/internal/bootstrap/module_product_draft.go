@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductDraftModule = fx.Module(
+	"product_draft_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductDraft, fx.As(new(usecase.ProductDraftRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductDraftInpl, fx.As(new(usecase.ProductDraft))),
+	),
+)
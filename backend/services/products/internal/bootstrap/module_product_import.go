@@ -0,0 +1,13 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductImportModule = fx.Module(
+	"product_import_module",
+	fx.Provide(
+		fx.Annotate(usecase.NewProductImportInpl, fx.As(new(usecase.ProductImport))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductOrderConsumptionModule = fx.Module(
+	"product_order_consumption_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductOrderConsumption, fx.As(new(usecase.ProductOrderConsumptionRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductOrderConsumptionInpl, fx.As(new(usecase.ProductOrderConsumption))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductPriceHistoryModule = fx.Module(
+	"product_price_history_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductPriceHistory, fx.As(new(usecase.ProductPriceHistoryRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductPriceHistoryInpl, fx.As(new(usecase.ProductPriceHistory))),
+	),
+)
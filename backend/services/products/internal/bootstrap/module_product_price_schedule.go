@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductPriceScheduleModule = fx.Module(
+	"product_price_schedule_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductPriceSchedule, fx.As(new(usecase.ProductPriceScheduleRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductPriceScheduleInpl, fx.As(new(usecase.ProductPriceSchedule))),
+	),
+)
@@ -0,0 +1,13 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductPurgeModule = fx.Module(
+	"product_purge_module",
+	fx.Provide(
+		fx.Annotate(usecase.NewProductPurgeInpl, fx.As(new(usecase.ProductPurge))),
+	),
+)
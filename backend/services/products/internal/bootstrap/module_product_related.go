@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductRelatedModule = fx.Module(
+	"product_related_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductRelated, fx.As(new(usecase.ProductRelatedRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductRelatedInpl, fx.As(new(usecase.ProductRelated))),
+	),
+)
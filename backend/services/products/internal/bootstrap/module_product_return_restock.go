@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductReturnRestockModule = fx.Module(
+	"product_return_restock_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductReturnRestock, fx.As(new(usecase.ProductReturnRestockRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductReturnRestockInpl, fx.As(new(usecase.ProductReturnRestock))),
+	),
+)
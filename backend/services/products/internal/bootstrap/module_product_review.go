@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductReviewModule = fx.Module(
+	"product_review_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductReview, fx.As(new(usecase.ProductReviewRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductReviewInpl, fx.As(new(usecase.ProductReview))),
+	),
+)
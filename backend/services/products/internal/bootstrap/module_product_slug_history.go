@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductSlugHistoryModule = fx.Module(
+	"product_slug_history_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductSlugHistory, fx.As(new(usecase.ProductSlugHistoryRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductSlugHistoryInpl, fx.As(new(usecase.ProductSlugHistory))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductTagModule = fx.Module(
+	"product_tag_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductTag, fx.As(new(usecase.ProductTagRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductTagInpl, fx.As(new(usecase.ProductTag))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var ProductVariantModule = fx.Module(
+	"product_variant_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewProductVariant, fx.As(new(usecase.ProductVariantRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewProductVariantInpl, fx.As(new(usecase.ProductVariant))),
+	),
+)
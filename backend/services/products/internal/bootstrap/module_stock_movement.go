@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var StockMovementModule = fx.Module(
+	"stock_movement_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewStockMovement, fx.As(new(usecase.StockMovementRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewStockMovementInpl, fx.As(new(usecase.StockMovement))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var WarehouseModule = fx.Module(
+	"warehouse_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewWarehouse, fx.As(new(usecase.WarehouseRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewWarehouseInpl, fx.As(new(usecase.Warehouse))),
+	),
+)
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/repository"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"go.uber.org/fx"
+)
+
+var WarehouseStockModule = fx.Module(
+	"warehouse_stock_module",
+	fx.Provide(
+		fx.Private,
+		fx.Annotate(repository.NewWarehouseStock, fx.As(new(usecase.WarehouseStockRepository))),
+	),
+	fx.Provide(
+		fx.Annotate(usecase.NewWarehouseStockInpl, fx.As(new(usecase.WarehouseStock))),
+	),
+)
@@ -13,6 +13,7 @@ import (
 	ordersgcl "github.com/m11ano/mipt-webdev-course/backend/services/products/internal/clients/grpc/orders"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db/txmngr"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/notifier"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/storage"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/storage/s3d"
 	"go.uber.org/fx/fxevent"
@@ -39,12 +40,43 @@ func ProvideS3Client(cfg config.Config) *s3.Client {
 	return s3d.NewS3Client(cfg.Storage.S3Endpoint, cfg.Storage.S3Region, cfg.Storage.S3AccessKey, cfg.Storage.S3SecretKey)
 }
 
+func ProvideNotifier(cfg config.Config, logger *slog.Logger) notifier.Notifier {
+	result := notifier.Multi{}
+
+	for _, channel := range cfg.Notifications.Channels {
+		switch channel {
+		case notifier.ChannelLog:
+			result = append(result, notifier.NewLog(logger))
+		case notifier.ChannelWebhook:
+			result = append(result, notifier.NewWebhook(cfg.Notifications.WebhookURL, time.Duration(cfg.Notifications.TimeoutSec)*time.Second))
+		case notifier.ChannelEmail:
+			result = append(result, notifier.NewEmail(notifier.EmailConfig{
+				Host:     cfg.Notifications.SMTP.Host,
+				Port:     cfg.Notifications.SMTP.Port,
+				Username: cfg.Notifications.SMTP.Username,
+				Password: cfg.Notifications.SMTP.Password,
+				From:     cfg.Notifications.SMTP.From,
+				To:       cfg.Notifications.SMTP.To,
+			}))
+		default:
+			logger.Warn("unknown notification channel", slog.String("channel", channel))
+		}
+	}
+
+	return result
+}
+
 func ProvideFiberApp(cfg config.Config, logger *slog.Logger) *fiber.App {
+	bodyLimit := -1
+	if cfg.HTTP.BodyLimitMB > 0 {
+		bodyLimit = cfg.HTTP.BodyLimitMB * 1024 * 1024
+	}
+
 	fiberApp := NewHTTPFiber(HTTPConfig{
 		UnderProxy:       cfg.HTTP.UnderProxy,
 		UseTraceID:       true,
 		UseLogger:        true,
-		BodyLimit:        -1,
+		BodyLimit:        bodyLimit,
 		CorsAllowOrigins: cfg.HTTP.Cors,
 	}, logger)
 	return fiberApp
@@ -0,0 +1,27 @@
+package productsgrpc
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	productsv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/products"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) ApplyOrderBlockByOrderID(ctx context.Context, in *productsv1.ApplyOrderBlockByOrderIDRequest) (*productsv1.ApplyOrderBlockByOrderIDResponse, error) {
+
+	if in.GetOrderId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty order_id")
+	}
+
+	err := s.productUC.ApplyOrderBlock(ctx, in.GetOrderId())
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return nil, appErr.AsGRPCError()
+		}
+		return nil, err
+	}
+
+	return &productsv1.ApplyOrderBlockByOrderIDResponse{}, nil
+}
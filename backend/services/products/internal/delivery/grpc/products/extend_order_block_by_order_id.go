@@ -0,0 +1,34 @@
+package productsgrpc
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	productsv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/products"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *serverAPI) ExtendOrderBlockByOrderID(ctx context.Context, in *productsv1.ExtendOrderBlockByOrderIDRequest) (*productsv1.ExtendOrderBlockByOrderIDResponse, error) {
+
+	if in.GetOrderId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty order_id")
+	}
+
+	expiresAt, err := s.productUC.ExtendOrderBlock(ctx, in.GetOrderId(), in.GetOrderStatus())
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return nil, appErr.AsGRPCError()
+		}
+		return nil, err
+	}
+
+	out := &productsv1.ExtendOrderBlockByOrderIDResponse{}
+
+	if expiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*expiresAt)
+	}
+
+	return out, nil
+}
@@ -29,6 +29,7 @@ func (s *serverAPI) GetOrderBlockedProductsByOrderID(ctx context.Context, in *pr
 	for i, item := range items {
 		out.Items[i] = &productsv1.OrderBlockedProduct{
 			ProductId: item.ProductID,
+			VariantId: item.VariantID,
 			Quantity:  item.Quantity,
 		}
 	}
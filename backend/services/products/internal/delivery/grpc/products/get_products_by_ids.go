@@ -36,6 +36,7 @@ func (s *serverAPI) GetProductsByIDs(ctx context.Context, in *productsv1.GetProd
 		out.Items[i] = &productsv1.ProductListItem{
 			Id:              item.Product.ID,
 			Name:            item.Product.Name,
+			Sku:             item.Product.SKU,
 			FullDescription: item.Product.FullDescription,
 			Price:           item.Product.Price.String(),
 			IsPublished:     item.Product.IsPublished,
@@ -43,6 +44,22 @@ func (s *serverAPI) GetProductsByIDs(ctx context.Context, in *productsv1.GetProd
 			CreatedAt:       timestamppb.New(item.Product.CreatedAt),
 			UpdatedAt:       toProtoTimestamp(item.Product.UpdatedAt),
 			DeletedAt:       toProtoTimestamp(item.Product.DeletedAt),
+			Variants:        make([]*productsv1.ProductVariant, len(item.Variants)),
+		}
+
+		for j, variant := range item.Variants {
+			out.Items[i].Variants[j] = &productsv1.ProductVariant{
+				Id:             variant.ID,
+				Sku:            variant.SKU,
+				Options:        variant.Options,
+				Price:          variant.Price.String(),
+				StockAvailable: variant.StockAvailable,
+				Sort:           variant.Sort,
+			}
+		}
+
+		if item.Product.TaxRate != nil {
+			out.Items[i].TaxRate = wrapperspb.String(item.Product.TaxRate.String())
 		}
 
 		if item.ProductPreviewFile != nil {
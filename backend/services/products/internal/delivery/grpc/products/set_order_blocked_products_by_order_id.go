@@ -21,11 +21,12 @@ func (s *serverAPI) SetOrderBlockedProductsByOrderID(ctx context.Context, in *pr
 	for i, item := range in.GetItems() {
 		composition[i] = usecase.ProductOrderBlockComposition{
 			ProductID: item.GetProductId(),
+			VariantID: item.GetVariantId(),
 			Quantity:  item.GetQuantity(),
 		}
 	}
 
-	err := s.productUC.SetOrderBlock(ctx, in.GetOrderId(), composition)
+	err := s.productUC.SetOrderBlock(ctx, in.GetOrderId(), in.GetOrderStatus(), in.GetDeliveryZone(), composition)
 	if err != nil {
 		if isAppErr, appErr := e.IsAppError(err); isAppErr {
 			return nil, appErr.AsGRPCError()
@@ -0,0 +1,38 @@
+package productsgrpc
+
+import (
+	"context"
+
+	"github.com/m11ano/e"
+	productsv1 "github.com/m11ano/mipt-webdev-course/backend/protos/gen/go/products"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) SetReturnRestockByReturnID(ctx context.Context, in *productsv1.SetReturnRestockByReturnIDRequest) (*productsv1.SetReturnRestockByReturnIDResponse, error) {
+
+	if in.GetReturnId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty return_id")
+	}
+
+	composition := make([]usecase.ProductReturnRestockComposition, len(in.GetItems()))
+
+	for i, item := range in.GetItems() {
+		composition[i] = usecase.ProductReturnRestockComposition{
+			ProductID: item.GetProductId(),
+			VariantID: item.GetVariantId(),
+			Quantity:  item.GetQuantity(),
+		}
+	}
+
+	err := s.productUC.SetReturnRestock(ctx, in.GetReturnId(), composition)
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return nil, appErr.AsGRPCError()
+		}
+		return nil, err
+	}
+
+	return nil, nil
+}
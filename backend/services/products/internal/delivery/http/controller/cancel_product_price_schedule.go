@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Отменить запланированную цену продукта
+// @Description Если цена уже действует, товару сразу возвращается прежняя цена
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Param schedule_id path int true "Schedule ID"
+// @Success 200 {object} ProductPriceScheduleOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/price/schedules/{schedule_id}/cancel [post]
+func (ctrl *Controller) CancelProductPriceScheduleHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	scheduleID, err := c.ParamsInt("schedule_id")
+	if err != nil {
+		return err
+	}
+
+	item, err := ctrl.priceScheduleUC.Cancel(c.Context(), int64(productID), int64(scheduleID))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(productPriceScheduleToOut(item))
+}
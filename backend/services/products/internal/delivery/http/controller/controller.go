@@ -9,19 +9,43 @@ import (
 )
 
 type Controller struct {
-	logger    *slog.Logger
-	vldtr     *validator.Validate
-	cfg       config.Config
-	fileUC    usecase.File
-	productUC usecase.Product
+	logger           *slog.Logger
+	vldtr            *validator.Validate
+	cfg              config.Config
+	fileUC           usecase.File
+	productUC        usecase.Product
+	categoryUC       usecase.Category
+	productImportUC  usecase.ProductImport
+	stockMovementUC  usecase.StockMovement
+	productReviewUC  usecase.ProductReview
+	priceScheduleUC  usecase.ProductPriceSchedule
+	priceHistoryUC   usecase.ProductPriceHistory
+	productDraftUC   usecase.ProductDraft
+	warehouseUC      usecase.Warehouse
+	warehouseStockUC usecase.WarehouseStock
+	relatedUC        usecase.ProductRelated
+	boughtTogetherUC usecase.ProductBoughtTogether
+	collectionUC     usecase.Collection
 }
 
-func New(logger *slog.Logger, vldtr *validator.Validate, cfg config.Config, fileUC usecase.File, productUC usecase.Product) *Controller {
+func New(logger *slog.Logger, vldtr *validator.Validate, cfg config.Config, fileUC usecase.File, productUC usecase.Product, categoryUC usecase.Category, productImportUC usecase.ProductImport, stockMovementUC usecase.StockMovement, productReviewUC usecase.ProductReview, priceScheduleUC usecase.ProductPriceSchedule, priceHistoryUC usecase.ProductPriceHistory, productDraftUC usecase.ProductDraft, warehouseUC usecase.Warehouse, warehouseStockUC usecase.WarehouseStock, relatedUC usecase.ProductRelated, boughtTogetherUC usecase.ProductBoughtTogether, collectionUC usecase.Collection) *Controller {
 	return &Controller{
-		logger:    logger,
-		vldtr:     vldtr,
-		cfg:       cfg,
-		fileUC:    fileUC,
-		productUC: productUC,
+		logger:           logger,
+		vldtr:            vldtr,
+		cfg:              cfg,
+		fileUC:           fileUC,
+		productUC:        productUC,
+		categoryUC:       categoryUC,
+		productImportUC:  productImportUC,
+		stockMovementUC:  stockMovementUC,
+		productReviewUC:  productReviewUC,
+		priceScheduleUC:  priceScheduleUC,
+		priceHistoryUC:   priceHistoryUC,
+		productDraftUC:   productDraftUC,
+		warehouseUC:      warehouseUC,
+		warehouseStockUC: warehouseStockUC,
+		relatedUC:        relatedUC,
+		boughtTogetherUC: boughtTogetherUC,
+		collectionUC:     collectionUC,
 	}
 }
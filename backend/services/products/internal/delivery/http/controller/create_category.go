@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+)
+
+type CategoryIn struct {
+	ParentID    *int64 `json:"parent_id" validate:"omitempty,gte=1"`
+	Name        string `json:"name" validate:"required,min=1,max=150"`
+	Slug        string `json:"slug" validate:"required,min=1,max=150"`
+	Sort        int32  `json:"sort"`
+	IsPublished bool   `json:"is_published"`
+}
+
+func (ctrl *Controller) CategoryHandlerValidate(in *CategoryIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+func (ctrl *Controller) parseCategoryIn(c *fiber.Ctx) (usecase.CategoryIn, error) {
+	in := &CategoryIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return usecase.CategoryIn{}, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.CategoryHandlerValidate(in)
+	if !ok {
+		return usecase.CategoryIn{}, e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	return usecase.CategoryIn{
+		ParentID:    in.ParentID,
+		Name:        in.Name,
+		Slug:        strings.TrimSpace(in.Slug),
+		Sort:        in.Sort,
+		IsPublished: in.IsPublished,
+	}, nil
+}
+
+// @Summary Создать категорию
+// @Security BearerAuth
+// @Tags categories
+// @Accept  json
+// @Produce  json
+// @Param request body CategoryIn true "JSON"
+// @Success 201 {object} CategoryOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/categories [post]
+func (ctrl *Controller) CreateCategoryHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	in, err := ctrl.parseCategoryIn(c)
+	if err != nil {
+		return err
+	}
+
+	category, err := ctrl.categoryUC.Create(c.Context(), in)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(categoryToOut(category))
+}
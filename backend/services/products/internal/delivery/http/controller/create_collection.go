@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/shopspring/decimal"
+)
+
+type CollectionIn struct {
+	Name        string `json:"name" validate:"required,min=1,max=150"`
+	Slug        string `json:"slug" validate:"required,min=1,max=150"`
+	Description string `json:"description"`
+	// manual - товары задаются вручную, rule - подбираются по условиям rule_*
+	Kind             string     `json:"kind" validate:"required,oneof=manual rule"`
+	RuleTag          *string    `json:"rule_tag" validate:"omitempty,min=1,max=100"`
+	RulePriceFrom    *float64   `json:"rule_price_from" validate:"omitempty,gte=0"`
+	RulePriceTo      *float64   `json:"rule_price_to" validate:"omitempty,gte=0"`
+	RuleCreatedAfter *time.Time `json:"rule_created_after"`
+	Sort             int32      `json:"sort"`
+	IsPublished      bool       `json:"is_published"`
+}
+
+func (ctrl *Controller) CollectionHandlerValidate(in *CollectionIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+func (ctrl *Controller) parseCollectionIn(c *fiber.Ctx) (usecase.CollectionIn, error) {
+	in := &CollectionIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return usecase.CollectionIn{}, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.CollectionHandlerValidate(in)
+	if !ok {
+		return usecase.CollectionIn{}, e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	rule := domain.CollectionRule{
+		Tag:          in.RuleTag,
+		CreatedAfter: in.RuleCreatedAfter,
+	}
+
+	if in.RulePriceFrom != nil {
+		priceFrom := decimal.NewFromFloat(*in.RulePriceFrom).Round(2)
+		rule.PriceFrom = &priceFrom
+	}
+
+	if in.RulePriceTo != nil {
+		priceTo := decimal.NewFromFloat(*in.RulePriceTo).Round(2)
+		rule.PriceTo = &priceTo
+	}
+
+	return usecase.CollectionIn{
+		Name:        in.Name,
+		Slug:        strings.TrimSpace(in.Slug),
+		Description: in.Description,
+		Kind:        domain.CollectionKind(in.Kind),
+		Rule:        rule,
+		Sort:        in.Sort,
+		IsPublished: in.IsPublished,
+	}, nil
+}
+
+// @Summary Создать подборку
+// @Security BearerAuth
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Param request body CollectionIn true "JSON"
+// @Success 201 {object} CollectionOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/collections [post]
+func (ctrl *Controller) CreateCollectionHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	in, err := ctrl.parseCollectionIn(c)
+	if err != nil {
+		return err
+	}
+
+	collection, err := ctrl.collectionUC.Create(c.Context(), in)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(collectionToOut(collection))
+}
@@ -1,6 +1,9 @@
 package controller
 
 import (
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/m11ano/e"
@@ -13,12 +16,19 @@ import (
 
 type CreateProductIn struct {
 	Name               string      `json:"name" validate:"required,min=1,max=150"`
+	Slug               string      `json:"slug" validate:"max=150"`
+	SKU                string      `json:"sku" validate:"max=64"`
 	IsPublished        bool        `json:"is_published"`
+	PublishAt          *time.Time  `json:"publish_at"`
+	UnpublishAt        *time.Time  `json:"unpublish_at"`
 	FullDescription    string      `json:"full_description"`
 	Price              float64     `json:"price" validate:"gte=0"`
+	TaxRate            *float64    `json:"tax_rate" validate:"omitempty,gte=0,lte=100"`
 	StockAvailable     int32       `json:"stock_available" validate:"gte=0"`
+	LowStockThreshold  *int32      `json:"low_stock_threshold" validate:"omitempty,gte=0"`
 	ImagePreviewFileID *uuid.UUID  `json:"image_preview_file_id" validate:"required,uuid"`
 	SliderFilesIDs     []uuid.UUID `json:"slider_files_ids" validate:"min=1,dive,uuid"`
+	CategoryIDs        []int64     `json:"category_ids" validate:"dive,gte=1"`
 }
 
 type CreateProductOut struct {
@@ -65,7 +75,21 @@ func (ctrl *Controller) CreateProductHandler(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
+	err = product.SetTaxRate(taxRateFromIn(in.TaxRate))
+	if err != nil {
+		return err
+	}
+	err = product.SetLowStockThreshold(in.LowStockThreshold)
+	if err != nil {
+		return err
+	}
+	err = product.SetPublishPeriod(in.PublishAt, in.UnpublishAt)
+	if err != nil {
+		return err
+	}
 	product.Name = in.Name
+	product.Slug = strings.TrimSpace(in.Slug)
+	product.SKU = strings.TrimSpace(in.SKU)
 	product.IsPublished = in.IsPublished
 	product.FullDescription = in.FullDescription
 	product.StockAvailable = in.StockAvailable
@@ -74,6 +98,8 @@ func (ctrl *Controller) CreateProductHandler(c *fiber.Ctx) error {
 	createIn := usecase.ProductCreateIn{
 		Product:        product,
 		SliderFilesIDs: in.SliderFilesIDs,
+		CategoryIDs:    in.CategoryIDs,
+		ActorID:        &authData.AccountID,
 	}
 
 	data, _, err := ctrl.productUC.Create(c.Context(), createIn)
@@ -87,3 +113,13 @@ func (ctrl *Controller) CreateProductHandler(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusCreated).JSON(result)
 }
+
+func taxRateFromIn(value *float64) *decimal.Decimal {
+	if value == nil {
+		return nil
+	}
+
+	rate := decimal.NewFromFloat(*value).Round(2)
+
+	return &rate
+}
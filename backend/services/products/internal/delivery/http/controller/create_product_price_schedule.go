@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/shopspring/decimal"
+)
+
+type CreateProductPriceScheduleIn struct {
+	Price    float64   `json:"price" validate:"gte=0"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	// null - цена действует бессрочно
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+func (ctrl *Controller) CreateProductPriceScheduleHandlerValidate(in *CreateProductPriceScheduleIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Запланировать цену продукта на период
+// @Description Цена применяется к товару в начале периода и возвращается по его окончании
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param request body CreateProductPriceScheduleIn true "JSON"
+// @Param id path int true "Product ID"
+// @Success 201 {object} ProductPriceScheduleOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/{id}/price/schedules [post]
+func (ctrl *Controller) CreateProductPriceScheduleHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	in := &CreateProductPriceScheduleIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.CreateProductPriceScheduleHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	item, err := ctrl.priceScheduleUC.Create(c.Context(), usecase.ProductPriceScheduleCreateIn{
+		ProductID: int64(productID),
+		Price:     decimal.NewFromFloat(in.Price).Round(2),
+		StartsAt:  in.StartsAt,
+		EndsAt:    in.EndsAt,
+		ActorID:   &authData.AccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(productPriceScheduleToOut(item))
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+)
+
+type CreateProductReviewIn struct {
+	OrderID    int64     `json:"order_id" validate:"required,gte=1"`
+	SecretKey  uuid.UUID `json:"secret_key" validate:"required"`
+	AuthorName string    `json:"author_name" validate:"required,min=1,max=150"`
+	Rating     int16     `json:"rating" validate:"required,gte=1,lte=5"`
+	Text       string    `json:"text" validate:"max=5000"`
+}
+
+func (ctrl *Controller) CreateProductReviewHandlerValidate(in *CreateProductReviewIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Оставить отзыв о продукте из выполненного заказа
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param request body CreateProductReviewIn true "JSON"
+// @Param id path int true "Product ID"
+// @Success 200 {object} ProductReviewOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 403 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/{id}/reviews [post]
+func (ctrl *Controller) CreateProductReviewHandler(c *fiber.Ctx) error {
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	in := &CreateProductReviewIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.CreateProductReviewHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	item, err := ctrl.productReviewUC.Create(c.Context(), usecase.ProductReviewCreateIn{
+		ProductID:  int64(productID),
+		OrderID:    in.OrderID,
+		SecretKey:  in.SecretKey,
+		AuthorName: in.AuthorName,
+		Rating:     in.Rating,
+		Text:       in.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(productReviewToOut(item, false))
+}
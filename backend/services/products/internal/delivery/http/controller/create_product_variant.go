@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/shopspring/decimal"
+)
+
+type ProductVariantIn struct {
+	SKU            string            `json:"sku" validate:"max=100"`
+	Options        map[string]string `json:"options" validate:"required,min=1"`
+	Price          float64           `json:"price" validate:"gte=0"`
+	StockAvailable int32             `json:"stock_available" validate:"gte=0"`
+	Sort           int32             `json:"sort"`
+}
+
+type ProductVariantOut struct {
+	ID             int64             `json:"id"`
+	SKU            string            `json:"sku"`
+	Options        map[string]string `json:"options"`
+	Price          float64           `json:"price"`
+	StockAvailable int32             `json:"stock_available"`
+	Sort           int32             `json:"sort"`
+}
+
+func (ctrl *Controller) ProductVariantHandlerValidate(in *ProductVariantIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+func (ctrl *Controller) parseProductVariantIn(c *fiber.Ctx) (usecase.ProductVariantIn, error) {
+	in := &ProductVariantIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return usecase.ProductVariantIn{}, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.ProductVariantHandlerValidate(in)
+	if !ok {
+		return usecase.ProductVariantIn{}, e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	return usecase.ProductVariantIn{
+		SKU:            strings.TrimSpace(in.SKU),
+		Options:        in.Options,
+		Price:          decimal.NewFromFloat(in.Price),
+		StockAvailable: in.StockAvailable,
+		Sort:           in.Sort,
+	}, nil
+}
+
+func productVariantToOut(item *domain.ProductVariant) ProductVariantOut {
+	price, _ := item.Price.Float64()
+
+	return ProductVariantOut{
+		ID:             item.ID,
+		SKU:            item.SKU,
+		Options:        item.Options,
+		Price:          price,
+		StockAvailable: item.StockAvailable,
+		Sort:           item.Sort,
+	}
+}
+
+// @Summary Создать вариант товара
+// @Description Остаток товара с вариантами учитывается по вариантам
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Param request body ProductVariantIn true "JSON"
+// @Success 201 {object} ProductVariantOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/{id}/variants [post]
+func (ctrl *Controller) CreateProductVariantHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in, err := ctrl.parseProductVariantIn(c)
+	if err != nil {
+		return err
+	}
+	in.ActorID = &authData.AccountID
+
+	variant, err := ctrl.productUC.CreateVariant(c.Context(), int64(productID), in)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(productVariantToOut(variant))
+}
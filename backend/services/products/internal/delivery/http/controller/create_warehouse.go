@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+)
+
+type WarehouseIn struct {
+	Name     string `json:"name" validate:"required,min=1,max=150"`
+	Code     string `json:"code" validate:"required,min=1,max=150"`
+	Priority int32  `json:"priority"`
+	// Зона доставки и расстояние до нее в километрах
+	DeliveryZones map[string]int32 `json:"delivery_zones" validate:"dive,keys,min=1,max=100,endkeys,gte=0"`
+	IsActive      bool             `json:"is_active"`
+}
+
+func (ctrl *Controller) WarehouseHandlerValidate(in *WarehouseIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+func (ctrl *Controller) parseWarehouseIn(c *fiber.Ctx) (usecase.WarehouseIn, error) {
+	in := &WarehouseIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return usecase.WarehouseIn{}, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.WarehouseHandlerValidate(in)
+	if !ok {
+		return usecase.WarehouseIn{}, e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	return usecase.WarehouseIn{
+		Name:          in.Name,
+		Code:          strings.TrimSpace(in.Code),
+		Priority:      in.Priority,
+		DeliveryZones: in.DeliveryZones,
+		IsActive:      in.IsActive,
+	}, nil
+}
+
+// @Summary Создать склад
+// @Security BearerAuth
+// @Tags warehouses
+// @Accept  json
+// @Produce  json
+// @Param request body WarehouseIn true "JSON"
+// @Success 201 {object} WarehouseOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/warehouses [post]
+func (ctrl *Controller) CreateWarehouseHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	in, err := ctrl.parseWarehouseIn(c)
+	if err != nil {
+		return err
+	}
+
+	warehouse, err := ctrl.warehouseUC.Create(c.Context(), in)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(warehouseToOut(warehouse))
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Удалить категорию
+// @Description Категорию с подкатегориями удалить нельзя, привязки товаров удаляются вместе с категорией
+// @Security BearerAuth
+// @Tags categories
+// @Param id path int true "Category ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/categories/{id} [delete]
+func (ctrl *Controller) DeleteCategoryHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	categoryID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.categoryUC.Delete(c.Context(), int64(categoryID))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Удалить подборку
+// @Description Товары подборки не удаляются, удаляется только их привязка к подборке
+// @Security BearerAuth
+// @Tags collections
+// @Param id path int true "Collection ID"
+// @Success 200 {string} string "OK"
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/collections/{id} [delete]
+func (ctrl *Controller) DeleteCollectionHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	collectionID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.collectionUC.Delete(c.Context(), int64(collectionID))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
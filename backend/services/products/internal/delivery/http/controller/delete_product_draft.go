@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Удалить черновик продукта
+// @Security BearerAuth
+// @Tags products
+// @Param id path int true "Product ID"
+// @Success 200 {string} string "OK"
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/draft [delete]
+func (ctrl *Controller) DeleteProductDraftHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	return ctrl.productDraftUC.Discard(c.Context(), int64(productID))
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Удалить отзыв
+// @Security BearerAuth
+// @Tags products
+// @Param review_id path int true "Review ID"
+// @Success 200 {string} string "OK"
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/reviews/{review_id} [delete]
+func (ctrl *Controller) DeleteProductReviewHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	reviewID, err := c.ParamsInt("review_id")
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.productReviewUC.Delete(c.Context(), int64(reviewID))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Удалить вариант товара
+// @Description Вариант, забронированный в заказах, удалить нельзя
+// @Security BearerAuth
+// @Tags products
+// @Param id path int true "Product ID"
+// @Param variant_id path int true "Variant ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/variants/{variant_id} [delete]
+func (ctrl *Controller) DeleteProductVariantHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	variantID, err := c.ParamsInt("variant_id")
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.productUC.DeleteVariant(c.Context(), int64(productID), int64(variantID))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
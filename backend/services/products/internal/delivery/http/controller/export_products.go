@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/sheet"
+)
+
+var exportContentTypes = map[sheet.Format]string{
+	sheet.FormatCSV:  "text/csv; charset=utf-8",
+	sheet.FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// @Summary Экспорт продуктов в CSV/XLSX
+// @Description Колонки совпадают с импортом, файл можно отредактировать и загрузить обратно.
+// @Security BearerAuth
+// @Tags products
+// @Produce  octet-stream
+// @Param format query string false "Формат файла, enum: csv, xlsx" default(xlsx)
+// @Success 200 {file} file
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/export [get]
+func (ctrl *Controller) ExportProductsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	format := sheet.Format(c.Query("format", string(sheet.FormatXLSX)))
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid format")
+	}
+
+	data, err := ctrl.productImportUC.Export(c.Context(), format)
+	if err != nil {
+		return err
+	}
+
+	c.Attachment(fmt.Sprintf("products-%s.%s", time.Now().Format("2006-01-02"), format))
+	c.Set(fiber.HeaderContentType, contentType)
+
+	return c.Send(data)
+}
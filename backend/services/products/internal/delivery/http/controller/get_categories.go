@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/samber/lo"
+)
+
+type CategoryOut struct {
+	ID          int64  `json:"id"`
+	ParentID    *int64 `json:"parent_id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Sort        int32  `json:"sort"`
+	IsPublished bool   `json:"is_published"`
+}
+
+type GetCategoriesOut struct {
+	Items []CategoryOut `json:"items"`
+}
+
+// @Summary Получить список категорий
+// @Description Плоский список, упорядоченный по sort, дерево строится по parent_id
+// @Tags categories
+// @Produce  json
+// @Success 200 {object} GetCategoriesOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/categories [get]
+func (ctrl *Controller) GetCategoriesHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	listOptions := usecase.CategoryListOptions{}
+
+	if !authData.IsAuth {
+		listOptions.IsPublished = lo.ToPtr(true)
+	}
+
+	data, err := ctrl.categoryUC.FindList(c.Context(), listOptions, nil)
+	if err != nil {
+		return err
+	}
+
+	out := GetCategoriesOut{
+		Items: make([]CategoryOut, len(data)),
+	}
+
+	for i, item := range data {
+		out.Items[i] = categoryToOut(item)
+	}
+
+	return c.JSON(out)
+}
+
+func categoryToOut(item *domain.Category) CategoryOut {
+	return CategoryOut{
+		ID:          item.ID,
+		ParentID:    item.ParentID,
+		Name:        item.Name,
+		Slug:        item.Slug,
+		Sort:        item.Sort,
+		IsPublished: item.IsPublished,
+	}
+}
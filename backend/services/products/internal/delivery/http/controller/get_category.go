@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Получить категорию по ID
+// @Tags categories
+// @Produce  json
+// @Param id path int true "Category ID"
+// @Success 200 {object} CategoryOut
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/categories/{id} [get]
+func (ctrl *Controller) GetCategoryHandler(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	authData := middleware.ExtractAuthData(c)
+
+	category, err := ctrl.categoryUC.FindOneByID(c.Context(), int64(id), nil)
+	if err != nil {
+		return err
+	}
+
+	if !authData.IsAuth && !category.IsPublished {
+		return e.NewErrorFrom(e.ErrNotFound)
+	}
+
+	return c.JSON(categoryToOut(category))
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Получить подборку по ID
+// @Tags collections
+// @Produce  json
+// @Param id path int true "Collection ID"
+// @Success 200 {object} CollectionOut
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/collections/{id} [get]
+func (ctrl *Controller) GetCollectionHandler(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	authData := middleware.ExtractAuthData(c)
+
+	collection, err := ctrl.collectionUC.FindOneByID(c.Context(), int64(id), nil)
+	if err != nil {
+		return err
+	}
+
+	if !authData.IsAuth && !collection.IsPublished {
+		return e.NewErrorFrom(e.ErrNotFound)
+	}
+
+	return c.JSON(collectionToOut(collection))
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Получить подборку по slug
+// @Tags collections
+// @Produce  json
+// @Param slug path string true "Collection slug"
+// @Success 200 {object} CollectionOut
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/collections/slug/{slug} [get]
+func (ctrl *Controller) GetCollectionBySlugHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	collection, err := ctrl.collectionUC.FindOneBySlug(c.Context(), c.Params("slug"))
+	if err != nil {
+		return err
+	}
+
+	if !authData.IsAuth && !collection.IsPublished {
+		return e.NewErrorFrom(e.ErrNotFound)
+	}
+
+	return c.JSON(collectionToOut(collection))
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+// @Summary Получить продукты подборки
+// @Description Товары ручной подборки выводятся в заданном порядке, sort учитывается только для подборки по правилам
+// @Tags collections
+// @Produce  json
+// @Param id path int true "Collection ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param sort query string false "Sort: created_at, price, name, popularity; prefix - for descending order" default(-created_at)
+// @Param skip_total query bool false "Do not count total"
+// @Success 200 {object} GetProductsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/collections/{id}/products [get]
+func (ctrl *Controller) GetCollectionProductsHandler(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", 20)
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	authData := middleware.ExtractAuthData(c)
+
+	collection, err := ctrl.collectionUC.FindOneByID(c.Context(), int64(id), nil)
+	if err != nil {
+		return err
+	}
+
+	var visibleAt *time.Time
+	if !authData.IsAuth {
+		if !collection.IsPublished {
+			return e.NewErrorFrom(e.ErrNotFound)
+		}
+		visibleAt = lo.ToPtr(time.Now())
+	}
+
+	sortStr := c.Query("sort", "-created_at")
+
+	sortItem, err := parseProductsSort(sortStr)
+	if err != nil {
+		return err
+	}
+
+	if sortItem.Field == usecase.ProductListSortFieldRelevance {
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid sort")
+	}
+
+	queryParams := &uctypes.QueryGetListParams{
+		Limit:        uint64(limit),
+		Offset:       uint64(offset),
+		WithoutTotal: c.QueryBool("skip_total", false),
+	}
+
+	data, total, err := ctrl.collectionUC.FindProductsFullPagedList(c.Context(), collection, visibleAt, &[]usecase.ProductListSort{
+		sortItem,
+		{
+			Field:  usecase.ProductListSortFieldID,
+			IsDesc: true,
+		},
+	}, queryParams)
+	if err != nil {
+		return err
+	}
+
+	result := GetProductsOut{
+		Items: make([]GetProductsOutItem, len(data)),
+	}
+
+	if !queryParams.WithoutTotal {
+		result.Total = &total
+	}
+
+	for i, item := range data {
+		result.Items[i] = ctrl.productFullToOutItem(item)
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/samber/lo"
+)
+
+type CollectionOut struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	// manual - товары задаются вручную, rule - подбираются по условиям rule_*
+	Kind             string     `json:"kind"`
+	RuleTag          *string    `json:"rule_tag"`
+	RulePriceFrom    *float64   `json:"rule_price_from"`
+	RulePriceTo      *float64   `json:"rule_price_to"`
+	RuleCreatedAfter *time.Time `json:"rule_created_after"`
+	Sort             int32      `json:"sort"`
+	IsPublished      bool       `json:"is_published"`
+}
+
+type GetCollectionsOut struct {
+	Items []CollectionOut `json:"items"`
+}
+
+// @Summary Получить список подборок
+// @Description Список, упорядоченный по sort
+// @Tags collections
+// @Produce  json
+// @Success 200 {object} GetCollectionsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/collections [get]
+func (ctrl *Controller) GetCollectionsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	listOptions := usecase.CollectionListOptions{}
+
+	if !authData.IsAuth {
+		listOptions.IsPublished = lo.ToPtr(true)
+	}
+
+	data, err := ctrl.collectionUC.FindList(c.Context(), listOptions, nil)
+	if err != nil {
+		return err
+	}
+
+	out := GetCollectionsOut{
+		Items: make([]CollectionOut, len(data)),
+	}
+
+	for i, item := range data {
+		out.Items[i] = collectionToOut(item)
+	}
+
+	return c.JSON(out)
+}
+
+func collectionToOut(item *domain.Collection) CollectionOut {
+	return CollectionOut{
+		ID:               item.ID,
+		Name:             item.Name,
+		Slug:             item.Slug,
+		Description:      item.Description,
+		Kind:             item.Kind.String(),
+		RuleTag:          item.RuleTag,
+		RulePriceFrom:    decimalPtrToFloat(item.RulePriceFrom),
+		RulePriceTo:      decimalPtrToFloat(item.RulePriceTo),
+		RuleCreatedAfter: item.RuleCreatedAfter,
+		Sort:             item.Sort,
+		IsPublished:      item.IsPublished,
+	}
+}
@@ -1,10 +1,14 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/m11ano/e"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/shopspring/decimal"
 )
 
 type FileOut struct {
@@ -13,14 +17,31 @@ type FileOut struct {
 }
 
 type GetProductOut struct {
-	ID              int64     `json:"id"`
-	Name            string    `json:"name"`
-	IsPublished     bool      `json:"is_published"`
-	FullDescription string    `json:"full_description"`
-	Price           float64   `json:"price"`
-	StockAvailable  int32     `json:"stock_available"`
-	ImagePreview    FileOut   `json:"image_preview"`
-	Slider          []FileOut `json:"slider"`
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	SKU         string `json:"sku"`
+	IsPublished bool   `json:"is_published"`
+	// Период публикации, null - без ограничения
+	PublishAt       *time.Time `json:"publish_at"`
+	UnpublishAt     *time.Time `json:"unpublish_at"`
+	FullDescription string     `json:"full_description"`
+	Price           float64    `json:"price"`
+	// Старая цена на время действия запланированной цены
+	CompareAtPrice *float64 `json:"compare_at_price"`
+	TaxRate        *float64 `json:"tax_rate"`
+	StockAvailable int32    `json:"stock_available"`
+	// null - используется порог по умолчанию
+	LowStockThreshold *int32              `json:"low_stock_threshold"`
+	Version           int64               `json:"version"`
+	ImagePreview      FileOut             `json:"image_preview"`
+	Slider            []FileOut           `json:"slider"`
+	CategoryIDs       []int64             `json:"category_ids"`
+	Tags              []string            `json:"tags"`
+	Variants          []ProductVariantOut `json:"variants"`
+	// Средняя оценка по одобренным отзывам, 0 - отзывов нет
+	RatingAvg   float64 `json:"rating_avg"`
+	RatingCount int64   `json:"rating_count"`
 }
 
 // @Summary Получить продукт по ID
@@ -28,6 +49,7 @@ type GetProductOut struct {
 // @Produce  json
 // @Param id path int true "Product ID"
 // @Success 200 {object} GetProductOut
+// @Header 200 {string} ETag "Product version"
 // @Failure 404 {object} middleware.ErrorJSON
 // @Router /products/{id} [get]
 func (ctrl *Controller) GetProductHandler(c *fiber.Ctx) error {
@@ -44,20 +66,49 @@ func (ctrl *Controller) GetProductHandler(c *fiber.Ctx) error {
 		return err
 	}
 
-	if !authData.IsAuth && !data.Product.IsPublished {
+	if !authData.IsAuth && !data.Product.IsVisibleAt(time.Now()) {
 		return e.NewErrorFrom(e.ErrNotFound)
 	}
 
+	out := ctrl.productOneFullToOut(data)
+
+	c.Set(fiber.HeaderETag, versionToETag(data.Product.Version))
+
+	return c.JSON(out)
+}
+
+func (ctrl *Controller) productOneFullToOut(data *usecase.ProductOneFullOut) *GetProductOut {
 	price, _ := data.Product.Price.Float64()
 
 	out := &GetProductOut{
-		ID:              data.Product.ID,
-		Name:            data.Product.Name,
-		IsPublished:     data.Product.IsPublished,
-		FullDescription: data.Product.FullDescription,
-		Price:           price,
-		StockAvailable:  data.Product.StockAvailable,
-		Slider:          make([]FileOut, len(data.SliderFiles)),
+		ID:                data.Product.ID,
+		Name:              data.Product.Name,
+		Slug:              data.Product.Slug,
+		SKU:               data.Product.SKU,
+		IsPublished:       data.Product.IsPublished,
+		PublishAt:         data.Product.PublishAt,
+		UnpublishAt:       data.Product.UnpublishAt,
+		FullDescription:   data.Product.FullDescription,
+		Price:             price,
+		StockAvailable:    data.Product.StockAvailable,
+		LowStockThreshold: data.Product.LowStockThreshold,
+		Version:           data.Product.Version,
+		Slider:            make([]FileOut, len(data.SliderFiles)),
+		CategoryIDs:       data.CategoryIDs,
+		Tags:              data.Tags,
+		Variants:          make([]ProductVariantOut, len(data.Variants)),
+	}
+
+	for i, item := range data.Variants {
+		out.Variants[i] = productVariantToOut(item)
+	}
+
+	out.CompareAtPrice = decimalPtrToFloat(data.Product.CompareAtPrice)
+	out.RatingAvg, out.RatingCount = productRatingToOut(data.Rating)
+
+	if data.Product.TaxRate != nil {
+		taxRate, _ := data.Product.TaxRate.Float64()
+		out.TaxRate = &taxRate
 	}
 
 	if data.ProductPreviewFile != nil {
@@ -74,5 +125,15 @@ func (ctrl *Controller) GetProductHandler(c *fiber.Ctx) error {
 		}
 	}
 
-	return c.JSON(out)
+	return out
+}
+
+func decimalPtrToFloat(value *decimal.Decimal) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	result, _ := value.Float64()
+
+	return &result
 }
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+)
+
+type ProductSlugRedirectOut struct {
+	Slug string `json:"slug"`
+}
+
+// @Summary Получить продукт по slug
+// @Description Если slug устарел, возвращается редирект на актуальный адрес
+// @Tags products
+// @Produce  json
+// @Param slug path string true "Product slug"
+// @Success 200 {object} GetProductOut
+// @Success 301 {object} ProductSlugRedirectOut
+// @Header 200 {string} ETag "Product version"
+// @Header 301 {string} Location "Actual product URL"
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/slug/{slug} [get]
+func (ctrl *Controller) GetProductBySlugHandler(c *fiber.Ctx) error {
+
+	slug := c.Params("slug")
+	if !domain.IsValidSlug(slug) {
+		return e.NewErrorFrom(e.ErrNotFound)
+	}
+
+	authData := middleware.ExtractAuthData(c)
+
+	data, err := ctrl.productUC.FindOneFullBySlug(c.Context(), slug)
+	if err != nil {
+		return err
+	}
+
+	if !authData.IsAuth && !data.Product.IsVisibleAt(time.Now()) {
+		return e.NewErrorFrom(e.ErrNotFound)
+	}
+
+	// Товар найден по прежнему slug
+	if data.Product.Slug != slug {
+		c.Location(strings.TrimSuffix(c.Path(), slug) + data.Product.Slug)
+
+		return c.Status(fiber.StatusMovedPermanently).JSON(ProductSlugRedirectOut{
+			Slug: data.Product.Slug,
+		})
+	}
+
+	c.Set(fiber.HeaderETag, versionToETag(data.Product.Version))
+
+	return c.JSON(ctrl.productOneFullToOut(data))
+}
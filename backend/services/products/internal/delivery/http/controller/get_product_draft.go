@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+type GetProductDraftOut struct {
+	// Товар с примененными правками черновика
+	Preview *GetProductOut `json:"preview"`
+	// Версия товара, на основе которой сохранен черновик
+	BaseVersion int64      `json:"base_version"`
+	ActorID     *uuid.UUID `json:"actor_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// @Summary Предпросмотр черновика продукта
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Success 200 {object} GetProductDraftOut
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/draft [get]
+func (ctrl *Controller) GetProductDraftHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	draft, preview, err := ctrl.productDraftUC.Preview(c.Context(), int64(productID))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(GetProductDraftOut{
+		Preview:     ctrl.productOneFullToOut(preview),
+		BaseVersion: draft.BaseVersion,
+		ActorID:     draft.ActorID,
+		CreatedAt:   draft.CreatedAt,
+		UpdatedAt:   draft.UpdatedAt,
+	})
+}
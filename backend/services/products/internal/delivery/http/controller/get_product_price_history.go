@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type GetProductPriceHistoryOutItem struct {
+	ID             int64      `json:"id"`
+	OldPrice       *float64   `json:"old_price"`
+	NewPrice       float64    `json:"new_price"`
+	CompareAtPrice *float64   `json:"compare_at_price"`
+	Reason         string     `json:"reason"`
+	ScheduleID     *int64     `json:"schedule_id"`
+	ActorID        *uuid.UUID `json:"actor_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type GetProductPriceHistoryOut struct {
+	Items []GetProductPriceHistoryOutItem `json:"items"`
+	Total int64                           `json:"total"`
+}
+
+// @Summary Получить историю изменения цены продукта
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} GetProductPriceHistoryOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/{id}/price/history [get]
+func (ctrl *Controller) GetProductPriceHistoryHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	items, total, err := ctrl.priceHistoryUC.FindPagedList(c.Context(), usecase.ProductPriceHistoryListOptions{
+		ProductID: lo.ToPtr(int64(productID)),
+	}, &uctypes.QueryGetListParams{
+		Limit:  uint64(limit),
+		Offset: uint64(offset),
+	})
+	if err != nil {
+		return err
+	}
+
+	result := GetProductPriceHistoryOut{
+		Items: make([]GetProductPriceHistoryOutItem, 0, len(items)),
+		Total: total,
+	}
+
+	for _, item := range items {
+		newPrice, _ := item.NewPrice.Float64()
+
+		result.Items = append(result.Items, GetProductPriceHistoryOutItem{
+			ID:             item.ID,
+			OldPrice:       decimalPtrToFloat(item.OldPrice),
+			NewPrice:       newPrice,
+			CompareAtPrice: decimalPtrToFloat(item.CompareAtPrice),
+			Reason:         item.Reason.String(),
+			ScheduleID:     item.ScheduleID,
+			ActorID:        item.ActorID,
+			CreatedAt:      item.CreatedAt,
+		})
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/samber/lo"
+)
+
+type ProductPriceScheduleOut struct {
+	ID                 int64      `json:"id"`
+	ProductID          int64      `json:"product_id"`
+	Price              float64    `json:"price"`
+	StartsAt           time.Time  `json:"starts_at"`
+	EndsAt             *time.Time `json:"ends_at"`
+	Status             string     `json:"status"`
+	PrevPrice          *float64   `json:"prev_price"`
+	PrevCompareAtPrice *float64   `json:"prev_compare_at_price"`
+	ActorID            *uuid.UUID `json:"actor_id"`
+	AppliedAt          *time.Time `json:"applied_at"`
+	FinishedAt         *time.Time `json:"finished_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type GetProductPriceSchedulesOut struct {
+	Items []ProductPriceScheduleOut `json:"items"`
+}
+
+var productPriceScheduleStatuses = map[string]domain.ProductPriceScheduleStatus{
+	domain.ProductPriceScheduleStatusScheduled.String(): domain.ProductPriceScheduleStatusScheduled,
+	domain.ProductPriceScheduleStatusActive.String():    domain.ProductPriceScheduleStatusActive,
+	domain.ProductPriceScheduleStatusFinished.String():  domain.ProductPriceScheduleStatusFinished,
+	domain.ProductPriceScheduleStatusCanceled.String():  domain.ProductPriceScheduleStatusCanceled,
+}
+
+// @Summary Получить запланированные цены продукта
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Param status query string false "Status: scheduled, active, finished, canceled"
+// @Success 200 {object} GetProductPriceSchedulesOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/{id}/price/schedules [get]
+func (ctrl *Controller) GetProductPriceSchedulesHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	listOptions := usecase.ProductPriceScheduleListOptions{
+		ProductID: lo.ToPtr(int64(productID)),
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status, ok := productPriceScheduleStatuses[statusStr]
+		if !ok {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid status")
+		}
+		listOptions.Statuses = &[]domain.ProductPriceScheduleStatus{status}
+	}
+
+	items, err := ctrl.priceScheduleUC.FindList(c.Context(), listOptions, nil)
+	if err != nil {
+		return err
+	}
+
+	result := GetProductPriceSchedulesOut{
+		Items: make([]ProductPriceScheduleOut, 0, len(items)),
+	}
+
+	for _, item := range items {
+		result.Items = append(result.Items, productPriceScheduleToOut(item))
+	}
+
+	return c.JSON(result)
+}
+
+func productPriceScheduleToOut(item *domain.ProductPriceSchedule) ProductPriceScheduleOut {
+	price, _ := item.Price.Float64()
+
+	return ProductPriceScheduleOut{
+		ID:                 item.ID,
+		ProductID:          item.ProductID,
+		Price:              price,
+		StartsAt:           item.StartsAt,
+		EndsAt:             item.EndsAt,
+		Status:             item.Status.String(),
+		PrevPrice:          decimalPtrToFloat(item.PrevPrice),
+		PrevCompareAtPrice: decimalPtrToFloat(item.PrevCompareAtPrice),
+		ActorID:            item.ActorID,
+		AppliedAt:          item.AppliedAt,
+		FinishedAt:         item.FinishedAt,
+		CreatedAt:          item.CreatedAt,
+	}
+}
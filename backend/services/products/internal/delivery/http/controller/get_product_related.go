@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/samber/lo"
+)
+
+type GetProductRelatedOut struct {
+	// Сопутствующие товары, подобранные вручную
+	Related []GetProductsOutItem `json:"related"`
+	// Товары, которые часто покупают вместе с этим товаром
+	BoughtTogether []GetProductsOutItem `json:"bought_together"`
+}
+
+// @Summary Получить сопутствующие и часто покупаемые вместе продукты
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Success 200 {object} GetProductRelatedOut
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/related [get]
+func (ctrl *Controller) GetProductRelatedHandler(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	authData := middleware.ExtractAuthData(c)
+
+	listOptions := usecase.ProductListOptions{
+		IDs: &[]int64{int64(id)},
+	}
+
+	// Неавторизованным показываем только опубликованные товары
+	var visibleAt *time.Time
+	if !authData.IsAuth {
+		visibleAt = lo.ToPtr(time.Now())
+		listOptions.VisibleAt = visibleAt
+	}
+
+	products, err := ctrl.productUC.FindList(c.Context(), listOptions, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(products) == 0 {
+		return e.NewErrorFrom(e.ErrNotFound)
+	}
+
+	related, err := ctrl.relatedUC.FindRelatedFullForProduct(c.Context(), int64(id), visibleAt)
+	if err != nil {
+		return err
+	}
+
+	boughtTogether, err := ctrl.boughtTogetherUC.FindBoughtTogetherFullForProduct(c.Context(), int64(id), visibleAt)
+	if err != nil {
+		return err
+	}
+
+	out := GetProductRelatedOut{
+		Related:        make([]GetProductsOutItem, len(related)),
+		BoughtTogether: make([]GetProductsOutItem, len(boughtTogether)),
+	}
+
+	for i, item := range related {
+		out.Related[i] = ctrl.productFullToOutItem(item)
+	}
+
+	for i, item := range boughtTogether {
+		out.BoughtTogether[i] = ctrl.productFullToOutItem(item)
+	}
+
+	return c.JSON(out)
+}
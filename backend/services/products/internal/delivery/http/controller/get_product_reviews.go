@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type ProductReviewOut struct {
+	ID         int64     `json:"id"`
+	ProductID  int64     `json:"product_id"`
+	AuthorName string    `json:"author_name"`
+	Rating     int16     `json:"rating"`
+	Text       string    `json:"text"`
+	CreatedAt  time.Time `json:"created_at"`
+	// Поля модерации возвращаются только администратору
+	OrderID     *int64     `json:"order_id,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	ModeratedBy *uuid.UUID `json:"moderated_by,omitempty"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+}
+
+type GetProductReviewsOut struct {
+	Items []ProductReviewOut `json:"items"`
+	Total int64              `json:"total"`
+}
+
+var productReviewStatuses = map[string]domain.ProductReviewStatus{
+	domain.ProductReviewStatusPending.String():  domain.ProductReviewStatusPending,
+	domain.ProductReviewStatusApproved.String(): domain.ProductReviewStatusApproved,
+	domain.ProductReviewStatusRejected.String(): domain.ProductReviewStatusRejected,
+}
+
+// @Summary Получить отзывы о продукте
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param status query string false "Status: pending, approved, rejected. Only approved reviews are returned for guests"
+// @Success 200 {object} GetProductReviewsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/{id}/reviews [get]
+func (ctrl *Controller) GetProductReviewsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	listOptions := usecase.ProductReviewListOptions{
+		ProductID: lo.ToPtr(int64(productID)),
+	}
+
+	return ctrl.findProductReviews(c, authData, listOptions)
+}
+
+// Общая часть списков отзывов: гостям доступны только одобренные отзывы
+func (ctrl *Controller) findProductReviews(c *fiber.Ctx, authData middleware.ExtractAuthDataOut, listOptions usecase.ProductReviewListOptions) error {
+	limit := c.QueryInt("limit", 20)
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status, ok := productReviewStatuses[statusStr]
+		if !ok {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid status")
+		}
+		listOptions.Status = &status
+	}
+
+	if !authData.IsAuth {
+		listOptions.Status = lo.ToPtr(domain.ProductReviewStatusApproved)
+	}
+
+	items, total, err := ctrl.productReviewUC.FindPagedList(c.Context(), listOptions, &uctypes.QueryGetListParams{
+		Limit:  uint64(limit),
+		Offset: uint64(offset),
+	})
+	if err != nil {
+		return err
+	}
+
+	result := GetProductReviewsOut{
+		Items: make([]ProductReviewOut, 0, len(items)),
+		Total: total,
+	}
+
+	for _, item := range items {
+		result.Items = append(result.Items, productReviewToOut(item, authData.IsAuth))
+	}
+
+	return c.JSON(result)
+}
+
+func productReviewToOut(item *domain.ProductReview, withModeration bool) ProductReviewOut {
+	out := ProductReviewOut{
+		ID:         item.ID,
+		ProductID:  item.ProductID,
+		AuthorName: item.AuthorName,
+		Rating:     item.Rating,
+		Text:       item.Text,
+		CreatedAt:  item.CreatedAt,
+	}
+
+	if withModeration {
+		out.OrderID = &item.OrderID
+		out.Status = item.Status.String()
+		out.ModeratedBy = item.ModeratedBy
+		out.ModeratedAt = item.ModeratedAt
+	}
+
+	return out
+}
+
+func productRatingToOut(rating *domain.ProductRating) (float64, int64) {
+	if rating == nil {
+		return 0, 0
+	}
+
+	avg, _ := rating.Avg.Float64()
+
+	return avg, rating.Count
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type GetProductStockMovementsOutItem struct {
+	ID          int64      `json:"id"`
+	VariantID   int64      `json:"variant_id"`
+	WarehouseID *int64     `json:"warehouse_id"`
+	Delta       int32      `json:"delta"`
+	Quantity    int32      `json:"quantity"`
+	Reason      string     `json:"reason"`
+	OrderID     *int64     `json:"order_id"`
+	ReturnID    *int64     `json:"return_id"`
+	ActorID     *uuid.UUID `json:"actor_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type GetProductStockMovementsOut struct {
+	Items []GetProductStockMovementsOutItem `json:"items"`
+	Total int64                             `json:"total"`
+}
+
+var stockMovementReasons = map[string]domain.StockMovementReason{
+	domain.StockMovementReasonInitial.String():      domain.StockMovementReasonInitial,
+	domain.StockMovementReasonManualAdjust.String(): domain.StockMovementReasonManualAdjust,
+	domain.StockMovementReasonOrderReserve.String(): domain.StockMovementReasonOrderReserve,
+	domain.StockMovementReasonOrderRelease.String(): domain.StockMovementReasonOrderRelease,
+	domain.StockMovementReasonOrderExpire.String():  domain.StockMovementReasonOrderExpire,
+	domain.StockMovementReasonReturn.String():       domain.StockMovementReasonReturn,
+	domain.StockMovementReasonReturnCancel.String(): domain.StockMovementReasonReturnCancel,
+	domain.StockMovementReasonTransferOut.String():  domain.StockMovementReasonTransferOut,
+	domain.StockMovementReasonTransferIn.String():   domain.StockMovementReasonTransferIn,
+}
+
+// @Summary Получить журнал движения остатков продукта
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param variant_id query int false "Variant ID, 0 - product without variants"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param reason query string false "Reason: initial, manual_adjust, order_reserve, order_release, order_expire, return, return_cancel, transfer_out, transfer_in"
+// @Success 200 {object} GetProductStockMovementsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/{id}/stock/movements [get]
+func (ctrl *Controller) GetProductStockMovementsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	listOptions := usecase.StockMovementListOptions{
+		ProductID: lo.ToPtr(int64(productID)),
+	}
+
+	if c.Query("variant_id") != "" {
+		variantID := int64(c.QueryInt("variant_id", -1))
+		if variantID < 0 {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid variant_id")
+		}
+		listOptions.VariantID = &variantID
+	}
+
+	if c.Query("warehouse_id") != "" {
+		warehouseID := int64(c.QueryInt("warehouse_id", 0))
+		if warehouseID < 1 {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid warehouse_id")
+		}
+		listOptions.WarehouseID = &warehouseID
+	}
+
+	if reasonStr := c.Query("reason"); reasonStr != "" {
+		reason, ok := stockMovementReasons[reasonStr]
+		if !ok {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid reason")
+		}
+		listOptions.Reason = &reason
+	}
+
+	items, total, err := ctrl.stockMovementUC.FindPagedList(c.Context(), listOptions, &uctypes.QueryGetListParams{
+		Limit:  uint64(limit),
+		Offset: uint64(offset),
+	})
+	if err != nil {
+		return err
+	}
+
+	result := GetProductStockMovementsOut{
+		Items: make([]GetProductStockMovementsOutItem, 0, len(items)),
+		Total: total,
+	}
+
+	for _, item := range items {
+		result.Items = append(result.Items, GetProductStockMovementsOutItem{
+			ID:          item.ID,
+			VariantID:   item.VariantID,
+			WarehouseID: item.WarehouseID,
+			Delta:       item.Delta,
+			Quantity:    item.Quantity,
+			Reason:      item.Reason.String(),
+			OrderID:     item.OrderID,
+			ReturnID:    item.ReturnID,
+			ActorID:     item.ActorID,
+			CreatedAt:   item.CreatedAt,
+		})
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/samber/lo"
+)
+
+type GetProductWarehouseStockOutItem struct {
+	WarehouseID int64 `json:"warehouse_id"`
+	// 0 - товар без вариантов
+	VariantID int64 `json:"variant_id"`
+	Quantity  int32 `json:"quantity"`
+}
+
+type GetProductWarehouseStockOut struct {
+	Items []GetProductWarehouseStockOutItem `json:"items"`
+}
+
+// @Summary Получить остатки продукта по складам
+// @Description Остаток продукта и вариантов в каталоге равен сумме остатков по складам
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Success 200 {object} GetProductWarehouseStockOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/{id}/stock/warehouses [get]
+func (ctrl *Controller) GetProductWarehouseStockHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	items, err := ctrl.warehouseStockUC.FindList(c.Context(), usecase.WarehouseStockListOptions{
+		ProductID:    lo.ToPtr(int64(productID)),
+		OnlyPositive: true,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	out := GetProductWarehouseStockOut{
+		Items: make([]GetProductWarehouseStockOutItem, len(items)),
+	}
+
+	for i, item := range items {
+		out.Items[i] = GetProductWarehouseStockOutItem{
+			WarehouseID: item.WarehouseID,
+			VariantID:   item.VariantID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return c.JSON(out)
+}
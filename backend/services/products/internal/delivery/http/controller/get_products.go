@@ -3,27 +3,40 @@ package controller
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/m11ano/e"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 )
 
 type GetProductsOutItem struct {
-	ID             int64   `json:"id"`
-	Name           string  `json:"name"`
-	IsPublished    bool    `json:"is_published"`
-	Price          float64 `json:"price"`
-	StockAvailable int32   `json:"stock_available"`
-	ImagePreview   string  `json:"image_preview"`
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug"`
+	SKU         string  `json:"sku"`
+	IsPublished bool    `json:"is_published"`
+	Price       float64 `json:"price"`
+	// Старая цена на время действия запланированной цены
+	CompareAtPrice *float64 `json:"compare_at_price"`
+	StockAvailable int32    `json:"stock_available"`
+	ImagePreview   string   `json:"image_preview"`
+	// Средняя оценка по одобренным отзывам, 0 - отзывов нет
+	RatingAvg   float64 `json:"rating_avg"`
+	RatingCount int64   `json:"rating_count"`
 }
 
 type GetProductsOut struct {
 	Items []GetProductsOutItem `json:"items"`
-	Total int64                `json:"total"`
+	// Не возвращается, если передан skip_total
+	Total *int64 `json:"total,omitempty"`
+	// Курсор следующей страницы, пустой на последней странице или если сортировка не поддерживает курсор
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // @Summary Получить список продуктов
@@ -32,6 +45,16 @@ type GetProductsOut struct {
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
 // @Param ids query string false "IDs of products, separated by comma. If not empty, then limit and offset will be ignored"
+// @Param category_id query int false "Category ID, products of child categories are included"
+// @Param q query string false "Full-text search by name and description"
+// @Param tag query string false "Tag"
+// @Param price_from query number false "Min price, product or any of its variants"
+// @Param price_to query number false "Max price, product or any of its variants"
+// @Param in_stock query bool false "Only products in stock (true) or out of stock (false)"
+// @Param low_stock query bool false "Admin only, ignored for anonymous requests. Only products with stock of product or any of its variants at or below the low stock threshold"
+// @Param sort query string false "Sort: created_at, price, name, popularity, relevance; prefix - for descending order" default(-created_at)
+// @Param cursor query string false "Cursor from next_cursor of the previous page, offset will be ignored. Not supported for popularity and relevance sort"
+// @Param skip_total query bool false "Do not count total"
 // @Success 200 {object} GetProductsOut
 // @Failure 400 {object} middleware.ErrorJSON
 // @Router /products [get]
@@ -63,19 +86,82 @@ func (ctrl *Controller) GetProductsHandler(c *fiber.Ctx) error {
 		}
 	}
 
+	categoryID := c.QueryInt("category_id", 0)
+	if categoryID < 0 {
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid category_id")
+	}
+
 	authData := middleware.ExtractAuthData(c)
 
-	listSort := usecase.ProductListOptions{
-		Sort: &[]usecase.ProductListSort{
-			{
-				Field:  usecase.ProductListSortFieldCreatedAt,
-				IsDesc: true,
-			},
+	listSort := usecase.ProductListOptions{}
+
+	searchQuery := strings.TrimSpace(c.Query("q"))
+	if searchQuery != "" {
+		listSort.Query = &searchQuery
+	}
+
+	if tagStr := c.Query("tag"); tagStr != "" {
+		tag, err := domain.NormalizeProductTag(tagStr)
+		if err != nil {
+			return err
+		}
+		listSort.Tag = &tag
+	}
+
+	priceFrom, err := parseQueryDecimal(c, "price_from")
+	if err != nil {
+		return err
+	}
+	listSort.PriceFrom = priceFrom
+
+	priceTo, err := parseQueryDecimal(c, "price_to")
+	if err != nil {
+		return err
+	}
+	listSort.PriceTo = priceTo
+
+	if inStockStr := c.Query("in_stock"); inStockStr != "" {
+		inStock, err := strconv.ParseBool(inStockStr)
+		if err != nil {
+			return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("invalid in_stock")
+		}
+		listSort.InStock = &inStock
+	}
+
+	// Остатки на складе видны только администратору
+	if authData.IsAuth && c.QueryBool("low_stock", false) {
+		listSort.LowStock = lo.ToPtr(ctrl.cfg.LowStock.DefaultThreshold)
+	}
+
+	// По умолчанию найденные товары сортируем по релевантности, остальные - от новых к старым
+	sortStr := c.Query("sort")
+	if sortStr == "" {
+		sortStr = "-created_at"
+		if listSort.Query != nil {
+			sortStr = "-relevance"
+		}
+	}
+
+	sortItem, err := parseProductsSort(sortStr)
+	if err != nil {
+		return err
+	}
+
+	// Сортировка по id делает порядок стабильным при равных значениях
+	listSort.Sort = &[]usecase.ProductListSort{
+		sortItem,
+		{
+			Field:  usecase.ProductListSortFieldID,
+			IsDesc: true,
 		},
 	}
 
 	if !authData.IsAuth && len(IDs) == 0 {
-		listSort.IsPublished = lo.ToPtr(true)
+		listSort.VisibleAt = lo.ToPtr(time.Now())
+	}
+
+	if categoryID > 0 {
+		listSort.CategoryID = lo.ToPtr(int64(categoryID))
 	}
 
 	if len(IDs) > 0 {
@@ -84,11 +170,20 @@ func (ctrl *Controller) GetProductsHandler(c *fiber.Ctx) error {
 	}
 
 	queryParams := &uctypes.QueryGetListParams{
-		Limit: uint64(limit),
+		Limit:        uint64(limit),
+		WithoutTotal: c.QueryBool("skip_total", false),
 	}
 
 	if len(IDs) == 0 {
 		queryParams.Offset = uint64(offset)
+
+		if cursorStr := c.Query("cursor"); cursorStr != "" {
+			cursor, err := uctypes.DecodeListCursor(cursorStr)
+			if err != nil || cursor.Sort != sortStr {
+				return e.NewErrorFrom(usecase.ErrProductListCursorInvalid)
+			}
+			queryParams.Cursor = cursor
+		}
 	}
 
 	data, total, err := ctrl.productUC.FindFullPagedList(c.Context(), listSort, queryParams)
@@ -98,24 +193,86 @@ func (ctrl *Controller) GetProductsHandler(c *fiber.Ctx) error {
 
 	result := GetProductsOut{
 		Items: make([]GetProductsOutItem, len(data)),
-		Total: total,
 	}
 
-	for i, item := range data {
-		price, _ := item.Product.Price.Float64()
-
-		result.Items[i] = GetProductsOutItem{
-			ID:             item.Product.ID,
-			Name:           item.Product.Name,
-			IsPublished:    item.Product.IsPublished,
-			Price:          price,
-			StockAvailable: item.Product.StockAvailable,
-		}
+	if !queryParams.WithoutTotal {
+		result.Total = &total
+	}
+
+	if len(IDs) == 0 && len(data) == limit {
+		last := data[len(data)-1].Product
 
-		if item.ProductPreviewFile != nil {
-			result.Items[i].ImagePreview = item.ProductPreviewFile.GetURL(&ctrl.cfg)
+		if value, err := usecase.ProductListCursorValue(last, sortItem.Field); err == nil {
+			result.NextCursor = uctypes.ListCursor{
+				Sort:  sortStr,
+				Value: value,
+				ID:    last.ID,
+			}.Encode()
 		}
 	}
 
+	for i, item := range data {
+		result.Items[i] = ctrl.productFullToOutItem(item)
+	}
+
 	return c.JSON(result)
 }
+
+func (ctrl *Controller) productFullToOutItem(item *usecase.ProductFullOut) GetProductsOutItem {
+	price, _ := item.Product.Price.Float64()
+
+	out := GetProductsOutItem{
+		ID:             item.Product.ID,
+		Name:           item.Product.Name,
+		Slug:           item.Product.Slug,
+		SKU:            item.Product.SKU,
+		IsPublished:    item.Product.IsPublished,
+		Price:          price,
+		StockAvailable: item.Product.StockAvailable,
+	}
+
+	out.CompareAtPrice = decimalPtrToFloat(item.Product.CompareAtPrice)
+	out.RatingAvg, out.RatingCount = productRatingToOut(item.Rating)
+
+	if item.ProductPreviewFile != nil {
+		out.ImagePreview = item.ProductPreviewFile.GetURL(&ctrl.cfg)
+	}
+
+	return out
+}
+
+var productsSortFields = map[string]usecase.ProductListSortField{
+	"created_at": usecase.ProductListSortFieldCreatedAt,
+	"price":      usecase.ProductListSortFieldPrice,
+	"name":       usecase.ProductListSortFieldName,
+	"popularity": usecase.ProductListSortFieldPopularity,
+	"relevance":  usecase.ProductListSortFieldRelevance,
+}
+
+func parseProductsSort(value string) (usecase.ProductListSort, error) {
+	isDesc := strings.HasPrefix(value, "-")
+
+	field, ok := productsSortFields[strings.TrimPrefix(value, "-")]
+	if !ok {
+		return usecase.ProductListSort{}, e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid sort")
+	}
+
+	return usecase.ProductListSort{
+		Field:  field,
+		IsDesc: isDesc,
+	}, nil
+}
+
+func parseQueryDecimal(c *fiber.Ctx, key string) (*decimal.Decimal, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	result, err := decimal.NewFromString(value)
+	if err != nil || result.IsNegative() {
+		return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid " + key)
+	}
+
+	return &result, nil
+}
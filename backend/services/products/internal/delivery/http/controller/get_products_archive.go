@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type GetProductsArchiveOutItem struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	SKU          string    `json:"sku"`
+	Price        float64   `json:"price"`
+	ImagePreview string    `json:"image_preview"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	// Дата окончательного удаления, если у товара не будет заказов
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+type GetProductsArchiveOut struct {
+	Items []GetProductsArchiveOutItem `json:"items"`
+	Total int64                       `json:"total"`
+}
+
+// @Summary Получить список удаленных продуктов
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param q query string false "Full-text search by name and description"
+// @Success 200 {object} GetProductsArchiveOut
+// @Failure 401 {object} middleware.ErrorJSON
+// @Router /products/archive [get]
+func (ctrl *Controller) GetProductsArchiveHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	limit := c.QueryInt("limit", 20)
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	listOptions := usecase.ProductListOptions{
+		IsDeleted: lo.ToPtr(true),
+		Sort: &[]usecase.ProductListSort{
+			{
+				Field:  usecase.ProductListSortFieldDeletedAt,
+				IsDesc: true,
+			},
+			{
+				Field:  usecase.ProductListSortFieldID,
+				IsDesc: true,
+			},
+		},
+	}
+
+	searchQuery := strings.TrimSpace(c.Query("q"))
+	if searchQuery != "" {
+		listOptions.Query = &searchQuery
+	}
+
+	data, total, err := ctrl.productUC.FindFullPagedList(c.Context(), listOptions, &uctypes.QueryGetListParams{
+		Limit:       uint64(limit),
+		Offset:      uint64(offset),
+		WithDeleted: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	retention := time.Duration(ctrl.cfg.Cron.Tasks.PurgeDeletedProducts.AfterDeleteDays) * 24 * time.Hour
+
+	result := GetProductsArchiveOut{
+		Items: make([]GetProductsArchiveOutItem, 0, len(data)),
+		Total: total,
+	}
+
+	for _, item := range data {
+		if item.Product.DeletedAt == nil {
+			continue
+		}
+
+		price, _ := item.Product.Price.Float64()
+
+		outItem := GetProductsArchiveOutItem{
+			ID:        item.Product.ID,
+			Name:      item.Product.Name,
+			Slug:      item.Product.Slug,
+			SKU:       item.Product.SKU,
+			Price:     price,
+			DeletedAt: *item.Product.DeletedAt,
+			PurgeAt:   item.Product.DeletedAt.Add(retention),
+		}
+
+		if item.ProductPreviewFile != nil {
+			outItem.ImagePreview = item.ProductPreviewFile.GetURL(&ctrl.cfg)
+		}
+
+		result.Items = append(result.Items, outItem)
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+)
+
+// @Summary Получить отзывы о всех продуктах для модерации
+// @Security BearerAuth
+// @Tags products
+// @Produce  json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param status query string false "Status: pending, approved, rejected"
+// @Param product_id query int false "Product ID"
+// @Success 200 {object} GetProductReviewsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/reviews [get]
+func (ctrl *Controller) GetReviewsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	listOptions := usecase.ProductReviewListOptions{}
+
+	if c.Query("product_id") != "" {
+		productID := int64(c.QueryInt("product_id", 0))
+		if productID < 1 {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid product_id")
+		}
+		listOptions.ProductID = &productID
+	}
+
+	return ctrl.findProductReviews(c, authData, listOptions)
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+)
+
+type WarehouseOut struct {
+	ID            int64            `json:"id"`
+	Name          string           `json:"name"`
+	Code          string           `json:"code"`
+	Priority      int32            `json:"priority"`
+	DeliveryZones map[string]int32 `json:"delivery_zones"`
+	IsActive      bool             `json:"is_active"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+type GetWarehousesOut struct {
+	Items []WarehouseOut `json:"items"`
+}
+
+// @Summary Получить список складов
+// @Description Упорядочен по приоритету, с которым склады используются при бронировании
+// @Security BearerAuth
+// @Tags warehouses
+// @Produce  json
+// @Success 200 {object} GetWarehousesOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/warehouses [get]
+func (ctrl *Controller) GetWarehousesHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	data, err := ctrl.warehouseUC.FindList(c.Context(), usecase.WarehouseListOptions{}, nil)
+	if err != nil {
+		return err
+	}
+
+	out := GetWarehousesOut{
+		Items: make([]WarehouseOut, len(data)),
+	}
+
+	for i, item := range data {
+		out.Items[i] = warehouseToOut(item)
+	}
+
+	return c.JSON(out)
+}
+
+func warehouseToOut(item *domain.Warehouse) WarehouseOut {
+	return WarehouseOut{
+		ID:            item.ID,
+		Name:          item.Name,
+		Code:          item.Code,
+		Priority:      item.Priority,
+		DeliveryZones: item.DeliveryZones,
+		IsActive:      item.IsActive,
+		CreatedAt:     item.CreatedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"io"
+	"mime/multipart"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/sheet"
+)
+
+type ImportProductsOutError struct {
+	Row      int      `json:"row"`
+	Messages []string `json:"messages"`
+}
+
+type ImportProductsOut struct {
+	DryRun  bool                     `json:"dry_run"`
+	Total   int                      `json:"total"`
+	Created int                      `json:"created"`
+	Updated int                      `json:"updated"`
+	Errors  []ImportProductsOutError `json:"errors"`
+}
+
+// @Summary Импорт продуктов из CSV/XLSX
+// @Description Колонки: sku, name, slug, description, price, tax_rate, stock, published, image_preview, images.
+// @Description Товары ищутся по sku: найденные обновляются, остальные создаются. Пустые колонки при обновлении не меняют товар.
+// @Description Изображения - ссылки или пути в архиве, в колонке images разделяются символом |.
+// @Security BearerAuth
+// @Tags products
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "Файл .csv или .xlsx"
+// @Param archive formData file false "zip-архив с изображениями"
+// @Param dry_run query bool false "Только проверить файл"
+// @Success 200 {object} ImportProductsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Router /products/import [post]
+func (ctrl *Controller) ImportProductsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("form field `file` is required")
+	}
+
+	format, ok := sheet.FormatFromFileName(fileHeader.Filename)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("file must be .csv or .xlsx")
+	}
+
+	fileData, err := readFormFile(fileHeader)
+	if err != nil {
+		return err
+	}
+
+	var archiveData []byte
+	archiveHeader, err := c.FormFile("archive")
+	if err == nil {
+		archiveData, err = readFormFile(archiveHeader)
+		if err != nil {
+			return err
+		}
+	}
+
+	dryRun := c.QueryBool("dry_run", false)
+
+	data, err := ctrl.productImportUC.Import(c.Context(), usecase.ProductImportIn{
+		Format:  format,
+		Data:    fileData,
+		Archive: archiveData,
+		DryRun:  dryRun,
+		ActorID: &authData.AccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	result := ImportProductsOut{
+		DryRun:  dryRun,
+		Total:   data.Total,
+		Created: data.Created,
+		Updated: data.Updated,
+		Errors:  make([]ImportProductsOutError, 0, len(data.Errors)),
+	}
+
+	for _, item := range data.Errors {
+		result.Errors = append(result.Errors, ImportProductsOutError{
+			Row:      item.Row,
+			Messages: item.Messages,
+		})
+	}
+
+	return c.JSON(result)
+}
+
+func readFormFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	f, err := fileHeader.Open()
+	if err != nil {
+		return nil, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot open uploaded file")
+	}
+	defer f.Close()
+
+	fileData, err := io.ReadAll(f)
+	if err != nil {
+		return nil, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot read uploaded file")
+	}
+
+	return fileData, nil
+}
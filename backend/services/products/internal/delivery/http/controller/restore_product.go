@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Восстановить удаленный продукт
+// @Description Вместе с товаром восстанавливаются варианты, удаленные вместе с ним
+// @Security BearerAuth
+// @Tags products
+// @Param id path int true "Product ID"
+// @Success 200 {string} string "OK"
+// @Header 200 {string} ETag "New product version"
+// @Failure 404 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/{id}/restore [post]
+func (ctrl *Controller) RestoreProductHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	product, err := ctrl.productUC.Restore(c.Context(), int64(productID))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, versionToETag(product.Version))
+
+	return c.SendStatus(fiber.StatusOK)
+}
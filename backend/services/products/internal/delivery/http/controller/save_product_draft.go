@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/shopspring/decimal"
+)
+
+type SaveProductDraftIn struct {
+	Name               string      `json:"name" validate:"required,min=1,max=150"`
+	FullDescription    string      `json:"full_description"`
+	Price              float64     `json:"price" validate:"gte=0"`
+	ImagePreviewFileID *uuid.UUID  `json:"image_preview_file_id" validate:"required,uuid"`
+	SliderFilesIDs     []uuid.UUID `json:"slider_files_ids" validate:"min=1,dive,uuid"`
+}
+
+func (ctrl *Controller) SaveProductDraftHandlerValidate(in *SaveProductDraftIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Сохранить черновик продукта
+// @Description Правки не видны покупателям до публикации черновика
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Param request body SaveProductDraftIn true "JSON"
+// @Param id path int true "Product ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/draft [put]
+func (ctrl *Controller) SaveProductDraftHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in := &SaveProductDraftIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.SaveProductDraftHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	_, err = ctrl.productDraftUC.Save(c.Context(), int64(productID), usecase.ProductDraftSaveIn{
+		Name:               in.Name,
+		FullDescription:    in.FullDescription,
+		Price:              decimal.NewFromFloat(in.Price).Round(2),
+		ImagePreviewFileID: in.ImagePreviewFileID,
+		SliderFilesIDs:     in.SliderFilesIDs,
+		ActorID:            &authData.AccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+)
+
+type TransferProductStockIn struct {
+	// 0 - товар без вариантов
+	VariantID       int64 `json:"variant_id" validate:"gte=0"`
+	FromWarehouseID int64 `json:"from_warehouse_id" validate:"required,gte=1"`
+	ToWarehouseID   int64 `json:"to_warehouse_id" validate:"required,gte=1,nefield=FromWarehouseID"`
+	Quantity        int32 `json:"quantity" validate:"gte=1"`
+}
+
+func (ctrl *Controller) TransferProductStockHandlerValidate(in *TransferProductStockIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Переместить остаток продукта между складами
+// @Description Общий остаток продукта не меняется
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Param request body TransferProductStockIn true "JSON"
+// @Param id path int true "Product ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/stock/transfer [post]
+func (ctrl *Controller) TransferProductStockHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id", 0)
+	if err != nil {
+		return err
+	}
+
+	in := &TransferProductStockIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.TransferProductStockHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	err = ctrl.productUC.TransferStock(c.Context(), usecase.ProductStockTransferIn{
+		ProductID:       int64(productID),
+		VariantID:       in.VariantID,
+		FromWarehouseID: in.FromWarehouseID,
+		ToWarehouseID:   in.ToWarehouseID,
+		Quantity:        in.Quantity,
+		ActorID:         &authData.AccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Редактировать категорию
+// @Security BearerAuth
+// @Tags categories
+// @Accept  json
+// @Produce  json
+// @Param request body CategoryIn true "JSON"
+// @Param id path int true "Category ID"
+// @Success 200 {object} CategoryOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/categories/{id} [put]
+func (ctrl *Controller) UpdateCategoryHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	categoryID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in, err := ctrl.parseCategoryIn(c)
+	if err != nil {
+		return err
+	}
+
+	category, err := ctrl.categoryUC.Update(c.Context(), int64(categoryID), in)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(categoryToOut(category))
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Редактировать подборку
+// @Description При смене типа подборки на rule товары, заданные вручную, удаляются
+// @Security BearerAuth
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Param request body CollectionIn true "JSON"
+// @Param id path int true "Collection ID"
+// @Success 200 {object} CollectionOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/collections/{id} [put]
+func (ctrl *Controller) UpdateCollectionHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	collectionID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in, err := ctrl.parseCollectionIn(c)
+	if err != nil {
+		return err
+	}
+
+	collection, err := ctrl.collectionUC.Update(c.Context(), int64(collectionID), in)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(collectionToOut(collection))
+}
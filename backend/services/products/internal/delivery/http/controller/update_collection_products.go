@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+)
+
+type UpdateCollectionProductsIn struct {
+	// Порядок в списке - порядок вывода
+	ProductIDs []int64 `json:"product_ids" validate:"max=500,dive,gte=1"`
+}
+
+type CollectionProductsOut struct {
+	ProductIDs []int64 `json:"product_ids"`
+}
+
+func (ctrl *Controller) UpdateCollectionProductsHandlerValidate(in *UpdateCollectionProductsIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Изменить товары ручной подборки
+// @Security BearerAuth
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Param request body UpdateCollectionProductsIn true "JSON"
+// @Param id path int true "Collection ID"
+// @Success 200 {object} CollectionProductsOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 401 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/collections/{id}/products [put]
+func (ctrl *Controller) UpdateCollectionProductsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	collectionID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in := &UpdateCollectionProductsIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.UpdateCollectionProductsHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	ids, err := ctrl.collectionUC.SaveProducts(c.Context(), int64(collectionID), in.ProductIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(CollectionProductsOut{
+		ProductIDs: ids,
+	})
+}
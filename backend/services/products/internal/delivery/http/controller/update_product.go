@@ -1,6 +1,9 @@
 package controller
 
 import (
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/m11ano/e"
@@ -12,11 +15,18 @@ import (
 
 type UpdateProductIn struct {
 	Name               string      `json:"name" validate:"required,min=1,max=150"`
+	Slug               string      `json:"slug" validate:"max=150"`
+	SKU                string      `json:"sku" validate:"max=64"`
 	IsPublished        bool        `json:"is_published"`
+	PublishAt          *time.Time  `json:"publish_at"`
+	UnpublishAt        *time.Time  `json:"unpublish_at"`
 	FullDescription    string      `json:"full_description"`
 	Price              float64     `json:"price" validate:"gte=0"`
+	TaxRate            *float64    `json:"tax_rate" validate:"omitempty,gte=0,lte=100"`
+	LowStockThreshold  *int32      `json:"low_stock_threshold" validate:"omitempty,gte=0"`
 	ImagePreviewFileID *uuid.UUID  `json:"image_preview_file_id" validate:"required,uuid"`
 	SliderFilesIDs     []uuid.UUID `json:"slider_files_ids" validate:"min=1,dive,uuid"`
+	CategoryIDs        []int64     `json:"category_ids" validate:"dive,gte=1"`
 }
 
 func (ctrl *Controller) UpdateProductHandlerValidate(in *UpdateProductIn) (isOk bool, errMsg []string) {
@@ -32,8 +42,12 @@ func (ctrl *Controller) UpdateProductHandlerValidate(in *UpdateProductIn) (isOk
 // @Accept  json
 // @Param request body UpdateProductIn true "JSON"
 // @Param id path int true "Product ID"
+// @Param If-Match header string true "Product version from ETag"
 // @Success 200 {string} string "OK"
+// @Header 200 {string} ETag "New product version"
 // @Failure 400 {object} middleware.ErrorJSON
+// @Failure 412 {object} middleware.ErrorJSON
+// @Failure 428 {object} middleware.ErrorJSON
 // @Router /products/{id} [put]
 func (ctrl *Controller) UpdateProductHandler(c *fiber.Ctx) error {
 
@@ -59,17 +73,33 @@ func (ctrl *Controller) UpdateProductHandler(c *fiber.Ctx) error {
 		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
 	}
 
-	_, _, err = ctrl.productUC.Update(c.Context(), int64(productID), usecase.ProductUpdateIn{
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	product, _, err := ctrl.productUC.Update(c.Context(), int64(productID), usecase.ProductUpdateIn{
+		Version:            version,
 		Name:               in.Name,
+		Slug:               strings.TrimSpace(in.Slug),
+		SKU:                strings.TrimSpace(in.SKU),
 		IsPublished:        in.IsPublished,
+		PublishAt:          in.PublishAt,
+		UnpublishAt:        in.UnpublishAt,
 		FullDescription:    in.FullDescription,
 		Price:              decimal.NewFromFloat(in.Price),
+		TaxRate:            taxRateFromIn(in.TaxRate),
 		ImagePreviewFileID: in.ImagePreviewFileID,
 		SliderFilesIDs:     in.SliderFilesIDs,
+		CategoryIDs:        in.CategoryIDs,
+		LowStockThreshold:  in.LowStockThreshold,
+		ActorID:            &authData.AccountID,
 	})
 	if err != nil {
-		return err
+		return versionMismatchToHTTP(err)
 	}
 
+	c.Set(fiber.HeaderETag, versionToETag(product.Version))
+
 	return c.SendStatus(fiber.StatusOK)
 }
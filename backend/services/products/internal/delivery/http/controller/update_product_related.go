@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+)
+
+type UpdateProductRelatedIn struct {
+	// Порядок в списке - порядок вывода
+	ProductIDs []int64 `json:"product_ids" validate:"max=50,dive,gte=1"`
+}
+
+type UpdateProductRelatedOut struct {
+	ProductIDs []int64 `json:"product_ids"`
+}
+
+func (ctrl *Controller) UpdateProductRelatedHandlerValidate(in *UpdateProductRelatedIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Изменить сопутствующие продукты
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param request body UpdateProductRelatedIn true "JSON"
+// @Param id path int true "Product ID"
+// @Success 200 {object} UpdateProductRelatedOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 401 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/related [put]
+func (ctrl *Controller) UpdateProductRelatedHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in := &UpdateProductRelatedIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.UpdateProductRelatedHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	items, err := ctrl.relatedUC.SaveRelatedForProduct(c.Context(), int64(id), in.ProductIDs)
+	if err != nil {
+		return err
+	}
+
+	out := UpdateProductRelatedOut{
+		ProductIDs: make([]int64, len(items)),
+	}
+
+	for i, item := range items {
+		out.ProductIDs[i] = item.RelatedProductID
+	}
+
+	return c.JSON(out)
+}
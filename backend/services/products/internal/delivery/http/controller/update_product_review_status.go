@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+)
+
+type UpdateProductReviewStatusIn struct {
+	Status string `json:"status" validate:"required,oneof=pending approved rejected"`
+}
+
+func (ctrl *Controller) UpdateProductReviewStatusHandlerValidate(in *UpdateProductReviewStatusIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Изменить статус модерации отзыва
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param request body UpdateProductReviewStatusIn true "JSON"
+// @Param review_id path int true "Review ID"
+// @Success 200 {object} ProductReviewOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/reviews/{review_id}/status [put]
+func (ctrl *Controller) UpdateProductReviewStatusHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	reviewID, err := c.ParamsInt("review_id", 0)
+	if err != nil {
+		return err
+	}
+
+	in := &UpdateProductReviewStatusIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.UpdateProductReviewStatusHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	item, err := ctrl.productReviewUC.SetStatus(c.Context(), int64(reviewID), productReviewStatuses[in.Status], authData.AccountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(productReviewToOut(item, true))
+}
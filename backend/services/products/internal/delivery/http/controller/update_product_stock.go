@@ -10,6 +10,8 @@ import (
 type UpdateProductStockIn struct {
 	Operation string `json:"operation" validate:"required,oneof=increase decrease"`
 	Value     int32  `json:"value" validate:"gte=1"`
+	// Не указан - склад по умолчанию
+	WarehouseID int64 `json:"warehouse_id" validate:"gte=0"`
 }
 
 func (ctrl *Controller) UpdateProductStockHandlerValidate(in *UpdateProductStockIn) (isOk bool, errMsg []string) {
@@ -57,7 +59,7 @@ func (ctrl *Controller) UpdateProductStockHandler(c *fiber.Ctx) error {
 		isIncrease = false
 	}
 
-	err = ctrl.productUC.ChangeStock(c.Context(), int64(productID), in.Value, isIncrease)
+	err = ctrl.productUC.ChangeStock(c.Context(), int64(productID), in.WarehouseID, in.Value, isIncrease, &authData.AccountID)
 	if err != nil {
 		return err
 	}
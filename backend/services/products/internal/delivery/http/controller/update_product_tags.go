@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/validation"
+)
+
+type UpdateProductTagsIn struct {
+	Tags []string `json:"tags" validate:"max=50,dive,required,max=100"`
+}
+
+type UpdateProductTagsOut struct {
+	// Метки после нормализации: нижний регистр, одиночные пробелы
+	Tags    []string `json:"tags"`
+	Version int64    `json:"version"`
+}
+
+func (ctrl *Controller) UpdateProductTagsHandlerValidate(in *UpdateProductTagsIn) (isOk bool, errMsg []string) {
+	if err := ctrl.vldtr.Struct(in); err != nil {
+		return validation.FormatErrors(err)
+	}
+	return true, []string{}
+}
+
+// @Summary Изменить метки продукта
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param request body UpdateProductTagsIn true "JSON"
+// @Param id path int true "Product ID"
+// @Param If-Match header string true "Product version from ETag"
+// @Success 200 {object} UpdateProductTagsOut
+// @Header 200 {string} ETag "New product version"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 401 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Failure 412 {object} middleware.ErrorJSON
+// @Failure 428 {object} middleware.ErrorJSON
+// @Router /products/{id}/tags [put]
+func (ctrl *Controller) UpdateProductTagsHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in := &UpdateProductTagsIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.UpdateProductTagsHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	product, tags, err := ctrl.productUC.SaveTags(c.Context(), int64(id), version, in.Tags)
+	if err != nil {
+		return versionMismatchToHTTP(err)
+	}
+
+	c.Set(fiber.HeaderETag, versionToETag(product.Version))
+
+	return c.JSON(UpdateProductTagsOut{
+		Tags:    tags,
+		Version: product.Version,
+	})
+}
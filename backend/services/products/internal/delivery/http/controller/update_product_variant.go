@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Редактировать вариант товара
+// @Description Остаток варианта не меняется, для этого есть отдельный метод
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Product ID"
+// @Param variant_id path int true "Variant ID"
+// @Param request body ProductVariantIn true "JSON"
+// @Success 200 {object} ProductVariantOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/{id}/variants/{variant_id} [put]
+func (ctrl *Controller) UpdateProductVariantHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	variantID, err := c.ParamsInt("variant_id")
+	if err != nil {
+		return err
+	}
+
+	in, err := ctrl.parseProductVariantIn(c)
+	if err != nil {
+		return err
+	}
+
+	variant, err := ctrl.productUC.UpdateVariant(c.Context(), int64(productID), int64(variantID), in)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(productVariantToOut(variant))
+}
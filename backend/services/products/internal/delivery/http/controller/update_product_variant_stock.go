@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Изменить остаток варианта товара на складе
+// @Security BearerAuth
+// @Tags products
+// @Accept  json
+// @Param request body UpdateProductStockIn true "JSON"
+// @Param id path int true "Product ID"
+// @Param variant_id path int true "Variant ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Router /products/{id}/variants/{variant_id}/stock [post]
+func (ctrl *Controller) UpdateProductVariantStockHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	productID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	variantID, err := c.ParamsInt("variant_id")
+	if err != nil {
+		return err
+	}
+
+	in := &UpdateProductStockIn{}
+
+	if err := c.BodyParser(in); err != nil {
+		return e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage("cannot parse request body")
+	}
+
+	ok, errMsg := ctrl.UpdateProductStockHandlerValidate(in)
+	if !ok {
+		return e.NewErrorFrom(e.ErrBadRequest).AddDetails(errMsg)
+	}
+
+	err = ctrl.productUC.ChangeVariantStock(c.Context(), int64(productID), int64(variantID), in.WarehouseID, in.Value, in.Operation == "increase", &authData.AccountID)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
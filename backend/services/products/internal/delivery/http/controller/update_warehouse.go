@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/delivery/http/middleware"
+)
+
+// @Summary Редактировать склад
+// @Description Отключить можно только склад без остатков и броней
+// @Security BearerAuth
+// @Tags warehouses
+// @Accept  json
+// @Produce  json
+// @Param request body WarehouseIn true "JSON"
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} WarehouseOut
+// @Failure 400 {object} middleware.ErrorJSON
+// @Failure 404 {object} middleware.ErrorJSON
+// @Failure 409 {object} middleware.ErrorJSON
+// @Router /products/warehouses/{id} [put]
+func (ctrl *Controller) UpdateWarehouseHandler(c *fiber.Ctx) error {
+
+	authData := middleware.ExtractAuthData(c)
+
+	if !authData.IsAuth {
+		return e.ErrUnauthorized
+	}
+
+	warehouseID, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+
+	in, err := ctrl.parseWarehouseIn(c)
+	if err != nil {
+		return err
+	}
+
+	warehouse, err := ctrl.warehouseUC.Update(c.Context(), int64(warehouseID), in)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(warehouseToOut(warehouse))
+}
@@ -23,11 +23,59 @@ func RegisterRoutes(app *fiber.App, config config.Config, ctrl *controller.Contr
 	}
 
 	serviceGroup.Get("/", ctrl.GetProductsHandler)
+	serviceGroup.Get("/archive", ctrl.GetProductsArchiveHandler)
 	serviceGroup.Get("/:id<min(1)>", ctrl.GetProductHandler)
+	serviceGroup.Get("/slug/:slug", ctrl.GetProductBySlugHandler)
 	serviceGroup.Post("/", ctrl.CreateProductHandler)
 	serviceGroup.Put("/:id<min(1)>", ctrl.UpdateProductHandler)
 	serviceGroup.Delete("/:id<min(1)>", ctrl.DeleteProductHandler)
+	serviceGroup.Post("/:id<min(1)>/restore", ctrl.RestoreProductHandler)
 	serviceGroup.Post("/:id<min(1)>/stock", ctrl.UpdateProductStockHandler)
+	serviceGroup.Get("/:id<min(1)>/stock/movements", ctrl.GetProductStockMovementsHandler)
+	serviceGroup.Get("/:id<min(1)>/stock/warehouses", ctrl.GetProductWarehouseStockHandler)
+	serviceGroup.Post("/:id<min(1)>/stock/transfer", ctrl.TransferProductStockHandler)
+	serviceGroup.Get("/:id<min(1)>/related", ctrl.GetProductRelatedHandler)
+	serviceGroup.Put("/:id<min(1)>/related", ctrl.UpdateProductRelatedHandler)
+	serviceGroup.Put("/:id<min(1)>/tags", ctrl.UpdateProductTagsHandler)
+	serviceGroup.Get("/:id<min(1)>/reviews", ctrl.GetProductReviewsHandler)
+	serviceGroup.Post("/:id<min(1)>/reviews", ctrl.CreateProductReviewHandler)
+	serviceGroup.Get("/:id<min(1)>/price/history", ctrl.GetProductPriceHistoryHandler)
+	serviceGroup.Get("/:id<min(1)>/price/schedules", ctrl.GetProductPriceSchedulesHandler)
+	serviceGroup.Post("/:id<min(1)>/price/schedules", ctrl.CreateProductPriceScheduleHandler)
+	serviceGroup.Post("/:id<min(1)>/price/schedules/:schedule_id<min(1)>/cancel", ctrl.CancelProductPriceScheduleHandler)
+	serviceGroup.Get("/:id<min(1)>/draft", ctrl.GetProductDraftHandler)
+	serviceGroup.Put("/:id<min(1)>/draft", ctrl.SaveProductDraftHandler)
+	serviceGroup.Post("/:id<min(1)>/draft/publish", ctrl.PublishProductDraftHandler)
+	serviceGroup.Delete("/:id<min(1)>/draft", ctrl.DeleteProductDraftHandler)
+	serviceGroup.Post("/:id<min(1)>/variants", ctrl.CreateProductVariantHandler)
+	serviceGroup.Put("/:id<min(1)>/variants/:variant_id<min(1)>", ctrl.UpdateProductVariantHandler)
+	serviceGroup.Delete("/:id<min(1)>/variants/:variant_id<min(1)>", ctrl.DeleteProductVariantHandler)
+	serviceGroup.Post("/:id<min(1)>/variants/:variant_id<min(1)>/stock", ctrl.UpdateProductVariantStockHandler)
 
 	serviceGroup.Post("/image", ctrl.UploadImageHandler)
+	serviceGroup.Post("/import", ctrl.ImportProductsHandler)
+	serviceGroup.Get("/export", ctrl.ExportProductsHandler)
+
+	serviceGroup.Get("/reviews", ctrl.GetReviewsHandler)
+	serviceGroup.Put("/reviews/:review_id<min(1)>/status", ctrl.UpdateProductReviewStatusHandler)
+	serviceGroup.Delete("/reviews/:review_id<min(1)>", ctrl.DeleteProductReviewHandler)
+
+	serviceGroup.Get("/categories", ctrl.GetCategoriesHandler)
+	serviceGroup.Get("/categories/:id<min(1)>", ctrl.GetCategoryHandler)
+	serviceGroup.Post("/categories", ctrl.CreateCategoryHandler)
+	serviceGroup.Put("/categories/:id<min(1)>", ctrl.UpdateCategoryHandler)
+	serviceGroup.Delete("/categories/:id<min(1)>", ctrl.DeleteCategoryHandler)
+
+	serviceGroup.Get("/collections", ctrl.GetCollectionsHandler)
+	serviceGroup.Get("/collections/:id<min(1)>", ctrl.GetCollectionHandler)
+	serviceGroup.Get("/collections/slug/:slug", ctrl.GetCollectionBySlugHandler)
+	serviceGroup.Get("/collections/:id<min(1)>/products", ctrl.GetCollectionProductsHandler)
+	serviceGroup.Post("/collections", ctrl.CreateCollectionHandler)
+	serviceGroup.Put("/collections/:id<min(1)>", ctrl.UpdateCollectionHandler)
+	serviceGroup.Put("/collections/:id<min(1)>/products", ctrl.UpdateCollectionProductsHandler)
+	serviceGroup.Delete("/collections/:id<min(1)>", ctrl.DeleteCollectionHandler)
+
+	serviceGroup.Get("/warehouses", ctrl.GetWarehousesHandler)
+	serviceGroup.Post("/warehouses", ctrl.CreateWarehouseHandler)
+	serviceGroup.Put("/warehouses/:id<min(1)>", ctrl.UpdateWarehouseHandler)
 }
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/m11ano/e"
+)
+
+var ErrCategoryInvalidSlug = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid slug")
+var ErrCategoryInvalidParent = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid parent category")
+
+type Category struct {
+	ID          int64
+	ParentID    *int64
+	Name        string
+	Slug        string
+	Sort        int32
+	IsPublished bool
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewCategory(id int64) *Category {
+	return &Category{
+		ID:        id,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (c *Category) SetSlug(slug string) error {
+	if !IsValidSlug(slug) {
+		return ErrCategoryInvalidSlug
+	}
+
+	c.Slug = slug
+
+	return nil
+}
+
+// Родитель не может быть самой категорией или ее потомком, descendantIDs - все потомки категории
+func (c *Category) SetParent(parentID *int64, descendantIDs []int64) error {
+	if parentID != nil {
+		if *parentID == c.ID {
+			return ErrCategoryInvalidParent
+		}
+
+		for _, id := range descendantIDs {
+			if id == *parentID {
+				return ErrCategoryInvalidParent
+			}
+		}
+	}
+
+	c.ParentID = parentID
+
+	return nil
+}
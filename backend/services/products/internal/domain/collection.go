@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/m11ano/e"
+	"github.com/shopspring/decimal"
+)
+
+var ErrCollectionInvalidSlug = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid slug")
+var ErrCollectionInvalidKind = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid kind")
+var ErrCollectionRuleRequired = e.NewErrorFrom(e.ErrBadRequest).SetMessage("rule collection must have at least one condition")
+var ErrCollectionRuleNotAllowed = e.NewErrorFrom(e.ErrBadRequest).SetMessage("manual collection cannot have conditions")
+var ErrCollectionInvalidPriceRange = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid price range")
+
+type CollectionKind string
+
+const (
+	// Товары и их порядок задаются вручную
+	CollectionKindManual CollectionKind = "manual"
+	// Товары подбираются по условиям
+	CollectionKindRule CollectionKind = "rule"
+)
+
+func (k CollectionKind) String() string {
+	return string(k)
+}
+
+func (k CollectionKind) IsValid() bool {
+	return k == CollectionKindManual || k == CollectionKindRule
+}
+
+// Условия подборки, товар должен подходить под все заданные условия
+type CollectionRule struct {
+	Tag *string
+	// Цена товара или хотя бы одного из его вариантов
+	PriceFrom *decimal.Decimal
+	PriceTo   *decimal.Decimal
+	// Товары, созданные не раньше
+	CreatedAfter *time.Time
+}
+
+func (r CollectionRule) IsEmpty() bool {
+	return r.Tag == nil && r.PriceFrom == nil && r.PriceTo == nil && r.CreatedAfter == nil
+}
+
+// Подборка товаров для посадочных страниц
+type Collection struct {
+	ID          int64
+	Name        string
+	Slug        string
+	Description string
+	Kind        CollectionKind
+	// Условия подборки по правилам, у ручной подборки не заданы
+	RuleTag          *string
+	RulePriceFrom    *decimal.Decimal
+	RulePriceTo      *decimal.Decimal
+	RuleCreatedAfter *time.Time
+	Sort             int32
+	IsPublished      bool
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewCollection(id int64) *Collection {
+	return &Collection{
+		ID:        id,
+		Kind:      CollectionKindManual,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (c *Collection) SetSlug(slug string) error {
+	if !IsValidSlug(slug) {
+		return ErrCollectionInvalidSlug
+	}
+
+	c.Slug = slug
+
+	return nil
+}
+
+// Ручная подборка не может иметь условий, подборка по правилам - хотя бы одно условие
+func (c *Collection) SetKindAndRule(kind CollectionKind, rule CollectionRule) error {
+	if !kind.IsValid() {
+		return ErrCollectionInvalidKind
+	}
+
+	if kind == CollectionKindManual && !rule.IsEmpty() {
+		return ErrCollectionRuleNotAllowed
+	}
+
+	if kind == CollectionKindRule && rule.IsEmpty() {
+		return ErrCollectionRuleRequired
+	}
+
+	if rule.Tag != nil {
+		tag, err := NormalizeProductTag(*rule.Tag)
+		if err != nil {
+			return err
+		}
+		rule.Tag = &tag
+	}
+
+	if (rule.PriceFrom != nil && rule.PriceFrom.IsNegative()) || (rule.PriceTo != nil && rule.PriceTo.IsNegative()) {
+		return ErrCollectionInvalidPriceRange
+	}
+
+	if rule.PriceFrom != nil && rule.PriceTo != nil && rule.PriceFrom.GreaterThan(*rule.PriceTo) {
+		return ErrCollectionInvalidPriceRange
+	}
+
+	c.Kind = kind
+	c.RuleTag = rule.Tag
+	c.RulePriceFrom = rule.PriceFrom
+	c.RulePriceTo = rule.PriceTo
+	c.RuleCreatedAfter = rule.CreatedAfter
+
+	return nil
+}
+
+func (c *Collection) Rule() CollectionRule {
+	return CollectionRule{
+		Tag:          c.RuleTag,
+		PriceFrom:    c.RulePriceFrom,
+		PriceTo:      c.RulePriceTo,
+		CreatedAfter: c.RuleCreatedAfter,
+	}
+}
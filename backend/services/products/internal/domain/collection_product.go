@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// Товар ручной подборки
+type CollectionProduct struct {
+	CollectionID int64
+	ProductID    int64
+	// Порядок вывода, по возрастанию
+	Sort int32
+
+	CreatedAt time.Time
+}
+
+func NewCollectionProduct(collectionID int64, productID int64, sort int32) *CollectionProduct {
+	return &CollectionProduct{
+		CollectionID: collectionID,
+		ProductID:    productID,
+		Sort:         sort,
+		CreatedAt:    time.Now(),
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// Уведомление о том, что остаток товара или варианта опустился до порога
+type LowStockAlert struct {
+	ProductID int64
+	// 0 - товар без вариантов
+	VariantID int64
+	// Название и артикул на момент пересечения порога
+	Name       string
+	SKU        string
+	Quantity   int32
+	Threshold  int32
+	NotifiedAt *time.Time
+
+	CreatedAt time.Time
+}
+
+func NewLowStockAlert(productID int64, variantID int64, name string, sku string, quantity int32, threshold int32) *LowStockAlert {
+	return &LowStockAlert{
+		ProductID: productID,
+		VariantID: variantID,
+		Name:      name,
+		SKU:       sku,
+		Quantity:  quantity,
+		Threshold: threshold,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (a *LowStockAlert) MarkNotified() {
+	now := time.Now()
+	a.NotifiedAt = &now
+}
@@ -10,18 +10,34 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+var ErrProductInvalidSlug = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid slug")
 var ErrProductInvalidPrice = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid price")
+var ErrProductInvalidTaxRate = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid tax rate")
 var ErrProductStockLowerZero = e.NewErrorFrom(e.ErrBadRequest).SetMessage("stock available must be greater than zero")
+var ErrProductVersionMismatch = e.NewErrorFrom(e.ErrConflict).SetMessage("product was changed by another request")
+var ErrProductInvalidPublishPeriod = e.NewErrorFrom(e.ErrBadRequest).SetMessage("unpublish_at must be later than publish_at")
+var ErrProductInvalidLowStockThreshold = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid low stock threshold")
 var ErrProductStockMoreMax = e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("total stock available must be lower than %d", math.MaxInt32))
 
 type Product struct {
-	ID                 int64
-	IsPublished        bool
-	Name               string
-	FullDescription    string
-	Price              decimal.Decimal
-	StockAvailable     int32
+	ID          int64
+	IsPublished bool
+	// Период публикации, nil - без ограничения
+	PublishAt       *time.Time
+	UnpublishAt     *time.Time
+	Name            string
+	Slug            string
+	SKU             string
+	FullDescription string
+	Price           decimal.Decimal
+	// Старая цена, выставляется на время действия запланированной цены
+	CompareAtPrice *decimal.Decimal
+	TaxRate        *decimal.Decimal
+	StockAvailable int32
+	// Порог низкого остатка, nil - порог по умолчанию
+	LowStockThreshold  *int32
 	ImagePreviewFileID *uuid.UUID
+	Version            int64
 
 	CreatedAt time.Time
 	UpdatedAt *time.Time
@@ -31,10 +47,62 @@ type Product struct {
 func NewProduct(id int64) *Product {
 	return &Product{
 		ID:        id,
+		Version:   1,
 		CreatedAt: time.Now(),
 	}
 }
 
+// Версия растет при каждом редактировании товара через API и используется для оптимистической блокировки
+func (p *Product) CheckVersion(version int64) error {
+	if p.Version != version {
+		return ErrProductVersionMismatch
+	}
+
+	return nil
+}
+
+func (p *Product) IncVersion() {
+	p.Version++
+}
+
+func (p *Product) SetPublishPeriod(publishAt *time.Time, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return ErrProductInvalidPublishPeriod
+	}
+
+	p.PublishAt = publishAt
+	p.UnpublishAt = unpublishAt
+
+	return nil
+}
+
+// Виден ли товар покупателям в указанный момент с учетом периода публикации
+func (p *Product) IsVisibleAt(t time.Time) bool {
+	if !p.IsPublished {
+		return false
+	}
+
+	if p.PublishAt != nil && p.PublishAt.After(t) {
+		return false
+	}
+
+	if p.UnpublishAt != nil && !p.UnpublishAt.After(t) {
+		return false
+	}
+
+	return true
+}
+
+func (p *Product) SetSlug(slug string) error {
+	if !IsValidSlug(slug) {
+		return ErrProductInvalidSlug
+	}
+
+	p.Slug = slug
+
+	return nil
+}
+
 func (p *Product) SetPrice(price decimal.Decimal) error {
 	if price.LessThan(decimal.Zero) {
 		return ErrProductInvalidPrice
@@ -45,6 +113,60 @@ func (p *Product) SetPrice(price decimal.Decimal) error {
 	return nil
 }
 
+// Ставка НДС в процентах, nil - используется ставка по умолчанию
+// Применение запланированной цены. Текущая цена запоминается в расписании,
+// а при снижении цены показывается как старая
+func (p *Product) ApplyScheduledPrice(schedule *ProductPriceSchedule) {
+	schedule.Activate(p.Price, p.CompareAtPrice)
+
+	if schedule.Price.LessThan(p.Price) {
+		prevPrice := p.Price
+		p.CompareAtPrice = &prevPrice
+	} else {
+		p.CompareAtPrice = nil
+	}
+
+	p.Price = schedule.Price
+}
+
+// Возврат цены после окончания расписания. Если цену за время действия изменили вручную, она сохраняется
+func (p *Product) RevertScheduledPrice(schedule *ProductPriceSchedule) {
+	if schedule.PrevPrice != nil && p.Price.Equal(schedule.Price) {
+		p.Price = *schedule.PrevPrice
+	}
+
+	p.CompareAtPrice = schedule.PrevCompareAtPrice
+}
+
+func (p *Product) SetTaxRate(rate *decimal.Decimal) error {
+	if rate != nil && (rate.LessThan(decimal.Zero) || rate.GreaterThan(decimal.NewFromInt(100))) {
+		return ErrProductInvalidTaxRate
+	}
+
+	p.TaxRate = rate
+
+	return nil
+}
+
+func (p *Product) SetLowStockThreshold(value *int32) error {
+	if value != nil && *value < 0 {
+		return ErrProductInvalidLowStockThreshold
+	}
+
+	p.LowStockThreshold = value
+
+	return nil
+}
+
+// Порог низкого остатка для товара и его вариантов
+func (p *Product) GetLowStockThreshold(defaultThreshold int32) int32 {
+	if p.LowStockThreshold != nil {
+		return *p.LowStockThreshold
+	}
+
+	return defaultThreshold
+}
+
 func (p *Product) SetStockAvailable(value int32) error {
 	if value < 0 {
 		return ErrProductStockLowerZero
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// Товар, который часто покупают вместе с товаром, рассчитывается по заказам
+type ProductBoughtTogether struct {
+	ProductID        int64
+	RelatedProductID int64
+	// Количество заказов, в которых товары были куплены вместе
+	OrdersCount int64
+
+	CreatedAt time.Time
+}
+
+func NewProductBoughtTogether(productID int64, relatedProductID int64, ordersCount int64) *ProductBoughtTogether {
+	return &ProductBoughtTogether{
+		ProductID:        productID,
+		RelatedProductID: relatedProductID,
+		OrdersCount:      ordersCount,
+		CreatedAt:        time.Now(),
+	}
+}
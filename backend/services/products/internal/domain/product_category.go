@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+type ProductCategory struct {
+	ProductID  int64
+	CategoryID int64
+
+	CreatedAt time.Time
+}
+
+func NewProductCategory(productID int64, categoryID int64) *ProductCategory {
+	return &ProductCategory{
+		ProductID:  productID,
+		CategoryID: categoryID,
+		CreatedAt:  time.Now(),
+	}
+}
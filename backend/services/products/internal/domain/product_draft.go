@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+var ErrProductDraftOutdated = e.NewErrorFrom(e.ErrConflict).SetMessage("product was changed after the draft was saved")
+
+// Черновик изменений товара. Правки не видны покупателям до публикации черновика
+type ProductDraft struct {
+	ProductID          int64
+	Name               string
+	FullDescription    string
+	Price              decimal.Decimal
+	ImagePreviewFileID *uuid.UUID
+	SliderFilesIDs     []uuid.UUID
+	// Версия товара, на основе которой сохранен черновик
+	BaseVersion int64
+	ActorID     *uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewProductDraft(productID int64) *ProductDraft {
+	return &ProductDraft{
+		ProductID:      productID,
+		SliderFilesIDs: []uuid.UUID{},
+		CreatedAt:      time.Now(),
+	}
+}
+
+func (d *ProductDraft) SetPrice(price decimal.Decimal) error {
+	if price.LessThan(decimal.Zero) {
+		return ErrProductInvalidPrice
+	}
+
+	d.Price = price
+
+	return nil
+}
+
+// Все файлы черновика: превью и слайдер
+func (d *ProductDraft) FilesIDs() []uuid.UUID {
+	result := make([]uuid.UUID, 0, len(d.SliderFilesIDs)+1)
+	if d.ImagePreviewFileID != nil {
+		result = append(result, *d.ImagePreviewFileID)
+	}
+	result = append(result, d.SliderFilesIDs...)
+
+	return lo.Uniq(result)
+}
+
+// Черновик можно опубликовать, только если товар не менялся после его сохранения
+func (d *ProductDraft) CheckBaseVersion(product *Product) error {
+	if product.Version != d.BaseVersion {
+		return ErrProductDraftOutdated
+	}
+
+	return nil
+}
+
+// Копия товара с правками из черновика для предпросмотра
+func (d *ProductDraft) ApplyTo(product *Product) *Product {
+	result := *product
+	result.Name = d.Name
+	result.FullDescription = d.FullDescription
+	result.Price = d.Price
+	result.ImagePreviewFileID = d.ImagePreviewFileID
+
+	return &result
+}
@@ -10,17 +10,25 @@ var ErrProductOrderBlockQuantityLess1 = e.NewErrorFrom(e.ErrBadRequest).SetMessa
 
 type ProductOrderBlock struct {
 	ProductID int64
+	// 0 - товар без вариантов
+	VariantID int64
 	OrderID   int64
-	Quantity  int32
+	// Склад, на котором забронирован товар
+	WarehouseID int64
+	Quantity    int32
+	// nil - бронь бессрочная
+	ExpiresAt *time.Time
 
 	CreatedAt time.Time
 }
 
-func NewProductOrderBlock(productID int64, OrderID int64, Quantity int32) (*ProductOrderBlock, error) {
+func NewProductOrderBlock(productID int64, variantID int64, OrderID int64, warehouseID int64, Quantity int32) (*ProductOrderBlock, error) {
 	item := &ProductOrderBlock{
-		ProductID: productID,
-		OrderID:   OrderID,
-		CreatedAt: time.Now(),
+		ProductID:   productID,
+		VariantID:   variantID,
+		OrderID:     OrderID,
+		WarehouseID: warehouseID,
+		CreatedAt:   time.Now(),
 	}
 
 	err := item.SetQuantity(Quantity)
@@ -31,6 +39,10 @@ func NewProductOrderBlock(productID int64, OrderID int64, Quantity int32) (*Prod
 	return item, nil
 }
 
+func (pb *ProductOrderBlock) IsExpired(now time.Time) bool {
+	return pb.ExpiresAt != nil && !pb.ExpiresAt.After(now)
+}
+
 func (pb *ProductOrderBlock) SetQuantity(quantity int32) error {
 	if quantity < 1 {
 		return ErrProductOrderBlockQuantityLess1
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/m11ano/e"
+)
+
+var ErrProductOrderConsumptionQuantityLess1 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid quantity")
+
+// Товары, окончательно списанные со склада по выполненному заказу
+type ProductOrderConsumption struct {
+	ProductID int64
+	// 0 - товар без вариантов
+	VariantID int64
+	OrderID   int64
+	// Склад, с которого товар отгружен
+	WarehouseID int64
+	Quantity    int32
+
+	CreatedAt time.Time
+}
+
+func NewProductOrderConsumption(productID int64, variantID int64, orderID int64, warehouseID int64, quantity int32) (*ProductOrderConsumption, error) {
+	item := &ProductOrderConsumption{
+		ProductID:   productID,
+		VariantID:   variantID,
+		OrderID:     orderID,
+		WarehouseID: warehouseID,
+		CreatedAt:   time.Now(),
+	}
+
+	err := item.SetQuantity(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Списание по брони заказа, количество берется из брони
+func NewProductOrderConsumptionFromBlock(block *ProductOrderBlock) (*ProductOrderConsumption, error) {
+	return NewProductOrderConsumption(block.ProductID, block.VariantID, block.OrderID, block.WarehouseID, block.Quantity)
+}
+
+func (pc *ProductOrderConsumption) SetQuantity(quantity int32) error {
+	if quantity < 1 {
+		return ErrProductOrderConsumptionQuantityLess1
+	}
+	pc.Quantity = quantity
+
+	return nil
+}
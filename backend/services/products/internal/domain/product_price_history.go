@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ProductPriceChangeReason string
+
+const (
+	// Цена при создании товара
+	ProductPriceChangeReasonInitial ProductPriceChangeReason = "initial"
+	// Изменение из админки или импорта
+	ProductPriceChangeReasonManual        ProductPriceChangeReason = "manual"
+	ProductPriceChangeReasonScheduleStart ProductPriceChangeReason = "schedule_start"
+	ProductPriceChangeReasonScheduleEnd   ProductPriceChangeReason = "schedule_end"
+)
+
+func (r ProductPriceChangeReason) String() string {
+	return string(r)
+}
+
+// Запись истории изменения цены товара
+type ProductPriceHistory struct {
+	ID        int64
+	ProductID int64
+	// nil для цены при создании товара
+	OldPrice       *decimal.Decimal
+	NewPrice       decimal.Decimal
+	CompareAtPrice *decimal.Decimal
+	Reason         ProductPriceChangeReason
+	ScheduleID     *int64
+	ActorID        *uuid.UUID
+
+	CreatedAt time.Time
+}
+
+func NewProductPriceHistory(productID int64, oldPrice *decimal.Decimal, newPrice decimal.Decimal, compareAtPrice *decimal.Decimal, reason ProductPriceChangeReason) *ProductPriceHistory {
+	return &ProductPriceHistory{
+		ProductID:      productID,
+		OldPrice:       oldPrice,
+		NewPrice:       newPrice,
+		CompareAtPrice: compareAtPrice,
+		Reason:         reason,
+		CreatedAt:      time.Now(),
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/shopspring/decimal"
+)
+
+var ErrProductPriceScheduleInvalidPeriod = e.NewErrorFrom(e.ErrBadRequest).SetMessage("ends_at must be later than starts_at and now")
+var ErrProductPriceScheduleNotCancelable = e.NewErrorFrom(e.ErrBadRequest).SetMessage("price schedule is already finished")
+
+type ProductPriceScheduleStatus string
+
+const (
+	// Ожидает начала действия
+	ProductPriceScheduleStatusScheduled ProductPriceScheduleStatus = "scheduled"
+	// Цена применена к товару
+	ProductPriceScheduleStatusActive   ProductPriceScheduleStatus = "active"
+	ProductPriceScheduleStatusFinished ProductPriceScheduleStatus = "finished"
+	ProductPriceScheduleStatusCanceled ProductPriceScheduleStatus = "canceled"
+)
+
+func (s ProductPriceScheduleStatus) String() string {
+	return string(s)
+}
+
+// Запланированная цена товара на период, например распродажа с полуночи
+type ProductPriceSchedule struct {
+	ID        int64
+	ProductID int64
+	Price     decimal.Decimal
+	StartsAt  time.Time
+	// nil - цена действует бессрочно и не возвращается
+	EndsAt *time.Time
+	Status ProductPriceScheduleStatus
+	// Цена и старая цена товара до применения, возвращаются по окончании
+	PrevPrice          *decimal.Decimal
+	PrevCompareAtPrice *decimal.Decimal
+	// Аккаунт, запланировавший цену
+	ActorID    *uuid.UUID
+	AppliedAt  *time.Time
+	FinishedAt *time.Time
+
+	CreatedAt time.Time
+}
+
+func NewProductPriceSchedule(productID int64, startsAt time.Time, endsAt *time.Time) (*ProductPriceSchedule, error) {
+	if endsAt != nil && (!endsAt.After(startsAt) || !endsAt.After(time.Now())) {
+		return nil, ErrProductPriceScheduleInvalidPeriod
+	}
+
+	return &ProductPriceSchedule{
+		ProductID: productID,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		Status:    ProductPriceScheduleStatusScheduled,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (s *ProductPriceSchedule) SetPrice(price decimal.Decimal) error {
+	if price.LessThan(decimal.Zero) {
+		return ErrProductInvalidPrice
+	}
+
+	s.Price = price
+
+	return nil
+}
+
+// Пересекается ли период с периодом другой цены
+func (s *ProductPriceSchedule) Overlaps(other *ProductPriceSchedule) bool {
+	if s.EndsAt != nil && !s.EndsAt.After(other.StartsAt) {
+		return false
+	}
+
+	if other.EndsAt != nil && !other.EndsAt.After(s.StartsAt) {
+		return false
+	}
+
+	return true
+}
+
+func (s *ProductPriceSchedule) IsExpired(now time.Time) bool {
+	return s.EndsAt != nil && !s.EndsAt.After(now)
+}
+
+func (s *ProductPriceSchedule) Activate(prevPrice decimal.Decimal, prevCompareAtPrice *decimal.Decimal) {
+	now := time.Now()
+	s.Status = ProductPriceScheduleStatusActive
+	s.PrevPrice = &prevPrice
+	s.PrevCompareAtPrice = prevCompareAtPrice
+	s.AppliedAt = &now
+}
+
+func (s *ProductPriceSchedule) Finish() {
+	now := time.Now()
+	s.Status = ProductPriceScheduleStatusFinished
+	s.FinishedAt = &now
+}
+
+func (s *ProductPriceSchedule) Cancel() error {
+	if s.Status != ProductPriceScheduleStatusScheduled && s.Status != ProductPriceScheduleStatusActive {
+		return ErrProductPriceScheduleNotCancelable
+	}
+
+	now := time.Now()
+	s.Status = ProductPriceScheduleStatusCanceled
+	s.FinishedAt = &now
+
+	return nil
+}
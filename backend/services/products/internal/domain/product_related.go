@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// Сопутствующий товар, подобранный вручную
+type ProductRelated struct {
+	ProductID        int64
+	RelatedProductID int64
+	// Порядок вывода, по возрастанию
+	Sort int32
+
+	CreatedAt time.Time
+}
+
+func NewProductRelated(productID int64, relatedProductID int64, sort int32) *ProductRelated {
+	return &ProductRelated{
+		ProductID:        productID,
+		RelatedProductID: relatedProductID,
+		Sort:             sort,
+		CreatedAt:        time.Now(),
+	}
+}
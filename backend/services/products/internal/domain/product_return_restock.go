@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/m11ano/e"
+)
+
+var ErrProductReturnRestockQuantityLess1 = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid quantity")
+
+type ProductReturnRestock struct {
+	ProductID int64
+	// 0 - товар без вариантов
+	VariantID int64
+	ReturnID  int64
+	// Склад, на который товар возвращен
+	WarehouseID int64
+	Quantity    int32
+
+	CreatedAt time.Time
+}
+
+func NewProductReturnRestock(productID int64, variantID int64, returnID int64, warehouseID int64, quantity int32) (*ProductReturnRestock, error) {
+	item := &ProductReturnRestock{
+		ProductID:   productID,
+		VariantID:   variantID,
+		ReturnID:    returnID,
+		WarehouseID: warehouseID,
+		CreatedAt:   time.Now(),
+	}
+
+	err := item.SetQuantity(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (pr *ProductReturnRestock) SetQuantity(quantity int32) error {
+	if quantity < 1 {
+		return ErrProductReturnRestockQuantityLess1
+	}
+	pr.Quantity = quantity
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ProductReviewRatingMin     = 1
+	ProductReviewRatingMax     = 5
+	ProductReviewAuthorNameMax = 150
+	ProductReviewTextMax       = 5000
+)
+
+var ErrProductReviewInvalidRating = e.NewErrorFrom(e.ErrBadRequest).SetMessage("rating must be between 1 and 5")
+var ErrProductReviewInvalidAuthorName = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid author name")
+var ErrProductReviewInvalidText = e.NewErrorFrom(e.ErrBadRequest).SetMessage("review text is too long")
+var ErrProductReviewInvalidStatus = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid review status")
+
+type ProductReviewStatus string
+
+const (
+	// Новый отзыв, ожидает проверки администратором
+	ProductReviewStatusPending  ProductReviewStatus = "pending"
+	ProductReviewStatusApproved ProductReviewStatus = "approved"
+	ProductReviewStatusRejected ProductReviewStatus = "rejected"
+)
+
+func (s ProductReviewStatus) String() string {
+	return string(s)
+}
+
+func (s ProductReviewStatus) IsValid() bool {
+	switch s {
+	case ProductReviewStatusPending, ProductReviewStatusApproved, ProductReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
+// Отзыв покупателя о товаре из выполненного заказа
+type ProductReview struct {
+	ID         int64
+	ProductID  int64
+	OrderID    int64
+	AuthorName string
+	Rating     int16
+	Text       string
+	Status     ProductReviewStatus
+	// Администратор, последним изменивший статус
+	ModeratedBy *uuid.UUID
+	ModeratedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewProductReview(productID int64, orderID int64) *ProductReview {
+	return &ProductReview{
+		ProductID: productID,
+		OrderID:   orderID,
+		Status:    ProductReviewStatusPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (r *ProductReview) SetAuthorName(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" || utf8.RuneCountInString(value) > ProductReviewAuthorNameMax {
+		return ErrProductReviewInvalidAuthorName
+	}
+	r.AuthorName = value
+
+	return nil
+}
+
+func (r *ProductReview) SetRating(value int16) error {
+	if value < ProductReviewRatingMin || value > ProductReviewRatingMax {
+		return ErrProductReviewInvalidRating
+	}
+	r.Rating = value
+
+	return nil
+}
+
+func (r *ProductReview) SetText(value string) error {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) > ProductReviewTextMax {
+		return ErrProductReviewInvalidText
+	}
+	r.Text = value
+
+	return nil
+}
+
+func (r *ProductReview) SetStatus(status ProductReviewStatus, moderatorID uuid.UUID) error {
+	if !status.IsValid() {
+		return ErrProductReviewInvalidStatus
+	}
+
+	now := time.Now()
+	r.Status = status
+	r.ModeratedBy = &moderatorID
+	r.ModeratedAt = &now
+	r.UpdatedAt = &now
+
+	return nil
+}
+
+// Агрегированный рейтинг товара по одобренным отзывам
+type ProductRating struct {
+	ProductID int64
+	Avg       decimal.Decimal
+	Count     int64
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Прежний slug товара, по нему старые ссылки перенаправляются на актуальный
+type ProductSlugHistory struct {
+	Slug      string
+	ProductID int64
+
+	CreatedAt time.Time
+}
+
+func NewProductSlugHistory(slug string, productID int64) *ProductSlugHistory {
+	return &ProductSlugHistory{
+		Slug:      slug,
+		ProductID: productID,
+		CreatedAt: time.Now(),
+	}
+}
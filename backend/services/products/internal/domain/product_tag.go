@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/m11ano/e"
+)
+
+const ProductTagMaxLength = 100
+
+var ErrProductTagInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid tag")
+
+// Произвольная метка товара
+type ProductTag struct {
+	ProductID int64
+	Tag       string
+
+	CreatedAt time.Time
+}
+
+func NewProductTag(productID int64, tag string) *ProductTag {
+	return &ProductTag{
+		ProductID: productID,
+		Tag:       tag,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Метки хранятся в нижнем регистре с одиночными пробелами, чтобы "Подарки" и " подарки" были одной меткой
+func NormalizeProductTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+
+	if tag == "" || utf8.RuneCountInString(tag) > ProductTagMaxLength {
+		return "", ErrProductTagInvalid
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/m11ano/e"
+	"github.com/shopspring/decimal"
+)
+
+var ErrProductVariantInvalidOptions = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid variant options")
+
+// Вариант товара, например размер или цвет, со своим артикулом, ценой и остатком
+type ProductVariant struct {
+	ID             int64
+	ProductID      int64
+	SKU            string
+	Options        map[string]string
+	Price          decimal.Decimal
+	StockAvailable int32
+	Sort           int32
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+func NewProductVariant(id int64, productID int64) *ProductVariant {
+	return &ProductVariant{
+		ID:        id,
+		ProductID: productID,
+		Options:   map[string]string{},
+		CreatedAt: time.Now(),
+	}
+}
+
+// Значения опций (например, "Размер": "M"), пустые названия и значения не допускаются
+func (v *ProductVariant) SetOptions(options map[string]string) error {
+	if len(options) == 0 {
+		return ErrProductVariantInvalidOptions
+	}
+
+	for name, value := range options {
+		if name == "" || value == "" {
+			return ErrProductVariantInvalidOptions
+		}
+	}
+
+	v.Options = options
+
+	return nil
+}
+
+func (v *ProductVariant) SetPrice(price decimal.Decimal) error {
+	if price.LessThan(decimal.Zero) {
+		return ErrProductInvalidPrice
+	}
+
+	v.Price = price
+
+	return nil
+}
+
+func (v *ProductVariant) SetStockAvailable(value int32) error {
+	if value < 0 {
+		return ErrProductStockLowerZero
+	}
+	v.StockAvailable = value
+
+	return nil
+}
+
+func (v *ProductVariant) IncreaseStock(value int64) error {
+	newValue := int64(v.StockAvailable) + value
+	if newValue > math.MaxInt32 {
+		return ErrProductStockMoreMax
+	}
+
+	return v.SetStockAvailable(int32(newValue))
+}
+
+func (v *ProductVariant) DecreaseStock(value int64) error {
+	newValue := int64(v.StockAvailable) - value
+	if newValue < 0 {
+		return ErrProductStockLowerZero
+	}
+
+	return v.SetStockAvailable(int32(newValue))
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+const SlugMaxLength = 150
+
+// Slug - латиница в нижнем регистре, цифры и дефисы между ними
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+var slugTranslit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+func IsValidSlug(slug string) bool {
+	return len(slug) <= SlugMaxLength && slugRegexp.MatchString(slug)
+}
+
+// Slug из произвольной строки: кириллица транслитерируется, остальные символы заменяются дефисами
+func GenerateSlug(value string) string {
+	var b strings.Builder
+
+	isHyphen := true
+	for _, r := range strings.ToLower(value) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			isHyphen = false
+		case slugTranslit[r] != "":
+			b.WriteString(slugTranslit[r])
+			isHyphen = false
+		case r == 'ъ' || r == 'ь':
+		default:
+			if !isHyphen {
+				b.WriteRune('-')
+				isHyphen = true
+			}
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > SlugMaxLength {
+		slug = slug[:SlugMaxLength]
+	}
+
+	return strings.Trim(slug, "-")
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockMovementReason string
+
+const (
+	// Остаток при создании товара или варианта
+	StockMovementReasonInitial StockMovementReason = "initial"
+	// Ручная корректировка из админки или импорта
+	StockMovementReasonManualAdjust StockMovementReason = "manual_adjust"
+	StockMovementReasonOrderReserve StockMovementReason = "order_reserve"
+	StockMovementReasonOrderRelease StockMovementReason = "order_release"
+	// Бронь заказа снята по истечении срока
+	StockMovementReasonOrderExpire StockMovementReason = "order_expire"
+	StockMovementReasonReturn      StockMovementReason = "return"
+	// Отмена возврата на склад при изменении состава возврата
+	StockMovementReasonReturnCancel StockMovementReason = "return_cancel"
+	// Перемещение между складами, остаток товара в каталоге не меняется
+	StockMovementReasonTransferOut StockMovementReason = "transfer_out"
+	StockMovementReasonTransferIn  StockMovementReason = "transfer_in"
+)
+
+func (r StockMovementReason) String() string {
+	return string(r)
+}
+
+// Запись журнала движения остатка товара или варианта
+type StockMovement struct {
+	ID        int64
+	ProductID int64
+	// 0 - товар без вариантов
+	VariantID int64
+	// nil - запись сделана до появления складов
+	WarehouseID *int64
+	Delta       int32
+	// Остаток после изменения, суммарный по всем складам
+	Quantity int32
+	Reason   StockMovementReason
+	OrderID  *int64
+	ReturnID *int64
+	// Аккаунт, изменивший остаток вручную
+	ActorID *uuid.UUID
+
+	CreatedAt time.Time
+}
+
+func NewStockMovement(productID int64, variantID int64, delta int32, quantity int32, reason StockMovementReason) *StockMovement {
+	return &StockMovement{
+		ProductID: productID,
+		VariantID: variantID,
+		Delta:     delta,
+		Quantity:  quantity,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+}
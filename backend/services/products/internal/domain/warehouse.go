@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/m11ano/e"
+)
+
+var ErrWarehouseInvalidCode = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid warehouse code")
+var ErrWarehouseInvalidDeliveryZones = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid warehouse delivery zones")
+
+// Склад, с которого отгружаются товары
+type Warehouse struct {
+	ID   int64
+	Name string
+	Code string
+	// Чем меньше значение, тем раньше склад используется при бронировании
+	Priority int32
+	// Зоны доставки, которые обслуживает склад, и расстояние до них в километрах
+	DeliveryZones map[string]int32
+	// Неактивный склад не участвует в бронировании, но его остатки сохраняются
+	IsActive bool
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewWarehouse(id int64) *Warehouse {
+	return &Warehouse{
+		ID:            id,
+		DeliveryZones: map[string]int32{},
+		IsActive:      true,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func (w *Warehouse) SetCode(code string) error {
+	if !IsValidSlug(code) {
+		return ErrWarehouseInvalidCode
+	}
+
+	w.Code = code
+
+	return nil
+}
+
+func (w *Warehouse) SetDeliveryZones(zones map[string]int32) error {
+	for zone, distance := range zones {
+		if zone == "" || distance < 0 {
+			return ErrWarehouseInvalidDeliveryZones
+		}
+	}
+
+	if zones == nil {
+		zones = map[string]int32{}
+	}
+
+	w.DeliveryZones = zones
+
+	return nil
+}
+
+// Расстояние до зоны доставки, false - склад зону не обслуживает
+func (w *Warehouse) DistanceTo(zone string) (int32, bool) {
+	if zone == "" {
+		return 0, false
+	}
+
+	distance, ok := w.DeliveryZones[zone]
+
+	return distance, ok
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Остаток товара или варианта на складе. Остаток товара в каталоге - сумма остатков по всем складам
+type WarehouseStock struct {
+	WarehouseID int64
+	ProductID   int64
+	// 0 - товар без вариантов
+	VariantID int64
+	Quantity  int32
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewWarehouseStock(warehouseID int64, productID int64, variantID int64) *WarehouseStock {
+	return &WarehouseStock{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		VariantID:   variantID,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (ws *WarehouseStock) SetQuantity(value int32) error {
+	if value < 0 {
+		return ErrProductStockLowerZero
+	}
+
+	ws.Quantity = value
+
+	return nil
+}
+
+func (ws *WarehouseStock) IncreaseStock(value int64) error {
+	newValue := int64(ws.Quantity) + value
+	if newValue > math.MaxInt32 {
+		return ErrProductStockMoreMax
+	}
+
+	return ws.SetQuantity(int32(newValue))
+}
+
+func (ws *WarehouseStock) DecreaseStock(value int64) error {
+	newValue := int64(ws.Quantity) - value
+	if newValue < 0 {
+		return ErrProductStockLowerZero
+	}
+
+	return ws.SetQuantity(int32(newValue))
+}
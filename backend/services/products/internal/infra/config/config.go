@@ -25,6 +25,33 @@ type Config struct {
 				Schedule         string `yaml:"schedule" env:"CRON_TASK_DELETE_NOT_ASSIGNED_FILES_SCHEDULE" env-default:""`
 				AfterCreateHours int    `yaml:"after_create_hours" env:"CRON_TASK_DELETE_NOT_ASSIGNED_FILES_AFTER_CREATE_HOURS" env-default:"24"`
 			} `yaml:"delete_not_assigned_files"`
+
+			SendLowStockAlerts struct {
+				Schedule string `yaml:"schedule" env:"CRON_TASK_SEND_LOW_STOCK_ALERTS_SCHEDULE" env-default:""`
+			} `yaml:"send_low_stock_alerts"`
+
+			ApplyPriceSchedules struct {
+				Schedule string `yaml:"schedule" env:"CRON_TASK_APPLY_PRICE_SCHEDULES_SCHEDULE" env-default:""`
+			} `yaml:"apply_price_schedules"`
+
+			PurgeDeletedProducts struct {
+				Schedule        string `yaml:"schedule" env:"CRON_TASK_PURGE_DELETED_PRODUCTS_SCHEDULE" env-default:""`
+				AfterDeleteDays int    `yaml:"after_delete_days" env:"CRON_TASK_PURGE_DELETED_PRODUCTS_AFTER_DELETE_DAYS" env-default:"30"`
+			} `yaml:"purge_deleted_products"`
+
+			ReleaseExpiredOrderBlocks struct {
+				Schedule string `yaml:"schedule" env:"CRON_TASK_RELEASE_EXPIRED_ORDER_BLOCKS_SCHEDULE" env-default:""`
+			} `yaml:"release_expired_order_blocks"`
+
+			RefreshBoughtTogether struct {
+				Schedule string `yaml:"schedule" env:"CRON_TASK_REFRESH_BOUGHT_TOGETHER_SCHEDULE" env-default:""`
+				// Учитываются заказы за последние дни
+				PeriodDays int `yaml:"period_days" env:"CRON_TASK_REFRESH_BOUGHT_TOGETHER_PERIOD_DAYS" env-default:"180"`
+				// Минимальное количество заказов, в которых товары купили вместе
+				MinOrders int `yaml:"min_orders" env:"CRON_TASK_REFRESH_BOUGHT_TOGETHER_MIN_ORDERS" env-default:"2"`
+				// Максимальное количество товаров для одного товара
+				LimitPerProduct int `yaml:"limit_per_product" env:"CRON_TASK_REFRESH_BOUGHT_TOGETHER_LIMIT_PER_PRODUCT" env-default:"10"`
+			} `yaml:"refresh_bought_together"`
 		} `yaml:"tasks"`
 	} `yaml:"cron"`
 	DB struct {
@@ -41,6 +68,8 @@ type Config struct {
 		UnderProxy   bool     `yaml:"under_proxy" env:"HTTP_UNDER_PROXY" env-default:"false"`
 		StartSwagger bool     `yaml:"start_swagger" env:"HTTP_START_SWAGGER" env-default:"false"`
 		Cors         []string `yaml:"cors" env:"HTTP_CORS"`
+		// Максимальный размер тела запроса, 0 - значение по умолчанию
+		BodyLimitMB int `yaml:"body_limit_mb" env:"HTTP_BODY_LIMIT_MB" env-default:"0"`
 	} `yaml:"http"`
 	GRPC struct {
 		Port    int `yaml:"port" env:"GRPC_PORT" env-default:"50051"`
@@ -52,6 +81,39 @@ type Config struct {
 			} `yaml:"orders"`
 		} `yaml:"clients"`
 	} `yaml:"grpc"`
+	Import struct {
+		MaxRows                 int `yaml:"max_rows" env:"IMPORT_MAX_ROWS" env-default:"5000"`
+		ImageDownloadTimeoutSec int `yaml:"image_download_timeout_sec" env:"IMPORT_IMAGE_DOWNLOAD_TIMEOUT_SEC" env-default:"15"`
+		MaxImageSizeMB          int `yaml:"max_image_size_mb" env:"IMPORT_MAX_IMAGE_SIZE_MB" env-default:"10"`
+	} `yaml:"import"`
+	LowStock struct {
+		// Порог для товаров без собственного порога
+		DefaultThreshold int32 `yaml:"default_threshold" env:"LOW_STOCK_DEFAULT_THRESHOLD" env-default:"5"`
+	} `yaml:"low_stock"`
+	OrderBlock struct {
+		// Срок брони в минутах для статуса заказа, 0 - бронь бессрочная
+		TTLMinutesByStatus map[string]int `yaml:"ttl_minutes_by_status" env:"ORDER_BLOCK_TTL_MINUTES_BY_STATUS" env-default:"new:30,created:1440,paid:10080,in_work:0,shipped:0,delivered:0,finished:0"`
+		// Срок брони для статусов, которых нет в списке
+		DefaultTTLMinutes int `yaml:"default_ttl_minutes" env:"ORDER_BLOCK_DEFAULT_TTL_MINUTES" env-default:"1440"`
+	} `yaml:"order_block"`
+	Warehouse struct {
+		// Выбор склада при бронировании: priority - по приоритету склада, closest - ближайший к зоне доставки заказа
+		Strategy string `yaml:"strategy" env:"WAREHOUSE_STRATEGY" env-default:"priority"`
+	} `yaml:"warehouse"`
+	Notifications struct {
+		// Каналы уведомлений администраторов: log, webhook, email
+		Channels   []string `yaml:"channels" env:"NOTIFICATIONS_CHANNELS" env-default:"log"`
+		WebhookURL string   `yaml:"webhook_url" env:"NOTIFICATIONS_WEBHOOK_URL" env-default:""`
+		TimeoutSec int      `yaml:"timeout_sec" env:"NOTIFICATIONS_TIMEOUT_SEC" env-default:"10"`
+		SMTP       struct {
+			Host     string   `yaml:"host" env:"NOTIFICATIONS_SMTP_HOST" env-default:""`
+			Port     int      `yaml:"port" env:"NOTIFICATIONS_SMTP_PORT" env-default:"587"`
+			Username string   `yaml:"username" env:"NOTIFICATIONS_SMTP_USERNAME" env-default:""`
+			Password string   `yaml:"password" env:"NOTIFICATIONS_SMTP_PASSWORD" env-default:""`
+			From     string   `yaml:"from" env:"NOTIFICATIONS_SMTP_FROM" env-default:""`
+			To       []string `yaml:"to" env:"NOTIFICATIONS_SMTP_TO"`
+		} `yaml:"smtp"`
+	} `yaml:"notifications"`
 	Storage struct {
 		S3Endpoint  string `yaml:"s3_endpoint" env:"STORAGE_S3_ENDPOINT" env-default:""`
 		S3AccessKey string `yaml:"s3_access_key" env:"STORAGE_S3_ACCESS_KEY" env-default:""`
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+type Email struct {
+	cfg EmailConfig
+}
+
+func NewEmail(cfg EmailConfig) *Email {
+	return &Email{
+		cfg: cfg,
+	}
+}
+
+func (n *Email) Notify(_ context.Context, msg Message) error {
+	if len(n.cfg.To) == 0 {
+		return nil
+	}
+
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	body.WriteString("\r\n")
+	body.WriteString(msg.Text)
+
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+
+	return smtp.SendMail(addr, auth, n.cfg.From, n.cfg.To, []byte(body.String()))
+}
@@ -0,0 +1,22 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+)
+
+type Log struct {
+	logger *slog.Logger
+}
+
+func NewLog(logger *slog.Logger) *Log {
+	return &Log{
+		logger: logger,
+	}
+}
+
+func (n *Log) Notify(ctx context.Context, msg Message) error {
+	n.logger.WarnContext(ctx, "notification", slog.String("subject", msg.Subject), slog.String("text", msg.Text))
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+)
+
+// Уведомление администраторам магазина
+type Message struct {
+	Subject string
+	Text    string
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+const (
+	ChannelLog     = "log"
+	ChannelWebhook = "webhook"
+	ChannelEmail   = "email"
+)
+
+// Уведомление доставлено не во все каналы. Повторять отправку не нужно, ошибки каналов только логируются
+type PartialError struct {
+	Err error
+}
+
+func (e *PartialError) Error() string {
+	return "notification was delivered partially: " + e.Err.Error()
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// Рассылает уведомление во все каналы, ошибка одного канала не мешает отправке в остальные.
+// Если хотя бы один канал принял уведомление, ошибки остальных возвращаются как PartialError
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	errs := make([]error, 0)
+
+	for _, n := range m {
+		err := n.Notify(ctx, msg)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if len(errs) < len(m) {
+		return &PartialError{Err: errors.Join(errs...)}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	categoryTable = "category"
+)
+
+type DBCategory struct {
+	ID          int64  `db:"id"`
+	ParentID    *int64 `db:"parent_id"`
+	Name        string `db:"name"`
+	Slug        string `db:"slug"`
+	Sort        int32  `db:"sort"`
+	IsPublished bool   `db:"is_published"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+var (
+	categoryTableFields = []string{}
+	categoryDBSchema    = &DBCategory{}
+)
+
+func init() {
+	categoryTableFields = dbhelper.ExtractDBFields(categoryDBSchema)
+}
+
+type Category struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewCategory(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *Category {
+	return &Category{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Category) dbToDomain(db *DBCategory) *domain.Category {
+	return &domain.Category{
+		ID:          db.ID,
+		ParentID:    db.ParentID,
+		Name:        db.Name,
+		Slug:        db.Slug,
+		Sort:        db.Sort,
+		IsPublished: db.IsPublished,
+
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
+	}
+}
+
+func (r *Category) buildWhereForList(listOptions usecase.CategoryListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.IDs != nil {
+		where = append(where, squirrel.Eq{"id": *listOptions.IDs})
+	}
+
+	if listOptions.Slug != nil {
+		where = append(where, squirrel.Eq{"slug": *listOptions.Slug})
+	}
+
+	if listOptions.IsPublished != nil {
+		where = append(where, squirrel.Eq{"is_published": *listOptions.IsPublished})
+	}
+
+	return where
+}
+
+func (r *Category) FindList(ctx context.Context, listOptions usecase.CategoryListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Category, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(categoryTableFields...).From(categoryTable).Where(where).OrderBy("sort ASC", "id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBCategory{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.Category, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *Category) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.Category, error) {
+	q := r.qb.Select(categoryTableFields...).From(categoryTable).Where(squirrel.Eq{"id": id})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBCategory{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+func (r *Category) Create(ctx context.Context, item *domain.Category) error {
+	dataMap, err := dbhelper.StructToDBMap(item, categoryDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(categoryTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *Category) Update(ctx context.Context, item *domain.Category) error {
+	dataMap, err := dbhelper.StructToDBMap(item, categoryDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "created_at")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Update(categoryTable).Where(squirrel.Eq{"id": item.ID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *Category) DeleteByList(ctx context.Context, listOptions usecase.CategoryListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(categoryTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	collectionTable = "collection"
+)
+
+type DBCollection struct {
+	ID               int64            `db:"id"`
+	Name             string           `db:"name"`
+	Slug             string           `db:"slug"`
+	Description      string           `db:"description"`
+	Kind             string           `db:"kind"`
+	RuleTag          *string          `db:"rule_tag"`
+	RulePriceFrom    *decimal.Decimal `db:"rule_price_from"`
+	RulePriceTo      *decimal.Decimal `db:"rule_price_to"`
+	RuleCreatedAfter *time.Time       `db:"rule_created_after"`
+	Sort             int32            `db:"sort"`
+	IsPublished      bool             `db:"is_published"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+var (
+	collectionTableFields = []string{}
+	collectionDBSchema    = &DBCollection{}
+)
+
+func init() {
+	collectionTableFields = dbhelper.ExtractDBFields(collectionDBSchema)
+}
+
+type Collection struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewCollection(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *Collection {
+	return &Collection{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Collection) dbToDomain(db *DBCollection) *domain.Collection {
+	return &domain.Collection{
+		ID:               db.ID,
+		Name:             db.Name,
+		Slug:             db.Slug,
+		Description:      db.Description,
+		Kind:             domain.CollectionKind(db.Kind),
+		RuleTag:          db.RuleTag,
+		RulePriceFrom:    db.RulePriceFrom,
+		RulePriceTo:      db.RulePriceTo,
+		RuleCreatedAfter: db.RuleCreatedAfter,
+		Sort:             db.Sort,
+		IsPublished:      db.IsPublished,
+
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
+	}
+}
+
+func (r *Collection) buildWhereForList(listOptions usecase.CollectionListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.IDs != nil {
+		where = append(where, squirrel.Eq{"id": *listOptions.IDs})
+	}
+
+	if listOptions.Slug != nil {
+		where = append(where, squirrel.Eq{"slug": *listOptions.Slug})
+	}
+
+	if listOptions.IsPublished != nil {
+		where = append(where, squirrel.Eq{"is_published": *listOptions.IsPublished})
+	}
+
+	return where
+}
+
+func (r *Collection) FindList(ctx context.Context, listOptions usecase.CollectionListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Collection, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(collectionTableFields...).From(collectionTable).Where(where).OrderBy("sort ASC", "id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBCollection{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.Collection, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *Collection) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.Collection, error) {
+	q := r.qb.Select(collectionTableFields...).From(collectionTable).Where(squirrel.Eq{"id": id})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBCollection{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+func (r *Collection) Create(ctx context.Context, item *domain.Collection) error {
+	dataMap, err := dbhelper.StructToDBMap(item, collectionDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(collectionTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *Collection) Update(ctx context.Context, item *domain.Collection) error {
+	dataMap, err := dbhelper.StructToDBMap(item, collectionDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "created_at")
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Update(collectionTable).Where(squirrel.Eq{"id": item.ID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *Collection) DeleteByList(ctx context.Context, listOptions usecase.CollectionListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(collectionTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	collectionProductTable = "collection_product"
+)
+
+type DBCollectionProduct struct {
+	CollectionID int64 `db:"collection_id"`
+	ProductID    int64 `db:"product_id"`
+	Sort         int32 `db:"sort"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	collectionProductTableFields = []string{}
+	collectionProductDBSchema    = &DBCollectionProduct{}
+)
+
+func init() {
+	collectionProductTableFields = dbhelper.ExtractDBFields(collectionProductDBSchema)
+}
+
+type CollectionProduct struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewCollectionProduct(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *CollectionProduct {
+	return &CollectionProduct{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *CollectionProduct) dbToDomain(db *DBCollectionProduct) *domain.CollectionProduct {
+	return &domain.CollectionProduct{
+		CollectionID: db.CollectionID,
+		ProductID:    db.ProductID,
+		Sort:         db.Sort,
+		CreatedAt:    db.CreatedAt,
+	}
+}
+
+func (r *CollectionProduct) buildWhereForList(listOptions usecase.CollectionProductListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.CollectionID != nil {
+		where = append(where, squirrel.Eq{"collection_id": *listOptions.CollectionID})
+	}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	return where
+}
+
+func (r *CollectionProduct) FindList(ctx context.Context, listOptions usecase.CollectionProductListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.CollectionProduct, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(collectionProductTableFields...).From(collectionProductTable).Where(where).OrderBy("collection_id ASC", "sort ASC", "product_id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBCollectionProduct{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.CollectionProduct, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *CollectionProduct) Create(ctx context.Context, item *domain.CollectionProduct) error {
+	dataMap, err := dbhelper.StructToDBMap(item, collectionProductDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(collectionProductTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *CollectionProduct) DeleteByList(ctx context.Context, listOptions usecase.CollectionProductListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(collectionProductTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
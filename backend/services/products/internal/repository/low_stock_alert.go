@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	lowStockAlertTable = "low_stock_alert"
+)
+
+type DBLowStockAlert struct {
+	ProductID  int64      `db:"product_id"`
+	VariantID  int64      `db:"variant_id"`
+	Name       string     `db:"name"`
+	SKU        string     `db:"sku"`
+	Quantity   int32      `db:"quantity"`
+	Threshold  int32      `db:"threshold"`
+	NotifiedAt *time.Time `db:"notified_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	lowStockAlertTableFields = []string{}
+	lowStockAlertDBSchema    = &DBLowStockAlert{}
+)
+
+func init() {
+	lowStockAlertTableFields = dbhelper.ExtractDBFields(lowStockAlertDBSchema)
+}
+
+type LowStockAlert struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewLowStockAlert(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *LowStockAlert {
+	return &LowStockAlert{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *LowStockAlert) dbToDomain(db *DBLowStockAlert) *domain.LowStockAlert {
+	return &domain.LowStockAlert{
+		ProductID:  db.ProductID,
+		VariantID:  db.VariantID,
+		Name:       db.Name,
+		SKU:        db.SKU,
+		Quantity:   db.Quantity,
+		Threshold:  db.Threshold,
+		NotifiedAt: db.NotifiedAt,
+		CreatedAt:  db.CreatedAt,
+	}
+}
+
+func (r *LowStockAlert) buildWhereForList(listOptions usecase.LowStockAlertListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.VariantID != nil {
+		where = append(where, squirrel.Eq{"variant_id": *listOptions.VariantID})
+	}
+
+	if listOptions.IsNotified != nil {
+		if *listOptions.IsNotified {
+			where = append(where, squirrel.Expr("notified_at IS NOT NULL"))
+		} else {
+			where = append(where, squirrel.Expr("notified_at IS NULL"))
+		}
+	}
+
+	return where
+}
+
+func (r *LowStockAlert) FindList(ctx context.Context, listOptions usecase.LowStockAlertListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.LowStockAlert, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(lowStockAlertTableFields...).From(lowStockAlertTable).Where(where).OrderBy("created_at ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBLowStockAlert{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.LowStockAlert, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+// Уже существующее уведомление по позиции не перезаписывается
+func (r *LowStockAlert) CreateIfNotExists(ctx context.Context, item *domain.LowStockAlert) error {
+	dataMap, err := dbhelper.StructToDBMap(item, lowStockAlertDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(lowStockAlertTable).SetMap(dataMap).Suffix("ON CONFLICT (product_id, variant_id) DO NOTHING").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *LowStockAlert) Update(ctx context.Context, item *domain.LowStockAlert) error {
+	dataMap, err := dbhelper.StructToDBMap(item, lowStockAlertDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "product_id")
+	delete(dataMap, "variant_id")
+	delete(dataMap, "created_at")
+
+	query, args, err := r.qb.Update(lowStockAlertTable).SetMap(dataMap).Where(squirrel.Eq{
+		"product_id": item.ProductID,
+		"variant_id": item.VariantID,
+	}).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *LowStockAlert) DeleteByList(ctx context.Context, listOptions usecase.LowStockAlertListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(lowStockAlertTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
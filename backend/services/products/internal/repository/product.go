@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -25,13 +26,21 @@ const (
 )
 
 type DBProduct struct {
-	ID                 int64           `db:"id"`
-	IsPublished        bool            `db:"is_published"`
-	Name               string          `db:"name"`
-	FullDescription    string          `db:"full_description"`
-	Price              decimal.Decimal `db:"price"`
-	StockAvailable     int32           `db:"stock_available"`
-	ImagePreviewFileID *uuid.UUID      `db:"image_preview_file_id"`
+	ID                 int64            `db:"id"`
+	IsPublished        bool             `db:"is_published"`
+	PublishAt          *time.Time       `db:"publish_at"`
+	UnpublishAt        *time.Time       `db:"unpublish_at"`
+	Name               string           `db:"name"`
+	Slug               string           `db:"slug"`
+	SKU                string           `db:"sku"`
+	FullDescription    string           `db:"full_description"`
+	Price              decimal.Decimal  `db:"price"`
+	CompareAtPrice     *decimal.Decimal `db:"compare_at_price"`
+	TaxRate            *decimal.Decimal `db:"tax_rate"`
+	StockAvailable     int32            `db:"stock_available"`
+	LowStockThreshold  *int32           `db:"low_stock_threshold"`
+	ImagePreviewFileID *uuid.UUID       `db:"image_preview_file_id"`
+	Version            int64            `db:"version"`
 
 	CreatedAt time.Time  `db:"created_at"`
 	UpdatedAt *time.Time `db:"updated_at"`
@@ -67,11 +76,19 @@ func (r *Product) dbToDomain(db *DBProduct) *domain.Product {
 	return &domain.Product{
 		ID:                 db.ID,
 		IsPublished:        db.IsPublished,
+		PublishAt:          db.PublishAt,
+		UnpublishAt:        db.UnpublishAt,
 		Name:               db.Name,
+		Slug:               db.Slug,
+		SKU:                db.SKU,
 		FullDescription:    db.FullDescription,
 		Price:              db.Price,
+		CompareAtPrice:     db.CompareAtPrice,
+		TaxRate:            db.TaxRate,
 		StockAvailable:     db.StockAvailable,
+		LowStockThreshold:  db.LowStockThreshold,
 		ImagePreviewFileID: db.ImagePreviewFileID,
+		Version:            db.Version,
 		CreatedAt:          db.CreatedAt,
 		UpdatedAt:          db.UpdatedAt,
 		DeletedAt:          db.DeletedAt,
@@ -89,7 +106,109 @@ func (r *Product) buildWhereForList(listOptions usecase.ProductListOptions, with
 		where = append(where, squirrel.Eq{"is_published": *listOptions.IsPublished})
 	}
 
-	if !withDeleted {
+	if listOptions.VisibleAt != nil {
+		where = append(where,
+			squirrel.Eq{"is_published": true},
+			squirrel.Or{squirrel.Eq{"publish_at": nil}, squirrel.LtOrEq{"publish_at": *listOptions.VisibleAt}},
+			squirrel.Or{squirrel.Eq{"unpublish_at": nil}, squirrel.Gt{"unpublish_at": *listOptions.VisibleAt}},
+		)
+	}
+
+	if listOptions.SKU != nil {
+		where = append(where, squirrel.Eq{"sku": *listOptions.SKU})
+	}
+
+	if listOptions.SKUs != nil {
+		where = append(where, squirrel.Eq{"sku": *listOptions.SKUs})
+	}
+
+	if listOptions.Slug != nil {
+		where = append(where, squirrel.Eq{"slug": *listOptions.Slug})
+	}
+
+	if listOptions.CategoryID != nil {
+		where = append(where, squirrel.Expr(`id IN (
+			SELECT pc.product_id FROM product_category pc WHERE pc.category_id IN (
+				WITH RECURSIVE tree AS (
+					SELECT c.id FROM category c WHERE c.id = ?
+					UNION ALL
+					SELECT c.id FROM category c JOIN tree t ON c.parent_id = t.id
+				)
+				SELECT tree.id FROM tree
+			)
+		)`, *listOptions.CategoryID))
+	}
+
+	if listOptions.Tag != nil {
+		where = append(where, squirrel.Expr("id IN (SELECT pt.product_id FROM product_tag pt WHERE pt.tag = ?)", *listOptions.Tag))
+	}
+
+	if listOptions.CreatedFrom != nil {
+		where = append(where, squirrel.GtOrEq{"created_at": *listOptions.CreatedFrom})
+	}
+
+	if listOptions.Query != nil {
+		where = append(where, squirrel.Expr("search_vector @@ websearch_to_tsquery('russian', ?)", *listOptions.Query))
+	}
+
+	if listOptions.PriceFrom != nil || listOptions.PriceTo != nil {
+		priceWhere := squirrel.And{}
+		variantPriceWhere := squirrel.And{}
+
+		if listOptions.PriceFrom != nil {
+			priceWhere = append(priceWhere, squirrel.GtOrEq{"price": *listOptions.PriceFrom})
+			variantPriceWhere = append(variantPriceWhere, squirrel.GtOrEq{"v.price": *listOptions.PriceFrom})
+		}
+
+		if listOptions.PriceTo != nil {
+			priceWhere = append(priceWhere, squirrel.LtOrEq{"price": *listOptions.PriceTo})
+			variantPriceWhere = append(variantPriceWhere, squirrel.LtOrEq{"v.price": *listOptions.PriceTo})
+		}
+
+		variantPriceSQL, variantPriceArgs, _ := variantPriceWhere.ToSql()
+
+		where = append(where, squirrel.Or{
+			priceWhere,
+			squirrel.Expr(`EXISTS (
+				SELECT 1 FROM product_variant v WHERE v.product_id = product.id AND v.deleted_at IS NULL AND `+variantPriceSQL+`
+			)`, variantPriceArgs...),
+		})
+	}
+
+	if listOptions.InStock != nil {
+		inStockSQL := `(stock_available > 0 OR EXISTS (
+			SELECT 1 FROM product_variant v WHERE v.product_id = product.id AND v.deleted_at IS NULL AND v.stock_available > 0
+		))`
+
+		if *listOptions.InStock {
+			where = append(where, squirrel.Expr(inStockSQL))
+		} else {
+			where = append(where, squirrel.Expr("NOT "+inStockSQL))
+		}
+	}
+
+	if listOptions.LowStock != nil {
+		where = append(where, squirrel.Expr(`(
+			(stock_available <= COALESCE(low_stock_threshold, ?) AND NOT EXISTS (
+				SELECT 1 FROM product_variant v WHERE v.product_id = product.id AND v.deleted_at IS NULL
+			))
+			OR EXISTS (
+				SELECT 1 FROM product_variant v WHERE v.product_id = product.id AND v.deleted_at IS NULL AND v.stock_available <= COALESCE(product.low_stock_threshold, ?)
+			)
+		)`, *listOptions.LowStock, *listOptions.LowStock))
+	}
+
+	if listOptions.DeletedAtTo != nil {
+		where = append(where, squirrel.LtOrEq{"deleted_at": *listOptions.DeletedAtTo})
+	}
+
+	if listOptions.IsDeleted != nil {
+		if *listOptions.IsDeleted {
+			where = append(where, squirrel.Expr("deleted_at IS NOT NULL"))
+		} else {
+			where = append(where, squirrel.Expr("deleted_at IS NULL"))
+		}
+	} else if !withDeleted {
 		where = append(where, squirrel.Expr("deleted_at IS NULL"))
 	}
 
@@ -98,29 +217,87 @@ func (r *Product) buildWhereForList(listOptions usecase.ProductListOptions, with
 
 var productSortFieldMap = map[usecase.ProductListSortField]string{
 	usecase.ProductListSortFieldCreatedAt: "created_at",
+	usecase.ProductListSortFieldID:        "id",
+	usecase.ProductListSortFieldPrice:     "price",
+	usecase.ProductListSortFieldName:      "name",
+	usecase.ProductListSortFieldDeletedAt: "deleted_at",
+	// Бронь снимается при отмене заказа и при списании выполненного заказа со склада, поэтому продажи - это
+	// списанное по выполненным заказам плюс бронь действующих заказов
+	usecase.ProductListSortFieldPopularity: "((SELECT COALESCE(SUM(c.quantity), 0) FROM product_order_consumption c WHERE c.product_id = product.id) + " +
+		"(SELECT COALESCE(SUM(b.quantity), 0) FROM product_order_block b WHERE b.product_id = product.id))",
 }
 
-func (r *Product) buildSortForList(listOptions usecase.ProductListOptions) []string {
+// Сортировка собирается в одно выражение, так как релевантность требует параметр поискового запроса
+func (r *Product) buildSortForList(listOptions usecase.ProductListOptions) (string, []any) {
 	if listOptions.Sort == nil {
-		return []string{}
+		return "", nil
 	}
 
 	sort := make([]string, 0, len(*listOptions.Sort))
+	args := []any{}
 
 	for _, sortItem := range *listOptions.Sort {
+		var dir string
+		if sortItem.IsDesc {
+			dir = "DESC"
+		} else {
+			dir = "ASC"
+		}
+
+		if sortItem.Field == usecase.ProductListSortFieldRelevance {
+			if listOptions.Query != nil {
+				sort = append(sort, fmt.Sprintf("ts_rank(search_vector, websearch_to_tsquery('russian', ?)) %s", dir))
+				args = append(args, *listOptions.Query)
+			}
+			continue
+		}
+
 		sortField, ok := productSortFieldMap[sortItem.Field]
 		if ok {
-			var dir string
-			if sortItem.IsDesc {
-				dir = "DESC"
-			} else {
-				dir = "ASC"
-			}
 			sort = append(sort, fmt.Sprintf("%s %s", sortField, dir))
 		}
 	}
 
-	return sort
+	return strings.Join(sort, ", "), args
+}
+
+// Условие выборки записей после курсора. Первое поле сортировки - ключ курсора, id - дополнительный ключ для равных значений
+func (r *Product) buildCursorWhere(listOptions usecase.ProductListOptions, cursor *uctypes.ListCursor) (squirrel.Sqlizer, error) {
+	if listOptions.Sort == nil || len(*listOptions.Sort) == 0 {
+		return nil, usecase.ErrProductListCursorNotSupported
+	}
+
+	keySort := (*listOptions.Sort)[0]
+
+	idIsDesc := keySort.IsDesc
+	for _, sortItem := range *listOptions.Sort {
+		if sortItem.Field == usecase.ProductListSortFieldID {
+			idIsDesc = sortItem.IsDesc
+		}
+	}
+
+	idOp := ">"
+	if idIsDesc {
+		idOp = "<"
+	}
+
+	if keySort.Field == usecase.ProductListSortFieldID {
+		return squirrel.Expr("id "+idOp+" ?", cursor.ID), nil
+	}
+
+	value, err := usecase.ParseProductListCursorValue(keySort.Field, cursor.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	keyOp := ">"
+	if keySort.IsDesc {
+		keyOp = "<"
+	}
+
+	column := productSortFieldMap[keySort.Field]
+
+	return squirrel.Expr(fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", column, keyOp, column, idOp), value, value, cursor.ID), nil
 }
 
 func (r *Product) buildPartUpdate(updateData usecase.ProductPartUpdateData) map[string]any {
@@ -160,9 +337,12 @@ func (r *Product) FindList(ctx context.Context, listOptions usecase.ProductListO
 		withDeleted = true
 	}
 	where := r.buildWhereForList(listOptions, withDeleted)
-	sort := r.buildSortForList(listOptions)
+	sort, sortArgs := r.buildSortForList(listOptions)
 
-	q := r.qb.Select(productTableFields...).From(productTable).Where(where).OrderBy(sort...)
+	q := r.qb.Select(productTableFields...).From(productTable).Where(where)
+	if sort != "" {
+		q = q.OrderByClause(sort, sortArgs...)
+	}
 
 	if queryParams != nil {
 		if queryParams.ForUpdate {
@@ -175,7 +355,13 @@ func (r *Product) FindList(ctx context.Context, listOptions usecase.ProductListO
 			q = q.Limit(queryParams.Limit)
 		}
 
-		if queryParams.Offset > 0 {
+		if queryParams.Cursor != nil {
+			cursorWhere, err := r.buildCursorWhere(listOptions, queryParams.Cursor)
+			if err != nil {
+				return nil, err
+			}
+			q = q.Where(cursorWhere)
+		} else if queryParams.Offset > 0 {
 			q = q.Offset(queryParams.Offset)
 		}
 	}
@@ -222,9 +408,12 @@ func (r *Product) FindPagedList(ctx context.Context, listOptions usecase.Product
 		withDeleted = true
 	}
 	where := r.buildWhereForList(listOptions, withDeleted)
-	sort := r.buildSortForList(listOptions)
+	sort, sortArgs := r.buildSortForList(listOptions)
 
-	q := r.qb.Select(productTableFields...).From(productTable).Where(where).OrderBy(sort...)
+	q := r.qb.Select(productTableFields...).From(productTable).Where(where)
+	if sort != "" {
+		q = q.OrderByClause(sort, sortArgs...)
+	}
 	qTotal := r.qb.Select("COUNT(*) as total").From(productTable).Where(where)
 
 	if queryParams != nil {
@@ -238,7 +427,13 @@ func (r *Product) FindPagedList(ctx context.Context, listOptions usecase.Product
 			q = q.Limit(queryParams.Limit)
 		}
 
-		if queryParams.Offset > 0 {
+		if queryParams.Cursor != nil {
+			cursorWhere, err := r.buildCursorWhere(listOptions, queryParams.Cursor)
+			if err != nil {
+				return nil, 0, err
+			}
+			q = q.Where(cursorWhere)
+		} else if queryParams.Offset > 0 {
 			q = q.Offset(queryParams.Offset)
 		}
 	}
@@ -283,17 +478,19 @@ func (r *Product) FindPagedList(ctx context.Context, listOptions usecase.Product
 		return nil
 	})
 
-	g.Go(func() error {
-		row := r.txc.DefaultTrOrDB(gCtx, r.db).QueryRow(gCtx, queryTotal, argsTotal...)
-		if err := row.Scan(&total); err != nil {
-			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
-			if !errIsConv {
-				r.logger.ErrorContext(ctx, "scan total", slog.Any("error", err))
+	if queryParams == nil || !queryParams.WithoutTotal {
+		g.Go(func() error {
+			row := r.txc.DefaultTrOrDB(gCtx, r.db).QueryRow(gCtx, queryTotal, argsTotal...)
+			if err := row.Scan(&total); err != nil {
+				errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+				if !errIsConv {
+					r.logger.ErrorContext(ctx, "scan total", slog.Any("error", err))
+				}
+				return convErr
 			}
-			return convErr
-		}
-		return nil
-	})
+			return nil
+		})
+	}
 
 	if err := g.Wait(); err != nil {
 		return nil, 0, err
@@ -489,3 +686,48 @@ func (r *Product) DeleteByList(ctx context.Context, listOptions usecase.ProductL
 	}
 	return nil
 }
+
+func (r *Product) Restore(ctx context.Context, id int64) error {
+
+	query, args, err := r.qb.Update(productTable).Where(squirrel.And{
+		squirrel.Eq{"id": id},
+		squirrel.Expr("deleted_at IS NOT NULL"),
+	}).Set("deleted_at", nil).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+	return nil
+}
+
+// Окончательное удаление строки товара, связанные данные удаляются каскадно
+func (r *Product) Purge(ctx context.Context, id int64) error {
+
+	query, args, err := r.qb.Delete(productTable).Where(squirrel.And{
+		squirrel.Eq{"id": id},
+		squirrel.Expr("deleted_at IS NOT NULL"),
+	}).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+	return nil
+}
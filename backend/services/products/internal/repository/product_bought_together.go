@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	productBoughtTogetherTable = "product_bought_together"
+)
+
+type DBProductBoughtTogether struct {
+	ProductID        int64 `db:"product_id"`
+	RelatedProductID int64 `db:"related_product_id"`
+	OrdersCount      int64 `db:"orders_count"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	productBoughtTogetherTableFields = []string{}
+	productBoughtTogetherDBSchema    = &DBProductBoughtTogether{}
+)
+
+func init() {
+	productBoughtTogetherTableFields = dbhelper.ExtractDBFields(productBoughtTogetherDBSchema)
+}
+
+type ProductBoughtTogether struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductBoughtTogether(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductBoughtTogether {
+	return &ProductBoughtTogether{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductBoughtTogether) dbToDomain(db *DBProductBoughtTogether) *domain.ProductBoughtTogether {
+	return &domain.ProductBoughtTogether{
+		ProductID:        db.ProductID,
+		RelatedProductID: db.RelatedProductID,
+		OrdersCount:      db.OrdersCount,
+		CreatedAt:        db.CreatedAt,
+	}
+}
+
+func (r *ProductBoughtTogether) buildWhereForList(listOptions usecase.ProductBoughtTogetherListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.ProductIDs != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductIDs})
+	}
+
+	return where
+}
+
+func (r *ProductBoughtTogether) FindList(ctx context.Context, listOptions usecase.ProductBoughtTogetherListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductBoughtTogether, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productBoughtTogetherTableFields...).From(productBoughtTogetherTable).Where(where).OrderBy("product_id ASC", "orders_count DESC", "related_product_id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBProductBoughtTogether{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.ProductBoughtTogether, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *ProductBoughtTogether) Create(ctx context.Context, item *domain.ProductBoughtTogether) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productBoughtTogetherDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(productBoughtTogetherTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductBoughtTogether) DeleteByList(ctx context.Context, listOptions usecase.ProductBoughtTogetherListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(productBoughtTogetherTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
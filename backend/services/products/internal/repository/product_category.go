@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	productCategoryTable = "product_category"
+)
+
+type DBProductCategory struct {
+	ProductID  int64 `db:"product_id"`
+	CategoryID int64 `db:"category_id"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	productCategoryTableFields = []string{}
+	productCategoryDBSchema    = &DBProductCategory{}
+)
+
+func init() {
+	productCategoryTableFields = dbhelper.ExtractDBFields(productCategoryDBSchema)
+}
+
+type ProductCategory struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductCategory(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductCategory {
+	return &ProductCategory{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductCategory) dbToDomain(db *DBProductCategory) *domain.ProductCategory {
+	return &domain.ProductCategory{
+		ProductID:  db.ProductID,
+		CategoryID: db.CategoryID,
+		CreatedAt:  db.CreatedAt,
+	}
+}
+
+func (r *ProductCategory) buildWhereForList(listOptions usecase.ProductCategoryListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.ProductIDs != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductIDs})
+	}
+
+	if listOptions.CategoryID != nil {
+		where = append(where, squirrel.Eq{"category_id": *listOptions.CategoryID})
+	}
+
+	return where
+}
+
+func (r *ProductCategory) FindList(ctx context.Context, listOptions usecase.ProductCategoryListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductCategory, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productCategoryTableFields...).From(productCategoryTable).Where(where).OrderBy("product_id ASC", "category_id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBProductCategory{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.ProductCategory, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *ProductCategory) Create(ctx context.Context, item *domain.ProductCategory) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productCategoryDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(productCategoryTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductCategory) DeleteByList(ctx context.Context, listOptions usecase.ProductCategoryListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(productCategoryTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
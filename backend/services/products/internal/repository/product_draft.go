@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	productDraftTable = "product_draft"
+)
+
+type DBProductDraft struct {
+	ProductID          int64           `db:"product_id"`
+	Name               string          `db:"name"`
+	FullDescription    string          `db:"full_description"`
+	Price              decimal.Decimal `db:"price"`
+	ImagePreviewFileID *uuid.UUID      `db:"image_preview_file_id"`
+	SliderFilesIDs     []uuid.UUID     `db:"slider_files_ids"`
+	BaseVersion        int64           `db:"base_version"`
+	ActorID            *uuid.UUID      `db:"actor_id"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+var (
+	productDraftTableFields = []string{}
+	productDraftDBSchema    = &DBProductDraft{}
+)
+
+func init() {
+	productDraftTableFields = dbhelper.ExtractDBFields(productDraftDBSchema)
+}
+
+type ProductDraft struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductDraft(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductDraft {
+	return &ProductDraft{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductDraft) dbToDomain(db *DBProductDraft) *domain.ProductDraft {
+	return &domain.ProductDraft{
+		ProductID:          db.ProductID,
+		Name:               db.Name,
+		FullDescription:    db.FullDescription,
+		Price:              db.Price,
+		ImagePreviewFileID: db.ImagePreviewFileID,
+		SliderFilesIDs:     db.SliderFilesIDs,
+		BaseVersion:        db.BaseVersion,
+		ActorID:            db.ActorID,
+		CreatedAt:          db.CreatedAt,
+		UpdatedAt:          db.UpdatedAt,
+	}
+}
+
+func (r *ProductDraft) FindOneByProductID(ctx context.Context, productID int64, queryParams *uctypes.QueryGetOneParams) (*domain.ProductDraft, error) {
+	q := r.qb.Select(productDraftTableFields...).From(productDraftTable).Where(squirrel.Eq{"product_id": productID})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBProductDraft{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+func (r *ProductDraft) Create(ctx context.Context, item *domain.ProductDraft) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productDraftDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(productDraftTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductDraft) Update(ctx context.Context, item *domain.ProductDraft) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productDraftDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "product_id")
+	delete(dataMap, "created_at")
+
+	query, args, err := r.qb.Update(productDraftTable).Where(squirrel.Eq{"product_id": item.ProductID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductDraft) Delete(ctx context.Context, productID int64) error {
+	query, args, err := r.qb.Delete(productDraftTable).Where(squirrel.Eq{"product_id": productID}).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -21,9 +21,12 @@ const (
 )
 
 type DBProductOrderBlock struct {
-	ProductID int64 `db:"product_id"`
-	OrderID   int64 `db:"order_id"`
-	Quantity  int32 `db:"quantity"`
+	ProductID   int64      `db:"product_id"`
+	VariantID   int64      `db:"variant_id"`
+	OrderID     int64      `db:"order_id"`
+	WarehouseID int64      `db:"warehouse_id"`
+	Quantity    int32      `db:"quantity"`
+	ExpiresAt   *time.Time `db:"expires_at"`
 
 	CreatedAt time.Time `db:"created_at"`
 }
@@ -55,10 +58,13 @@ func NewProductOrderBlock(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGet
 
 func (r *ProductOrderBlock) dbToDomain(db *DBProductOrderBlock) *domain.ProductOrderBlock {
 	return &domain.ProductOrderBlock{
-		ProductID: db.ProductID,
-		OrderID:   db.OrderID,
-		Quantity:  db.Quantity,
-		CreatedAt: db.CreatedAt,
+		ProductID:   db.ProductID,
+		VariantID:   db.VariantID,
+		OrderID:     db.OrderID,
+		WarehouseID: db.WarehouseID,
+		Quantity:    db.Quantity,
+		ExpiresAt:   db.ExpiresAt,
+		CreatedAt:   db.CreatedAt,
 	}
 }
 
@@ -69,10 +75,22 @@ func (r *ProductOrderBlock) buildWhereForList(listOptions usecase.ProductOrderBl
 		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
 	}
 
+	if listOptions.VariantID != nil {
+		where = append(where, squirrel.Eq{"variant_id": *listOptions.VariantID})
+	}
+
 	if listOptions.OrderID != nil {
 		where = append(where, squirrel.Eq{"order_id": *listOptions.OrderID})
 	}
 
+	if listOptions.WarehouseID != nil {
+		where = append(where, squirrel.Eq{"warehouse_id": *listOptions.WarehouseID})
+	}
+
+	if listOptions.ExpiresAtTo != nil {
+		where = append(where, squirrel.LtOrEq{"expires_at": *listOptions.ExpiresAtTo})
+	}
+
 	return where
 }
 
@@ -179,3 +197,67 @@ func (r *ProductOrderBlock) DeleteByList(ctx context.Context, listOptions usecas
 
 	return nil
 }
+
+func (r *ProductOrderBlock) FindOrderIDs(ctx context.Context, listOptions usecase.ProductOrderBlockListOptions, offset uint64, limit uint64) ([]int64, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select("order_id").Distinct().From(productOrderBlockTable).Where(where).OrderBy("order_id")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	result := []int64{}
+
+	if err := pgxscan.ScanAll(&result, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return result, nil
+}
+
+func (r *ProductOrderBlock) UpdateExpiresAtByOrderID(ctx context.Context, orderID int64, expiresAt *time.Time) error {
+
+	query, args, err := r.qb.Update(productOrderBlockTable).Where(squirrel.Eq{"order_id": orderID}).Set("expires_at", expiresAt).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	productOrderConsumptionTable = "product_order_consumption"
+)
+
+type DBProductOrderConsumption struct {
+	ProductID   int64 `db:"product_id"`
+	VariantID   int64 `db:"variant_id"`
+	OrderID     int64 `db:"order_id"`
+	WarehouseID int64 `db:"warehouse_id"`
+	Quantity    int32 `db:"quantity"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	productOrderConsumptionTableFields = []string{}
+	productOrderConsumptionDBSchema    = &DBProductOrderConsumption{}
+)
+
+func init() {
+	productOrderConsumptionTableFields = dbhelper.ExtractDBFields(productOrderConsumptionDBSchema)
+}
+
+type ProductOrderConsumption struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductOrderConsumption(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductOrderConsumption {
+	return &ProductOrderConsumption{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductOrderConsumption) dbToDomain(db *DBProductOrderConsumption) *domain.ProductOrderConsumption {
+	return &domain.ProductOrderConsumption{
+		ProductID:   db.ProductID,
+		VariantID:   db.VariantID,
+		OrderID:     db.OrderID,
+		WarehouseID: db.WarehouseID,
+		Quantity:    db.Quantity,
+		CreatedAt:   db.CreatedAt,
+	}
+}
+
+func (r *ProductOrderConsumption) buildWhereForList(listOptions usecase.ProductOrderConsumptionListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.VariantID != nil {
+		where = append(where, squirrel.Eq{"variant_id": *listOptions.VariantID})
+	}
+
+	if listOptions.OrderID != nil {
+		where = append(where, squirrel.Eq{"order_id": *listOptions.OrderID})
+	}
+
+	if listOptions.WarehouseID != nil {
+		where = append(where, squirrel.Eq{"warehouse_id": *listOptions.WarehouseID})
+	}
+
+	return where
+}
+
+func (r *ProductOrderConsumption) FindList(ctx context.Context, listOptions usecase.ProductOrderConsumptionListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductOrderConsumption, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productOrderConsumptionTableFields...).From(productOrderConsumptionTable).Where(where)
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBProductOrderConsumption{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.ProductOrderConsumption, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *ProductOrderConsumption) Create(ctx context.Context, item *domain.ProductOrderConsumption) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productOrderConsumptionDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(productOrderConsumptionTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	productPriceHistoryTable = "product_price_history"
+)
+
+type DBProductPriceHistory struct {
+	ID             int64            `db:"id"`
+	ProductID      int64            `db:"product_id"`
+	OldPrice       *decimal.Decimal `db:"old_price"`
+	NewPrice       decimal.Decimal  `db:"new_price"`
+	CompareAtPrice *decimal.Decimal `db:"compare_at_price"`
+	Reason         string           `db:"reason"`
+	ScheduleID     *int64           `db:"schedule_id"`
+	ActorID        *uuid.UUID       `db:"actor_id"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	productPriceHistoryTableFields = []string{}
+	productPriceHistoryDBSchema    = &DBProductPriceHistory{}
+)
+
+func init() {
+	productPriceHistoryTableFields = dbhelper.ExtractDBFields(productPriceHistoryDBSchema)
+}
+
+type ProductPriceHistory struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductPriceHistory(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductPriceHistory {
+	return &ProductPriceHistory{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductPriceHistory) dbToDomain(db *DBProductPriceHistory) *domain.ProductPriceHistory {
+	return &domain.ProductPriceHistory{
+		ID:             db.ID,
+		ProductID:      db.ProductID,
+		OldPrice:       db.OldPrice,
+		NewPrice:       db.NewPrice,
+		CompareAtPrice: db.CompareAtPrice,
+		Reason:         domain.ProductPriceChangeReason(db.Reason),
+		ScheduleID:     db.ScheduleID,
+		ActorID:        db.ActorID,
+		CreatedAt:      db.CreatedAt,
+	}
+}
+
+func (r *ProductPriceHistory) buildWhereForList(listOptions usecase.ProductPriceHistoryListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.Reason != nil {
+		where = append(where, squirrel.Eq{"reason": listOptions.Reason.String()})
+	}
+
+	return where
+}
+
+func (r *ProductPriceHistory) FindPagedList(ctx context.Context, listOptions usecase.ProductPriceHistoryListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductPriceHistory, int64, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productPriceHistoryTableFields...).From(productPriceHistoryTable).Where(where).OrderBy("id DESC")
+	qTotal := r.qb.Select("COUNT(*) as total").From(productPriceHistoryTable).Where(where)
+
+	if queryParams != nil {
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, 0, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	queryTotal, argsTotal, err := qTotal.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building total query", slog.Any("error", err))
+		return nil, 0, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	var (
+		dbData []*DBProductPriceHistory
+		total  int64
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		rows, err := r.txc.DefaultTrOrDB(gCtx, r.db).Query(gCtx, query, args...)
+		if err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+			}
+			return convErr
+		}
+		defer rows.Close()
+
+		if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+			}
+			return convErr
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		row := r.txc.DefaultTrOrDB(gCtx, r.db).QueryRow(gCtx, queryTotal, argsTotal...)
+		if err := row.Scan(&total); err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "scan total", slog.Any("error", err))
+			}
+			return convErr
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*domain.ProductPriceHistory, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, total, nil
+}
+
+func (r *ProductPriceHistory) Create(ctx context.Context, item *domain.ProductPriceHistory) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productPriceHistoryDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+
+	query, args, err := r.qb.Insert(productPriceHistoryTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
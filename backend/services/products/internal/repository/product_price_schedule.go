@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	productPriceScheduleTable = "product_price_schedule"
+)
+
+type DBProductPriceSchedule struct {
+	ID                 int64            `db:"id"`
+	ProductID          int64            `db:"product_id"`
+	Price              decimal.Decimal  `db:"price"`
+	StartsAt           time.Time        `db:"starts_at"`
+	EndsAt             *time.Time       `db:"ends_at"`
+	Status             string           `db:"status"`
+	PrevPrice          *decimal.Decimal `db:"prev_price"`
+	PrevCompareAtPrice *decimal.Decimal `db:"prev_compare_at_price"`
+	ActorID            *uuid.UUID       `db:"actor_id"`
+	AppliedAt          *time.Time       `db:"applied_at"`
+	FinishedAt         *time.Time       `db:"finished_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	productPriceScheduleTableFields = []string{}
+	productPriceScheduleDBSchema    = &DBProductPriceSchedule{}
+)
+
+func init() {
+	productPriceScheduleTableFields = dbhelper.ExtractDBFields(productPriceScheduleDBSchema)
+}
+
+type ProductPriceSchedule struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductPriceSchedule(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductPriceSchedule {
+	return &ProductPriceSchedule{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductPriceSchedule) dbToDomain(db *DBProductPriceSchedule) *domain.ProductPriceSchedule {
+	return &domain.ProductPriceSchedule{
+		ID:                 db.ID,
+		ProductID:          db.ProductID,
+		Price:              db.Price,
+		StartsAt:           db.StartsAt,
+		EndsAt:             db.EndsAt,
+		Status:             domain.ProductPriceScheduleStatus(db.Status),
+		PrevPrice:          db.PrevPrice,
+		PrevCompareAtPrice: db.PrevCompareAtPrice,
+		ActorID:            db.ActorID,
+		AppliedAt:          db.AppliedAt,
+		FinishedAt:         db.FinishedAt,
+		CreatedAt:          db.CreatedAt,
+	}
+}
+
+func (r *ProductPriceSchedule) buildWhereForList(listOptions usecase.ProductPriceScheduleListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.Statuses != nil {
+		where = append(where, squirrel.Eq{"status": lo.Map(*listOptions.Statuses, func(item domain.ProductPriceScheduleStatus, _ int) string {
+			return item.String()
+		})})
+	}
+
+	if listOptions.StartsAtTo != nil {
+		where = append(where, squirrel.LtOrEq{"starts_at": *listOptions.StartsAtTo})
+	}
+
+	if listOptions.EndsAtTo != nil {
+		where = append(where, squirrel.LtOrEq{"ends_at": *listOptions.EndsAtTo})
+	}
+
+	return where
+}
+
+func (r *ProductPriceSchedule) FindList(ctx context.Context, listOptions usecase.ProductPriceScheduleListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductPriceSchedule, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productPriceScheduleTableFields...).From(productPriceScheduleTable).Where(where).OrderBy("starts_at ASC", "id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBProductPriceSchedule{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.ProductPriceSchedule, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *ProductPriceSchedule) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.ProductPriceSchedule, error) {
+	q := r.qb.Select(productPriceScheduleTableFields...).From(productPriceScheduleTable).Where(squirrel.Eq{"id": id})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBProductPriceSchedule{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+func (r *ProductPriceSchedule) Create(ctx context.Context, item *domain.ProductPriceSchedule) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productPriceScheduleDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+
+	query, args, err := r.qb.Insert(productPriceScheduleTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductPriceSchedule) Update(ctx context.Context, item *domain.ProductPriceSchedule) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productPriceScheduleDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "product_id")
+	delete(dataMap, "created_at")
+
+	query, args, err := r.qb.Update(productPriceScheduleTable).Where(squirrel.Eq{"id": item.ID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	productRelatedTable = "product_related"
+)
+
+type DBProductRelated struct {
+	ProductID        int64 `db:"product_id"`
+	RelatedProductID int64 `db:"related_product_id"`
+	Sort             int32 `db:"sort"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	productRelatedTableFields = []string{}
+	productRelatedDBSchema    = &DBProductRelated{}
+)
+
+func init() {
+	productRelatedTableFields = dbhelper.ExtractDBFields(productRelatedDBSchema)
+}
+
+type ProductRelated struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductRelated(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductRelated {
+	return &ProductRelated{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductRelated) dbToDomain(db *DBProductRelated) *domain.ProductRelated {
+	return &domain.ProductRelated{
+		ProductID:        db.ProductID,
+		RelatedProductID: db.RelatedProductID,
+		Sort:             db.Sort,
+		CreatedAt:        db.CreatedAt,
+	}
+}
+
+func (r *ProductRelated) buildWhereForList(listOptions usecase.ProductRelatedListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.ProductIDs != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductIDs})
+	}
+
+	if listOptions.RelatedProductID != nil {
+		where = append(where, squirrel.Eq{"related_product_id": *listOptions.RelatedProductID})
+	}
+
+	return where
+}
+
+func (r *ProductRelated) FindList(ctx context.Context, listOptions usecase.ProductRelatedListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductRelated, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productRelatedTableFields...).From(productRelatedTable).Where(where).OrderBy("product_id ASC", "sort ASC", "related_product_id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBProductRelated{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.ProductRelated, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *ProductRelated) Create(ctx context.Context, item *domain.ProductRelated) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productRelatedDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(productRelatedTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductRelated) DeleteByList(ctx context.Context, listOptions usecase.ProductRelatedListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(productRelatedTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
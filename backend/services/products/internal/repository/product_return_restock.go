@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	productReturnRestockTable = "product_return_restock"
+)
+
+type DBProductReturnRestock struct {
+	ProductID   int64 `db:"product_id"`
+	VariantID   int64 `db:"variant_id"`
+	ReturnID    int64 `db:"return_id"`
+	WarehouseID int64 `db:"warehouse_id"`
+	Quantity    int32 `db:"quantity"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	productReturnRestockTableFields = []string{}
+	productReturnRestockDBSchema    = &DBProductReturnRestock{}
+)
+
+func init() {
+	productReturnRestockTableFields = dbhelper.ExtractDBFields(productReturnRestockDBSchema)
+}
+
+type ProductReturnRestock struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductReturnRestock(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductReturnRestock {
+	return &ProductReturnRestock{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductReturnRestock) dbToDomain(db *DBProductReturnRestock) *domain.ProductReturnRestock {
+	return &domain.ProductReturnRestock{
+		ProductID:   db.ProductID,
+		VariantID:   db.VariantID,
+		ReturnID:    db.ReturnID,
+		WarehouseID: db.WarehouseID,
+		Quantity:    db.Quantity,
+		CreatedAt:   db.CreatedAt,
+	}
+}
+
+func (r *ProductReturnRestock) buildWhereForList(listOptions usecase.ProductReturnRestockListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.VariantID != nil {
+		where = append(where, squirrel.Eq{"variant_id": *listOptions.VariantID})
+	}
+
+	if listOptions.ReturnID != nil {
+		where = append(where, squirrel.Eq{"return_id": *listOptions.ReturnID})
+	}
+
+	if listOptions.WarehouseID != nil {
+		where = append(where, squirrel.Eq{"warehouse_id": *listOptions.WarehouseID})
+	}
+
+	return where
+}
+
+func (r *ProductReturnRestock) FindList(ctx context.Context, listOptions usecase.ProductReturnRestockListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductReturnRestock, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productReturnRestockTableFields...).From(productReturnRestockTable).Where(where)
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBProductReturnRestock{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.ProductReturnRestock, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *ProductReturnRestock) Create(ctx context.Context, item *domain.ProductReturnRestock) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productReturnRestockDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	query, args, err := r.qb.Insert(productReturnRestockTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductReturnRestock) DeleteByList(ctx context.Context, listOptions usecase.ProductReturnRestockListOptions) error {
+
+	where := r.buildWhereForList(listOptions)
+
+	query, args, err := r.qb.Delete(productReturnRestockTable).Where(where).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
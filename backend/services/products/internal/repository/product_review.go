@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	productReviewTable = "product_review"
+)
+
+type DBProductReview struct {
+	ID          int64      `db:"id"`
+	ProductID   int64      `db:"product_id"`
+	OrderID     int64      `db:"order_id"`
+	AuthorName  string     `db:"author_name"`
+	Rating      int16      `db:"rating"`
+	Text        string     `db:"text"`
+	Status      string     `db:"status"`
+	ModeratedBy *uuid.UUID `db:"moderated_by"`
+	ModeratedAt *time.Time `db:"moderated_at"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+type DBProductRating struct {
+	ProductID int64           `db:"product_id"`
+	Avg       decimal.Decimal `db:"avg"`
+	Count     int64           `db:"count"`
+}
+
+var (
+	productReviewTableFields = []string{}
+	productReviewDBSchema    = &DBProductReview{}
+)
+
+func init() {
+	productReviewTableFields = dbhelper.ExtractDBFields(productReviewDBSchema)
+}
+
+type ProductReview struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewProductReview(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *ProductReview {
+	return &ProductReview{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ProductReview) dbToDomain(db *DBProductReview) *domain.ProductReview {
+	return &domain.ProductReview{
+		ID:          db.ID,
+		ProductID:   db.ProductID,
+		OrderID:     db.OrderID,
+		AuthorName:  db.AuthorName,
+		Rating:      db.Rating,
+		Text:        db.Text,
+		Status:      domain.ProductReviewStatus(db.Status),
+		ModeratedBy: db.ModeratedBy,
+		ModeratedAt: db.ModeratedAt,
+		CreatedAt:   db.CreatedAt,
+		UpdatedAt:   db.UpdatedAt,
+	}
+}
+
+func (r *ProductReview) buildWhereForList(listOptions usecase.ProductReviewListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.OrderID != nil {
+		where = append(where, squirrel.Eq{"order_id": *listOptions.OrderID})
+	}
+
+	if listOptions.Status != nil {
+		where = append(where, squirrel.Eq{"status": listOptions.Status.String()})
+	}
+
+	return where
+}
+
+func (r *ProductReview) FindPagedList(ctx context.Context, listOptions usecase.ProductReviewListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductReview, int64, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(productReviewTableFields...).From(productReviewTable).Where(where).OrderBy("id DESC")
+	qTotal := r.qb.Select("COUNT(*) as total").From(productReviewTable).Where(where)
+
+	if queryParams != nil {
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, 0, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	queryTotal, argsTotal, err := qTotal.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building total query", slog.Any("error", err))
+		return nil, 0, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	var (
+		dbData []*DBProductReview
+		total  int64
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		rows, err := r.txc.DefaultTrOrDB(gCtx, r.db).Query(gCtx, query, args...)
+		if err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+			}
+			return convErr
+		}
+		defer rows.Close()
+
+		if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+			}
+			return convErr
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		row := r.txc.DefaultTrOrDB(gCtx, r.db).QueryRow(gCtx, queryTotal, argsTotal...)
+		if err := row.Scan(&total); err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "scan total", slog.Any("error", err))
+			}
+			return convErr
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*domain.ProductReview, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, total, nil
+}
+
+func (r *ProductReview) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.ProductReview, error) {
+	q := r.qb.Select(productReviewTableFields...).From(productReviewTable).Where(squirrel.Eq{"id": id})
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := &DBProductReview{}
+
+	if err := pgxscan.ScanOne(dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	return r.dbToDomain(dbData), nil
+}
+
+// Средняя оценка и количество одобренных отзывов по товарам
+func (r *ProductReview) FindRatingsByProducts(ctx context.Context, productIDs []int64) ([]*domain.ProductRating, error) {
+	if len(productIDs) == 0 {
+		return []*domain.ProductRating{}, nil
+	}
+
+	query, args, err := r.qb.
+		Select("product_id", "ROUND(AVG(rating), 2) AS avg", "COUNT(*) AS count").
+		From(productReviewTable).
+		Where(squirrel.Eq{
+			"product_id": productIDs,
+			"status":     domain.ProductReviewStatusApproved.String(),
+		}).
+		GroupBy("product_id").
+		ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+	defer rows.Close()
+
+	var dbData []*DBProductRating
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.ProductRating, 0, len(dbData))
+	for _, item := range dbData {
+		result = append(result, &domain.ProductRating{
+			ProductID: item.ProductID,
+			Avg:       item.Avg,
+			Count:     item.Count,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *ProductReview) Create(ctx context.Context, item *domain.ProductReview) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productReviewDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+
+	query, args, err := r.qb.Insert(productReviewTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductReview) Update(ctx context.Context, item *domain.ProductReview) error {
+	dataMap, err := dbhelper.StructToDBMap(item, productReviewDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+	delete(dataMap, "product_id")
+	delete(dataMap, "order_id")
+	delete(dataMap, "created_at")
+
+	query, args, err := r.qb.Update(productReviewTable).Where(squirrel.Eq{"id": item.ID}).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *ProductReview) Delete(ctx context.Context, id int64) error {
+	query, args, err := r.qb.Delete(productReviewTable).Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
@@ -70,6 +70,10 @@ func (r *ProductSliderImage) buildWhereForList(listOptions usecase.ProductSlider
 		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
 	}
 
+	if listOptions.ProductIDs != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductIDs})
+	}
+
 	if listOptions.FileID != nil {
 		where = append(where, squirrel.Eq{"file_id": *listOptions.FileID})
 	}
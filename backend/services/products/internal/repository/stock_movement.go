@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	stockMovementTable = "stock_movement"
+)
+
+type DBStockMovement struct {
+	ID          int64      `db:"id"`
+	ProductID   int64      `db:"product_id"`
+	VariantID   int64      `db:"variant_id"`
+	WarehouseID *int64     `db:"warehouse_id"`
+	Delta       int32      `db:"delta"`
+	Quantity    int32      `db:"quantity"`
+	Reason      string     `db:"reason"`
+	OrderID     *int64     `db:"order_id"`
+	ReturnID    *int64     `db:"return_id"`
+	ActorID     *uuid.UUID `db:"actor_id"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+var (
+	stockMovementTableFields = []string{}
+	stockMovementDBSchema    = &DBStockMovement{}
+)
+
+func init() {
+	stockMovementTableFields = dbhelper.ExtractDBFields(stockMovementDBSchema)
+}
+
+type StockMovement struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewStockMovement(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *StockMovement {
+	return &StockMovement{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *StockMovement) dbToDomain(db *DBStockMovement) *domain.StockMovement {
+	return &domain.StockMovement{
+		ID:          db.ID,
+		ProductID:   db.ProductID,
+		VariantID:   db.VariantID,
+		WarehouseID: db.WarehouseID,
+		Delta:       db.Delta,
+		Quantity:    db.Quantity,
+		Reason:      domain.StockMovementReason(db.Reason),
+		OrderID:     db.OrderID,
+		ReturnID:    db.ReturnID,
+		ActorID:     db.ActorID,
+		CreatedAt:   db.CreatedAt,
+	}
+}
+
+func (r *StockMovement) buildWhereForList(listOptions usecase.StockMovementListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.VariantID != nil {
+		where = append(where, squirrel.Eq{"variant_id": *listOptions.VariantID})
+	}
+
+	if listOptions.OrderID != nil {
+		where = append(where, squirrel.Eq{"order_id": *listOptions.OrderID})
+	}
+
+	if listOptions.WarehouseID != nil {
+		where = append(where, squirrel.Eq{"warehouse_id": *listOptions.WarehouseID})
+	}
+
+	if listOptions.Reason != nil {
+		where = append(where, squirrel.Eq{"reason": listOptions.Reason.String()})
+	}
+
+	return where
+}
+
+func (r *StockMovement) FindPagedList(ctx context.Context, listOptions usecase.StockMovementListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.StockMovement, int64, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(stockMovementTableFields...).From(stockMovementTable).Where(where).OrderBy("id DESC")
+	qTotal := r.qb.Select("COUNT(*) as total").From(stockMovementTable).Where(where)
+
+	if queryParams != nil {
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, 0, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	queryTotal, argsTotal, err := qTotal.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building total query", slog.Any("error", err))
+		return nil, 0, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	var (
+		dbData []*DBStockMovement
+		total  int64
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		rows, err := r.txc.DefaultTrOrDB(gCtx, r.db).Query(gCtx, query, args...)
+		if err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+			}
+			return convErr
+		}
+		defer rows.Close()
+
+		if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+			}
+			return convErr
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		row := r.txc.DefaultTrOrDB(gCtx, r.db).QueryRow(gCtx, queryTotal, argsTotal...)
+		if err := row.Scan(&total); err != nil {
+			errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+			if !errIsConv {
+				r.logger.ErrorContext(ctx, "scan total", slog.Any("error", err))
+			}
+			return convErr
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*domain.StockMovement, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, total, nil
+}
+
+func (r *StockMovement) Create(ctx context.Context, item *domain.StockMovement) error {
+	dataMap, err := dbhelper.StructToDBMap(item, stockMovementDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "id")
+
+	query, args, err := r.qb.Insert(stockMovementTable).SetMap(dataMap).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	row := r.txc.DefaultTrOrDB(ctx, r.db).QueryRow(ctx, query, args...)
+
+	if err := row.Scan(&item.ID); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
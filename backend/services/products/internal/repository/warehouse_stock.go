@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/db"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/dbhelper"
+)
+
+const (
+	warehouseStockTable = "warehouse_stock"
+)
+
+type DBWarehouseStock struct {
+	WarehouseID int64 `db:"warehouse_id"`
+	ProductID   int64 `db:"product_id"`
+	VariantID   int64 `db:"variant_id"`
+	Quantity    int32 `db:"quantity"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+var (
+	warehouseStockTableFields = []string{}
+	warehouseStockDBSchema    = &DBWarehouseStock{}
+)
+
+func init() {
+	warehouseStockTableFields = dbhelper.ExtractDBFields(warehouseStockDBSchema)
+}
+
+type WarehouseStock struct {
+	logger *slog.Logger
+	db     db.PgxPool
+	txc    *trmpgx.CtxGetter
+	qb     squirrel.StatementBuilderType
+}
+
+func NewWarehouseStock(logger *slog.Logger, db db.PgxPool, txc *trmpgx.CtxGetter) *WarehouseStock {
+	return &WarehouseStock{
+		logger: logger,
+		db:     db,
+		txc:    txc,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *WarehouseStock) dbToDomain(db *DBWarehouseStock) *domain.WarehouseStock {
+	return &domain.WarehouseStock{
+		WarehouseID: db.WarehouseID,
+		ProductID:   db.ProductID,
+		VariantID:   db.VariantID,
+		Quantity:    db.Quantity,
+
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
+	}
+}
+
+func (r *WarehouseStock) buildWhereForList(listOptions usecase.WarehouseStockListOptions) squirrel.And {
+	where := squirrel.And{}
+
+	if listOptions.WarehouseID != nil {
+		where = append(where, squirrel.Eq{"warehouse_id": *listOptions.WarehouseID})
+	}
+
+	if listOptions.ProductID != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductID})
+	}
+
+	if listOptions.ProductIDs != nil {
+		where = append(where, squirrel.Eq{"product_id": *listOptions.ProductIDs})
+	}
+
+	if listOptions.VariantID != nil {
+		where = append(where, squirrel.Eq{"variant_id": *listOptions.VariantID})
+	}
+
+	if listOptions.OnlyPositive {
+		where = append(where, squirrel.Gt{"quantity": 0})
+	}
+
+	return where
+}
+
+func (r *WarehouseStock) FindList(ctx context.Context, listOptions usecase.WarehouseStockListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.WarehouseStock, error) {
+
+	where := r.buildWhereForList(listOptions)
+
+	q := r.qb.Select(warehouseStockTableFields...).From(warehouseStockTable).Where(where).OrderBy("warehouse_id ASC", "product_id ASC", "variant_id ASC")
+
+	if queryParams != nil {
+		if queryParams.ForUpdate {
+			q = q.Suffix("FOR UPDATE")
+		} else if queryParams.ForShare {
+			q = q.Suffix("FOR SHARE")
+		}
+
+		if queryParams.Limit > 0 {
+			q = q.Limit(queryParams.Limit)
+		}
+
+		if queryParams.Offset > 0 {
+			q = q.Offset(queryParams.Offset)
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return nil, e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	rows, err := r.txc.DefaultTrOrDB(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	defer rows.Close()
+
+	dbData := []*DBWarehouseStock{}
+
+	if err := pgxscan.ScanAll(&dbData, rows); err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "scan row", slog.Any("error", err))
+		}
+		return nil, convErr
+	}
+
+	result := make([]*domain.WarehouseStock, 0, len(dbData))
+	for _, dbItem := range dbData {
+		result = append(result, r.dbToDomain(dbItem))
+	}
+
+	return result, nil
+}
+
+func (r *WarehouseStock) Create(ctx context.Context, item *domain.WarehouseStock) error {
+	dataMap, err := dbhelper.StructToDBMap(item, warehouseStockDBSchema)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "convert struct to db map", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+	delete(dataMap, "updated_at")
+
+	query, args, err := r.qb.Insert(warehouseStockTable).SetMap(dataMap).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
+
+func (r *WarehouseStock) Update(ctx context.Context, item *domain.WarehouseStock) error {
+
+	query, args, err := r.qb.Update(warehouseStockTable).Where(squirrel.Eq{
+		"warehouse_id": item.WarehouseID,
+		"product_id":   item.ProductID,
+		"variant_id":   item.VariantID,
+	}).Set("quantity", item.Quantity).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "building query", slog.Any("error", err))
+		return e.NewErrorFrom(e.ErrInternal).Wrap(err)
+	}
+
+	_, err = r.txc.DefaultTrOrDB(ctx, r.db).Exec(ctx, query, args...)
+	if err != nil {
+		errIsConv, convErr := e.ErrConvertPgxToLogic(err)
+		if !errIsConv {
+			r.logger.ErrorContext(ctx, "executing query", slog.Any("error", err))
+		}
+		return convErr
+	}
+
+	return nil
+}
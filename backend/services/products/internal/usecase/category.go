@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+var ErrCategorySlugAlreadyExists = e.NewErrorFrom(e.ErrConflict).SetMessage("category with this slug already exists")
+var ErrCategoryHasChildren = e.NewErrorFrom(e.ErrBadRequest).SetMessage("category has child categories")
+
+type CategoryListOptions struct {
+	IDs         *[]int64
+	Slug        *string
+	IsPublished *bool
+}
+
+type CategoryIn struct {
+	ParentID    *int64
+	Name        string
+	Slug        string
+	Sort        int32
+	IsPublished bool
+}
+
+//go:generate mockery --name=Category --output=../../tests/mocks --case=underscore
+type Category interface {
+	FindList(ctx context.Context, listOptions CategoryListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Category, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.Category, err error)
+	Create(ctx context.Context, input CategoryIn) (item *domain.Category, err error)
+	Update(ctx context.Context, id int64, input CategoryIn) (item *domain.Category, err error)
+	Delete(ctx context.Context, id int64) (err error)
+}
+
+//go:generate mockery --name=CategoryRepository --output=../../tests/mocks --case=underscore
+type CategoryRepository interface {
+	FindList(ctx context.Context, listOptions CategoryListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Category, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.Category, err error)
+	Create(ctx context.Context, item *domain.Category) (err error)
+	Update(ctx context.Context, item *domain.Category) (err error)
+	DeleteByList(ctx context.Context, listOptions CategoryListOptions) (err error)
+}
+
+type CategoryInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      CategoryRepository
+	txManager *manager.Manager
+}
+
+func NewCategoryInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo CategoryRepository) *CategoryInpl {
+	uc := &CategoryInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *CategoryInpl) FindList(ctx context.Context, listOptions CategoryListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Category, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *CategoryInpl) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.Category, error) {
+	return uc.repo.FindOneByID(ctx, id, queryParams)
+}
+
+func (uc *CategoryInpl) Create(ctx context.Context, input CategoryIn) (*domain.Category, error) {
+	category := domain.NewCategory(0)
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		err := uc.setData(ctx, category, input)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Create(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (uc *CategoryInpl) Update(ctx context.Context, id int64, input CategoryIn) (*domain.Category, error) {
+	var category *domain.Category
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		category, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = uc.setData(ctx, category, input)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Update(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (uc *CategoryInpl) setData(ctx context.Context, category *domain.Category, input CategoryIn) error {
+	err := category.SetSlug(input.Slug)
+	if err != nil {
+		return err
+	}
+
+	items, err := uc.repo.FindList(ctx, CategoryListOptions{
+		Slug: &input.Slug,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.ID != category.ID {
+			return ErrCategorySlugAlreadyExists
+		}
+	}
+
+	// Дерево категорий блокируем целиком, чтобы параллельные переносы не образовали цикл
+	all, err := uc.repo.FindList(ctx, CategoryListOptions{}, &uctypes.QueryGetListParams{
+		ForUpdate: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if input.ParentID != nil {
+		_, ok := lo.Find(all, func(item *domain.Category) bool {
+			return item.ID == *input.ParentID
+		})
+		if !ok {
+			return domain.ErrCategoryInvalidParent
+		}
+	}
+
+	var descendantIDs []int64
+	if category.ID > 0 {
+		descendantIDs = CategoryDescendantIDs(all, category.ID)
+	}
+
+	err = category.SetParent(input.ParentID, descendantIDs)
+	if err != nil {
+		return err
+	}
+
+	category.Name = input.Name
+	category.Sort = input.Sort
+	category.IsPublished = input.IsPublished
+
+	return nil
+}
+
+func (uc *CategoryInpl) Delete(ctx context.Context, id int64) error {
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		category, err := uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		all, err := uc.repo.FindList(ctx, CategoryListOptions{}, nil)
+		if err != nil {
+			return err
+		}
+
+		if len(CategoryDescendantIDs(all, category.ID)) > 0 {
+			return ErrCategoryHasChildren
+		}
+
+		// Привязки товаров к категории удаляются каскадно
+		return uc.repo.DeleteByList(ctx, CategoryListOptions{
+			IDs: &[]int64{category.ID},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Все потомки категории на любой глубине
+func CategoryDescendantIDs(categories []*domain.Category, id int64) []int64 {
+	childrenByParent := make(map[int64][]int64, len(categories))
+	for _, item := range categories {
+		if item.ParentID != nil {
+			childrenByParent[*item.ParentID] = append(childrenByParent[*item.ParentID], item.ID)
+		}
+	}
+
+	result := make([]int64, 0)
+	queue := []int64{id}
+	visited := map[int64]bool{id: true}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, childID := range childrenByParent[cur] {
+			if visited[childID] {
+				continue
+			}
+			visited[childID] = true
+			result = append(result, childID)
+			queue = append(queue, childID)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+var ErrCollectionSlugAlreadyExists = e.NewErrorFrom(e.ErrConflict).SetMessage("collection with this slug already exists")
+var ErrCollectionNotManual = e.NewErrorFrom(e.ErrBadRequest).SetMessage("products can be set only for manual collection")
+var ErrCollectionProductInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid product id")
+
+type CollectionListOptions struct {
+	IDs         *[]int64
+	Slug        *string
+	IsPublished *bool
+}
+
+type CollectionIn struct {
+	Name        string
+	Slug        string
+	Description string
+	Kind        domain.CollectionKind
+	Rule        domain.CollectionRule
+	Sort        int32
+	IsPublished bool
+}
+
+//go:generate mockery --name=Collection --output=../../tests/mocks --case=underscore
+type Collection interface {
+	FindList(ctx context.Context, listOptions CollectionListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Collection, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.Collection, err error)
+	FindOneBySlug(ctx context.Context, slug string) (item *domain.Collection, err error)
+	Create(ctx context.Context, input CollectionIn) (item *domain.Collection, err error)
+	Update(ctx context.Context, id int64, input CollectionIn) (item *domain.Collection, err error)
+	Delete(ctx context.Context, id int64) (err error)
+	FindProductIDs(ctx context.Context, id int64) (ids []int64, err error)
+	SaveProducts(ctx context.Context, id int64, productIDs []int64) (ids []int64, err error)
+	FindProductsFullPagedList(ctx context.Context, collection *domain.Collection, visibleAt *time.Time, sort *[]ProductListSort, queryParams *uctypes.QueryGetListParams) (out []*ProductFullOut, total int64, err error)
+}
+
+//go:generate mockery --name=CollectionRepository --output=../../tests/mocks --case=underscore
+type CollectionRepository interface {
+	FindList(ctx context.Context, listOptions CollectionListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Collection, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.Collection, err error)
+	Create(ctx context.Context, item *domain.Collection) (err error)
+	Update(ctx context.Context, item *domain.Collection) (err error)
+	DeleteByList(ctx context.Context, listOptions CollectionListOptions) (err error)
+}
+
+type CollectionInpl struct {
+	logger              *slog.Logger
+	config              config.Config
+	repo                CollectionRepository
+	txManager           *manager.Manager
+	collectionProductUC CollectionProduct
+	productUC           Product
+}
+
+func NewCollectionInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo CollectionRepository, collectionProductUC CollectionProduct, productUC Product) *CollectionInpl {
+	uc := &CollectionInpl{
+		logger:              logger,
+		config:              config,
+		txManager:           txManager,
+		repo:                repo,
+		collectionProductUC: collectionProductUC,
+		productUC:           productUC,
+	}
+	return uc
+}
+
+func (uc *CollectionInpl) FindList(ctx context.Context, listOptions CollectionListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Collection, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *CollectionInpl) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.Collection, error) {
+	return uc.repo.FindOneByID(ctx, id, queryParams)
+}
+
+func (uc *CollectionInpl) FindOneBySlug(ctx context.Context, slug string) (*domain.Collection, error) {
+	items, err := uc.repo.FindList(ctx, CollectionListOptions{
+		Slug: &slug,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, e.NewErrorFrom(e.ErrNotFound)
+	}
+
+	return items[0], nil
+}
+
+func (uc *CollectionInpl) Create(ctx context.Context, input CollectionIn) (*domain.Collection, error) {
+	collection := domain.NewCollection(0)
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		err := uc.setData(ctx, collection, input)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Create(ctx, collection)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// При смене ручной подборки на подборку по правилам заданные вручную товары удаляются
+func (uc *CollectionInpl) Update(ctx context.Context, id int64, input CollectionIn) (*domain.Collection, error) {
+	var collection *domain.Collection
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		collection, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = uc.setData(ctx, collection, input)
+		if err != nil {
+			return err
+		}
+
+		if collection.Kind != domain.CollectionKindManual {
+			_, err = uc.collectionProductUC.SaveProductsForCollection(ctx, collection.ID, []int64{})
+			if err != nil {
+				return err
+			}
+		}
+
+		return uc.repo.Update(ctx, collection)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (uc *CollectionInpl) setData(ctx context.Context, collection *domain.Collection, input CollectionIn) error {
+	err := collection.SetSlug(input.Slug)
+	if err != nil {
+		return err
+	}
+
+	items, err := uc.repo.FindList(ctx, CollectionListOptions{
+		Slug: &input.Slug,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.ID != collection.ID {
+			return ErrCollectionSlugAlreadyExists
+		}
+	}
+
+	err = collection.SetKindAndRule(input.Kind, input.Rule)
+	if err != nil {
+		return err
+	}
+
+	collection.Name = input.Name
+	collection.Description = input.Description
+	collection.Sort = input.Sort
+	collection.IsPublished = input.IsPublished
+
+	return nil
+}
+
+func (uc *CollectionInpl) Delete(ctx context.Context, id int64) error {
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		collection, err := uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Товары ручной подборки удаляются каскадно
+		return uc.repo.DeleteByList(ctx, CollectionListOptions{
+			IDs: &[]int64{collection.ID},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (uc *CollectionInpl) FindProductIDs(ctx context.Context, id int64) ([]int64, error) {
+	return uc.collectionProductUC.FindProductIDsForCollection(ctx, id)
+}
+
+// Замена товаров ручной подборки, порядок в списке - порядок вывода
+func (uc *CollectionInpl) SaveProducts(ctx context.Context, id int64, productIDs []int64) ([]int64, error) {
+	var result []int64
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		collection, err := uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if collection.Kind != domain.CollectionKindManual {
+			return ErrCollectionNotManual
+		}
+
+		productIDs = lo.Uniq(productIDs)
+
+		if len(productIDs) > 0 {
+			products, err := uc.productUC.FindList(ctx, ProductListOptions{
+				IDs: &productIDs,
+			}, &uctypes.QueryGetListParams{
+				ForShare: true,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(products) != len(productIDs) {
+				return ErrCollectionProductInvalid
+			}
+		}
+
+		items, err := uc.collectionProductUC.SaveProductsForCollection(ctx, collection.ID, productIDs)
+		if err != nil {
+			return err
+		}
+
+		result = lo.Map(items, func(item *domain.CollectionProduct, _ int) int64 {
+			return item.ProductID
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Товары подборки. Ручная подборка выводится в заданном порядке, sort учитывается только для подборки по правилам.
+// Если передан visibleAt, то только товары, опубликованные на этот момент
+func (uc *CollectionInpl) FindProductsFullPagedList(ctx context.Context, collection *domain.Collection, visibleAt *time.Time, sort *[]ProductListSort, queryParams *uctypes.QueryGetListParams) ([]*ProductFullOut, int64, error) {
+	if collection.Kind == domain.CollectionKindRule {
+		rule := collection.Rule()
+
+		return uc.productUC.FindFullPagedList(ctx, ProductListOptions{
+			Tag:         rule.Tag,
+			PriceFrom:   rule.PriceFrom,
+			PriceTo:     rule.PriceTo,
+			CreatedFrom: rule.CreatedAfter,
+			VisibleAt:   visibleAt,
+			Sort:        sort,
+		}, queryParams)
+	}
+
+	ids, err := uc.collectionProductUC.FindProductIDsForCollection(ctx, collection.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(ids) == 0 {
+		return []*ProductFullOut{}, 0, nil
+	}
+
+	// Сначала отбрасываем удаленные и скрытые товары, чтобы страницы и total считались по видимым
+	products, err := uc.productUC.FindList(ctx, ProductListOptions{
+		IDs:       &ids,
+		VisibleAt: visibleAt,
+	}, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	productsMap := lo.SliceToMap(products, func(item *domain.Product) (int64, struct{}) {
+		return item.ID, struct{}{}
+	})
+
+	visibleIDs := lo.Filter(ids, func(id int64, _ int) bool {
+		_, ok := productsMap[id]
+		return ok
+	})
+
+	total := int64(len(visibleIDs))
+
+	if queryParams != nil {
+		pageEnd := len(visibleIDs)
+		if queryParams.Limit > 0 {
+			pageEnd = int(min(queryParams.Offset+queryParams.Limit, uint64(len(visibleIDs))))
+		}
+		visibleIDs = visibleIDs[min(int(queryParams.Offset), pageEnd):pageEnd]
+	}
+
+	out, err := findProductsFullByIDsInOrder(ctx, uc.productUC, visibleIDs, visibleAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return out, total, nil
+}
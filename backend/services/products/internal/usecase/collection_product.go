@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type CollectionProductListOptions struct {
+	CollectionID *int64
+	ProductID    *int64
+}
+
+//go:generate mockery --name=CollectionProduct --output=../../tests/mocks --case=underscore
+type CollectionProduct interface {
+	FindProductIDsForCollection(ctx context.Context, collectionID int64) (ids []int64, err error)
+	SaveProductsForCollection(ctx context.Context, collectionID int64, productIDs []int64) (items []*domain.CollectionProduct, err error)
+}
+
+//go:generate mockery --name=CollectionProductRepository --output=../../tests/mocks --case=underscore
+type CollectionProductRepository interface {
+	FindList(ctx context.Context, listOptions CollectionProductListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.CollectionProduct, err error)
+	Create(ctx context.Context, item *domain.CollectionProduct) (err error)
+	DeleteByList(ctx context.Context, listOptions CollectionProductListOptions) (err error)
+}
+
+type CollectionProductInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      CollectionProductRepository
+	txManager *manager.Manager
+}
+
+func NewCollectionProductInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo CollectionProductRepository) *CollectionProductInpl {
+	uc := &CollectionProductInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+// ID товаров подборки в порядке вывода
+func (uc *CollectionProductInpl) FindProductIDsForCollection(ctx context.Context, collectionID int64) ([]int64, error) {
+	items, err := uc.repo.FindList(ctx, CollectionProductListOptions{
+		CollectionID: &collectionID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(items, func(item *domain.CollectionProduct, _ int) int64 {
+		return item.ProductID
+	}), nil
+}
+
+// Заменяет товары подборки, порядок в списке - порядок вывода
+func (uc *CollectionProductInpl) SaveProductsForCollection(ctx context.Context, collectionID int64, productIDs []int64) ([]*domain.CollectionProduct, error) {
+
+	productIDs = lo.Uniq(productIDs)
+	result := make([]*domain.CollectionProduct, 0, len(productIDs))
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		err := uc.repo.DeleteByList(ctx, CollectionProductListOptions{
+			CollectionID: &collectionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, productID := range productIDs {
+			item := domain.NewCollectionProduct(collectionID, productID, int32(i))
+
+			err = uc.repo.Create(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -54,6 +54,7 @@ type File interface {
 	FindListInMap(ctx context.Context, listOptions FileListOptions, queryParams *uctypes.QueryGetListParams) (items map[uuid.UUID]*domain.File, err error)
 	FindOneByID(ctx context.Context, id uuid.UUID, queryParams *uctypes.QueryGetOneParams) (file *domain.File, err error)
 	MarkAsAssigned(ctx context.Context, ids []uuid.UUID) (err error)
+	MarkAsNotAssigned(ctx context.Context, ids []uuid.UUID) (err error)
 	UploadImageFile(ctx context.Context, target domain.FileTarget, fileName string, fileData []byte) (file *domain.File, err error)
 	DeleteFilesByIDs(ctx context.Context, ids []uuid.UUID) (err error)
 	TaskRemoveFilesFromStorage(ctx context.Context, maxDeletedAt time.Time) (err error)
@@ -167,6 +168,14 @@ func (uc *FileInpl) MarkAsAssigned(ctx context.Context, ids []uuid.UUID) error {
 	}, false)
 }
 
+func (uc *FileInpl) MarkAsNotAssigned(ctx context.Context, ids []uuid.UUID) error {
+	return uc.repo.PartUpdateByList(ctx, FilePartUpdateData{
+		AssignedToTarget: lo.ToPtr(false),
+	}, FileListOptions{
+		IDs: lo.ToPtr(ids),
+	}, false)
+}
+
 func (uc *FileInpl) UploadImageFile(ctx context.Context, target domain.FileTarget, fileName string, fileData []byte) (*domain.File, error) {
 
 	_, ok := ImageSizes[target]
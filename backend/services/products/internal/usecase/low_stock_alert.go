@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/notifier"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type LowStockAlertListOptions struct {
+	ProductID  *int64
+	VariantID  *int64
+	IsNotified *bool
+}
+
+type LowStockAlertCheckIn struct {
+	ProductID int64
+	VariantID int64
+	Name      string
+	SKU       string
+	Quantity  int32
+	Threshold int32
+}
+
+//go:generate mockery --name=LowStockAlert --output=../../tests/mocks --case=underscore
+type LowStockAlert interface {
+	Check(ctx context.Context, input LowStockAlertCheckIn) (err error)
+	TaskSendAlerts(ctx context.Context) (err error)
+}
+
+//go:generate mockery --name=LowStockAlertRepository --output=../../tests/mocks --case=underscore
+type LowStockAlertRepository interface {
+	FindList(ctx context.Context, listOptions LowStockAlertListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.LowStockAlert, err error)
+	CreateIfNotExists(ctx context.Context, item *domain.LowStockAlert) (err error)
+	Update(ctx context.Context, item *domain.LowStockAlert) (err error)
+	DeleteByList(ctx context.Context, listOptions LowStockAlertListOptions) (err error)
+}
+
+type LowStockAlertInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      LowStockAlertRepository
+	txManager *manager.Manager
+	notifier  notifier.Notifier
+}
+
+func NewLowStockAlertInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo LowStockAlertRepository, notifier notifier.Notifier) *LowStockAlertInpl {
+	uc := &LowStockAlertInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+		notifier:  notifier,
+	}
+	return uc
+}
+
+// Вызывается в транзакции изменения остатка. Уведомление ставится в очередь при первом опускании до порога
+// и снимается, когда остаток снова выше порога, поэтому на одно пересечение порога приходится одно уведомление
+func (uc *LowStockAlertInpl) Check(ctx context.Context, input LowStockAlertCheckIn) error {
+	if input.Quantity > input.Threshold {
+		return uc.repo.DeleteByList(ctx, LowStockAlertListOptions{
+			ProductID: &input.ProductID,
+			VariantID: &input.VariantID,
+		})
+	}
+
+	return uc.repo.CreateIfNotExists(ctx, domain.NewLowStockAlert(input.ProductID, input.VariantID, input.Name, input.SKU, input.Quantity, input.Threshold))
+}
+
+// Отправка накопившихся уведомлений. Уведомление считается отправленным, если его принял хотя бы один канал,
+// иначе отправка прерывается и повторяется при следующем запуске
+func (uc *LowStockAlertInpl) TaskSendAlerts(ctx context.Context) error {
+	for {
+		items, err := uc.repo.FindList(ctx, LowStockAlertListOptions{
+			IsNotified: lo.ToPtr(false),
+		}, &uctypes.QueryGetListParams{
+			Limit: 100,
+		})
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "find low stock alerts", slog.Any("error", err))
+			return err
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		for _, item := range items {
+			err = uc.notifier.Notify(ctx, lowStockAlertMessage(item))
+			if err != nil {
+				var partialErr *notifier.PartialError
+				if !errors.As(err, &partialErr) {
+					uc.logger.ErrorContext(ctx, "send low stock alert", slog.Any("error", err), slog.Int64("product_id", item.ProductID), slog.Int64("variant_id", item.VariantID))
+					return err
+				}
+
+				uc.logger.WarnContext(ctx, "low stock alert was not sent to some channels", slog.Any("error", err), slog.Int64("product_id", item.ProductID), slog.Int64("variant_id", item.VariantID))
+			}
+
+			item.MarkNotified()
+
+			err = uc.repo.Update(ctx, item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func lowStockAlertMessage(item *domain.LowStockAlert) notifier.Message {
+	position := fmt.Sprintf("товар #%d", item.ProductID)
+	if item.VariantID > 0 {
+		position = fmt.Sprintf("%s, вариант #%d", position, item.VariantID)
+	}
+
+	text := fmt.Sprintf("%s (%s)", item.Name, position)
+	if item.SKU != "" {
+		text = fmt.Sprintf("%s, артикул %s", text, item.SKU)
+	}
+
+	return notifier.Message{
+		Subject: fmt.Sprintf("Низкий остаток: %s", item.Name),
+		Text:    fmt.Sprintf("%s: остаток %d при пороге %d", text, item.Quantity, item.Threshold),
+	}
+}
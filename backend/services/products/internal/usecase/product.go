@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/google/uuid"
@@ -18,6 +21,16 @@ import (
 
 var ErrProductFileIDInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid file_id")
 var ErrProductAlreadyHasOrders = e.NewErrorFrom(e.ErrBadRequest).SetMessage("product already has orders")
+var ErrProductSKUAlreadyExists = e.NewErrorFrom(e.ErrConflict).SetMessage("product with this sku already exists")
+var ErrProductSlugAlreadyExists = e.NewErrorFrom(e.ErrConflict).SetMessage("product with this slug already exists")
+var ErrProductCategoryIDInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid category_id")
+var ErrProductVariantRequired = e.NewErrorFrom(e.ErrBadRequest).SetMessage("product has variants, variant must be specified")
+var ErrProductVariantInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid variant")
+var ErrProductListCursorNotSupported = e.NewErrorFrom(e.ErrBadRequest).SetMessage("cursor is not supported for this sort")
+var ErrProductListCursorInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid cursor")
+var ErrProductNotDeleted = e.NewErrorFrom(e.ErrConflict).SetMessage("product is not deleted")
+var ErrProductHasStockMovements = e.NewErrorFrom(e.ErrBadRequest).SetMessage("product has stock movements")
+var ErrProductOrderBlockNotFound = e.NewErrorFrom(e.ErrNotFound).SetMessage("order block not found")
 
 type ProductPartUpdateData struct {
 	Name            *string
@@ -32,6 +45,14 @@ type ProductListSortField int
 
 const (
 	ProductListSortFieldCreatedAt ProductListSortField = iota
+	ProductListSortFieldID
+	ProductListSortFieldPrice
+	ProductListSortFieldName
+	// Количество товара в заказах
+	ProductListSortFieldPopularity
+	// Релевантность поисковому запросу, работает только вместе с Query
+	ProductListSortFieldRelevance
+	ProductListSortFieldDeletedAt
 )
 
 type ProductListSort struct {
@@ -42,45 +63,155 @@ type ProductListSort struct {
 type ProductListOptions struct {
 	IDs         *[]int64
 	IsPublished *bool
+	// Опубликованные товары, период публикации которых включает указанный момент
+	VisibleAt *time.Time
+	SKU       *string
+	SKUs      *[]string
+	Slug      *string
+	// Товары категории вместе с товарами всех ее подкатегорий
+	CategoryID *int64
+	// Товары с меткой, метка должна быть нормализована
+	Tag         *string
+	CreatedFrom *time.Time
+	// Полнотекстовый поиск по названию и описанию
+	Query *string
+	// Цена товара или хотя бы одного из его вариантов
+	PriceFrom *decimal.Decimal
+	PriceTo   *decimal.Decimal
+	// Есть остаток у товара или хотя бы у одного из его вариантов
+	InStock *bool
+	// Остаток товара или хотя бы одного варианта не выше порога. Значение - порог для товаров без собственного порога
+	LowStock *int32
+	// Только удаленные (true) или только не удаленные (false) товары, без фильтра учитывается WithDeleted
+	IsDeleted   *bool
+	DeletedAtTo *time.Time
 	Sort        *[]ProductListSort
 }
 
+// Значение поля сортировки для курсора. Выборка по курсору поддерживается только для полей самого товара
+func ProductListCursorValue(item *domain.Product, field ProductListSortField) (string, error) {
+	switch field {
+	case ProductListSortFieldCreatedAt:
+		return item.CreatedAt.UTC().Format(time.RFC3339Nano), nil
+	case ProductListSortFieldID:
+		return strconv.FormatInt(item.ID, 10), nil
+	case ProductListSortFieldPrice:
+		return item.Price.String(), nil
+	case ProductListSortFieldName:
+		return item.Name, nil
+	}
+
+	return "", ErrProductListCursorNotSupported
+}
+
+func ParseProductListCursorValue(field ProductListSortField, value string) (any, error) {
+	var result any
+	var err error
+
+	switch field {
+	case ProductListSortFieldCreatedAt:
+		result, err = time.Parse(time.RFC3339Nano, value)
+	case ProductListSortFieldID:
+		result, err = strconv.ParseInt(value, 10, 64)
+	case ProductListSortFieldPrice:
+		result, err = decimal.NewFromString(value)
+	case ProductListSortFieldName:
+		result = value
+	default:
+		return nil, ErrProductListCursorNotSupported
+	}
+
+	if err != nil {
+		return nil, e.NewErrorFrom(ErrProductListCursorInvalid).Wrap(err)
+	}
+
+	return result, nil
+}
+
 type ProductCreateIn struct {
 	Product        *domain.Product
 	SliderFilesIDs []uuid.UUID
+	CategoryIDs    []int64
+	// Аккаунт, задавший начальный остаток
+	ActorID *uuid.UUID
 }
 
 type ProductUpdateIn struct {
+	// Версия товара, которую видел клиент
+	Version            int64
 	Name               string
+	SKU                string
 	IsPublished        bool
+	PublishAt          *time.Time
+	UnpublishAt        *time.Time
 	FullDescription    string
 	Price              decimal.Decimal
+	TaxRate            *decimal.Decimal
 	ImagePreviewFileID *uuid.UUID
 	SliderFilesIDs     []uuid.UUID
+	CategoryIDs        []int64
+	// Пустой slug не меняется
+	Slug              string
+	LowStockThreshold *int32
+	ActorID           *uuid.UUID
 }
 
 type ProductOneFullOut struct {
 	Product            *domain.Product
 	ProductPreviewFile *domain.File
 	SliderFiles        []*domain.File
+	CategoryIDs        []int64
+	Tags               []string
+	Variants           []*domain.ProductVariant
+	// nil, если одобренных отзывов нет
+	Rating *domain.ProductRating
+}
+
+// Перемещение остатка товара или варианта между складами
+type ProductStockTransferIn struct {
+	ProductID       int64
+	VariantID       int64
+	FromWarehouseID int64
+	ToWarehouseID   int64
+	Quantity        int32
+	ActorID         *uuid.UUID
 }
 
 type ProductFullOut struct {
 	Product            *domain.Product
 	ProductPreviewFile *domain.File
+	Variants           []*domain.ProductVariant
+	// nil, если одобренных отзывов нет
+	Rating *domain.ProductRating
 }
 
 //go:generate mockery --name=Product --output=../../tests/mocks --case=underscore
 type Product interface {
+	FindList(ctx context.Context, listOptions ProductListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Product, err error)
 	FindFullPagedList(ctx context.Context, listOptions ProductListOptions, queryParams *uctypes.QueryGetListParams) (out []*ProductFullOut, total int64, err error)
 	FindFullList(ctx context.Context, listOptions ProductListOptions, queryParams *uctypes.QueryGetListParams) (out []*ProductFullOut, err error)
 	FindOneFullByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (out *ProductOneFullOut, err error)
+	FindOneFullList(ctx context.Context, listOptions ProductListOptions, queryParams *uctypes.QueryGetListParams) (out []*ProductOneFullOut, err error)
+	FindOneFullBySlug(ctx context.Context, slug string) (out *ProductOneFullOut, err error)
 	Create(ctx context.Context, input ProductCreateIn) (product *domain.Product, slider []*domain.ProductSliderImage, err error)
 	Update(ctx context.Context, id int64, input ProductUpdateIn) (product *domain.Product, slider []*domain.ProductSliderImage, err error)
-	ChangeStock(ctx context.Context, id int64, value int32, isIncrease bool) (err error)
+	ChangeStock(ctx context.Context, id int64, warehouseID int64, value int32, isIncrease bool, actorID *uuid.UUID) (err error)
 	Delete(ctx context.Context, id int64) (err error)
-	SetOrderBlock(ctx context.Context, orderID int64, composition []ProductOrderBlockComposition) (err error)
+	Restore(ctx context.Context, id int64) (product *domain.Product, err error)
+	SaveTags(ctx context.Context, id int64, version int64, tags []string) (product *domain.Product, savedTags []string, err error)
+	Purge(ctx context.Context, id int64) (err error)
+	SetOrderBlock(ctx context.Context, orderID int64, orderStatus string, deliveryZone string, composition []ProductOrderBlockComposition) (err error)
+	ExtendOrderBlock(ctx context.Context, orderID int64, orderStatus string) (expiresAt *time.Time, err error)
+	TaskReleaseExpiredOrderBlocks(ctx context.Context) (err error)
 	ApplyOrderBlock(ctx context.Context, orderID int64) (err error)
+	SetReturnRestock(ctx context.Context, returnID int64, composition []ProductReturnRestockComposition) (err error)
+	CreateVariant(ctx context.Context, productID int64, input ProductVariantIn) (variant *domain.ProductVariant, err error)
+	UpdateVariant(ctx context.Context, productID int64, variantID int64, input ProductVariantIn) (variant *domain.ProductVariant, err error)
+	ChangeVariantStock(ctx context.Context, productID int64, variantID int64, warehouseID int64, value int32, isIncrease bool, actorID *uuid.UUID) (err error)
+	TransferStock(ctx context.Context, input ProductStockTransferIn) (err error)
+	DeleteVariant(ctx context.Context, productID int64, variantID int64) (err error)
+	ApplyPriceSchedule(ctx context.Context, schedule *domain.ProductPriceSchedule) (err error)
+	RevertPriceSchedule(ctx context.Context, schedule *domain.ProductPriceSchedule) (err error)
 }
 
 //go:generate mockery --name=ProductRepository --output=../../tests/mocks --case=underscore
@@ -93,33 +224,65 @@ type ProductRepository interface {
 	PartUpdateByList(ctx context.Context, updateData ProductPartUpdateData, listOptions ProductListOptions, withDeleted bool) (err error)
 	PartUpdateByID(ctx context.Context, updateData ProductPartUpdateData, id int64) (err error)
 	DeleteByList(ctx context.Context, listOptions ProductListOptions) (err error)
+	Restore(ctx context.Context, id int64) (err error)
+	Purge(ctx context.Context, id int64) (err error)
 }
 
 type ProductInpl struct {
-	logger               *slog.Logger
-	config               config.Config
-	repo                 ProductRepository
-	txManager            *manager.Manager
-	fileUC               File
-	productSliderImageUC ProductSliderImage
-	productOrderBlockUC  ProductOrderBlock
-	ordersGCL            *ordersgcl.ClientConn
+	logger                 *slog.Logger
+	config                 config.Config
+	repo                   ProductRepository
+	txManager              *manager.Manager
+	fileUC                 File
+	productSliderImageUC   ProductSliderImage
+	productOrderBlockUC    ProductOrderBlock
+	productReturnRestockUC ProductReturnRestock
+	productConsumptionUC   ProductOrderConsumption
+	categoryUC             Category
+	productCategoryUC      ProductCategory
+	productVariantUC       ProductVariant
+	productSlugHistoryUC   ProductSlugHistory
+	stockMovementUC        StockMovement
+	lowStockAlertUC        LowStockAlert
+	productReviewUC        ProductReview
+	productPriceHistoryUC  ProductPriceHistory
+	warehouseUC            Warehouse
+	warehouseStockUC       WarehouseStock
+	productTagUC           ProductTag
+	ordersGCl              *ordersgcl.ClientConn
 }
 
-func NewProductInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductRepository, filesUC File, productSliderImageUC ProductSliderImage, productOrderBlockUC ProductOrderBlock, ordersGCL *ordersgcl.ClientConn) *ProductInpl {
+func NewProductInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductRepository, filesUC File, productSliderImageUC ProductSliderImage, productOrderBlockUC ProductOrderBlock, productReturnRestockUC ProductReturnRestock, productConsumptionUC ProductOrderConsumption, categoryUC Category, productCategoryUC ProductCategory, productVariantUC ProductVariant, productSlugHistoryUC ProductSlugHistory, stockMovementUC StockMovement, lowStockAlertUC LowStockAlert, productReviewUC ProductReview, productPriceHistoryUC ProductPriceHistory, warehouseUC Warehouse, warehouseStockUC WarehouseStock, productTagUC ProductTag, ordersGCl *ordersgcl.ClientConn) *ProductInpl {
 	uc := &ProductInpl{
-		logger:               logger,
-		config:               config,
-		txManager:            txManager,
-		repo:                 repo,
-		fileUC:               filesUC,
-		productSliderImageUC: productSliderImageUC,
-		productOrderBlockUC:  productOrderBlockUC,
-		ordersGCL:            ordersGCL,
+		logger:                 logger,
+		config:                 config,
+		txManager:              txManager,
+		repo:                   repo,
+		fileUC:                 filesUC,
+		productSliderImageUC:   productSliderImageUC,
+		productOrderBlockUC:    productOrderBlockUC,
+		productReturnRestockUC: productReturnRestockUC,
+		productConsumptionUC:   productConsumptionUC,
+		categoryUC:             categoryUC,
+		productCategoryUC:      productCategoryUC,
+		productVariantUC:       productVariantUC,
+		productSlugHistoryUC:   productSlugHistoryUC,
+		stockMovementUC:        stockMovementUC,
+		lowStockAlertUC:        lowStockAlertUC,
+		productReviewUC:        productReviewUC,
+		productPriceHistoryUC:  productPriceHistoryUC,
+		warehouseUC:            warehouseUC,
+		warehouseStockUC:       warehouseStockUC,
+		productTagUC:           productTagUC,
+		ordersGCl:              ordersGCl,
 	}
 	return uc
 }
 
+func (uc *ProductInpl) FindList(ctx context.Context, listOptions ProductListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Product, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
 func (uc *ProductInpl) FindFullPagedList(ctx context.Context, listOptions ProductListOptions, queryParams *uctypes.QueryGetListParams) ([]*ProductFullOut, int64, error) {
 
 	list, total, err := uc.repo.FindPagedList(ctx, listOptions, queryParams)
@@ -141,10 +304,26 @@ func (uc *ProductInpl) FindFullPagedList(ctx context.Context, listOptions Produc
 		return nil, 0, err
 	}
 
+	productIDs := lo.Map(list, func(item *domain.Product, _ int) int64 {
+		return item.ID
+	})
+
+	variants, err := uc.productVariantUC.FindListByProducts(ctx, productIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ratings, err := uc.productReviewUC.FindRatingsInMap(ctx, productIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	result := make([]*ProductFullOut, len(list))
 	for i, item := range list {
 		result[i] = &ProductFullOut{
-			Product: item,
+			Product:  item,
+			Variants: variants[item.ID],
+			Rating:   ratings[item.ID],
 		}
 
 		if item.ImagePreviewFileID != nil {
@@ -179,10 +358,26 @@ func (uc *ProductInpl) FindFullList(ctx context.Context, listOptions ProductList
 		return nil, err
 	}
 
+	productIDs := lo.Map(list, func(item *domain.Product, _ int) int64 {
+		return item.ID
+	})
+
+	variants, err := uc.productVariantUC.FindListByProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := uc.productReviewUC.FindRatingsInMap(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]*ProductFullOut, len(list))
 	for i, item := range list {
 		result[i] = &ProductFullOut{
-			Product: item,
+			Product:  item,
+			Variants: variants[item.ID],
+			Rating:   ratings[item.ID],
 		}
 
 		if item.ImagePreviewFileID != nil {
@@ -207,6 +402,23 @@ func (uc *ProductInpl) FindOneFullByID(ctx context.Context, id int64, queryParam
 		return nil, err
 	}
 
+	categoryIDs, err := uc.productCategoryUC.FindCategoryIDsForProduct(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := uc.productTagUC.FindTagsForProduct(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := uc.productVariantUC.FindList(ctx, ProductVariantListOptions{
+		ProductID: &product.ID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	filesIDsCap := len(slider)
 	if product.ImagePreviewFileID != nil {
 		filesIDsCap++
@@ -226,9 +438,18 @@ func (uc *ProductInpl) FindOneFullByID(ctx context.Context, id int64, queryParam
 		return nil, err
 	}
 
+	ratings, err := uc.productReviewUC.FindRatingsInMap(ctx, []int64{product.ID})
+	if err != nil {
+		return nil, err
+	}
+
 	out := &ProductOneFullOut{
 		Product:     product,
 		SliderFiles: make([]*domain.File, 0, len(slider)),
+		CategoryIDs: categoryIDs,
+		Tags:        tags,
+		Variants:    variants,
+		Rating:      ratings[product.ID],
 	}
 
 	if product.ImagePreviewFileID != nil {
@@ -248,12 +469,126 @@ func (uc *ProductInpl) FindOneFullByID(ctx context.Context, id int64, queryParam
 	return out, nil
 }
 
+// Полные данные нескольких товаров, связанные данные читаются одним запросом на все товары
+func (uc *ProductInpl) FindOneFullList(ctx context.Context, listOptions ProductListOptions, queryParams *uctypes.QueryGetListParams) ([]*ProductOneFullOut, error) {
+	list, err := uc.repo.FindList(ctx, listOptions, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return []*ProductOneFullOut{}, nil
+	}
+
+	productIDs := lo.Map(list, func(item *domain.Product, _ int) int64 {
+		return item.ID
+	})
+
+	sliders, err := uc.productSliderImageUC.FindSliderImagesForProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs, err := uc.productCategoryUC.FindCategoryIDsForProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := uc.productTagUC.FindTagsForProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := uc.productVariantUC.FindListByProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	filesIDs := make([]uuid.UUID, 0, len(list))
+	for _, product := range list {
+		if product.ImagePreviewFileID != nil {
+			filesIDs = append(filesIDs, *product.ImagePreviewFileID)
+		}
+		for _, item := range sliders[product.ID] {
+			filesIDs = append(filesIDs, item.FileID)
+		}
+	}
+
+	files, err := uc.fileUC.FindListInMap(ctx, FileListOptions{
+		IDs: &filesIDs,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := uc.productReviewUC.FindRatingsInMap(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*ProductOneFullOut, len(list))
+	for i, product := range list {
+		out := &ProductOneFullOut{
+			Product:     product,
+			SliderFiles: make([]*domain.File, 0, len(sliders[product.ID])),
+			CategoryIDs: lo.Ternary(categoryIDs[product.ID] != nil, categoryIDs[product.ID], []int64{}),
+			Tags:        lo.Ternary(tags[product.ID] != nil, tags[product.ID], []string{}),
+			Variants:    lo.Ternary(variants[product.ID] != nil, variants[product.ID], []*domain.ProductVariant{}),
+			Rating:      ratings[product.ID],
+		}
+
+		if product.ImagePreviewFileID != nil {
+			file, ok := files[*product.ImagePreviewFileID]
+			if ok {
+				out.ProductPreviewFile = file
+			}
+		}
+
+		for _, item := range sliders[product.ID] {
+			file, ok := files[item.FileID]
+			if ok {
+				out.SliderFiles = append(out.SliderFiles, file)
+			}
+		}
+
+		result[i] = out
+	}
+
+	return result, nil
+}
+
+// Товар ищется по актуальному slug, затем по прежним. Если найден по прежнему, у результата будет другой slug
+func (uc *ProductInpl) FindOneFullBySlug(ctx context.Context, slug string) (*ProductOneFullOut, error) {
+	items, err := uc.repo.FindList(ctx, ProductListOptions{
+		Slug: &slug,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if product, ok := lo.First(items); ok {
+		return uc.FindOneFullByID(ctx, product.ID, nil)
+	}
+
+	productID, isFound, err := uc.productSlugHistoryUC.FindProductIDBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isFound {
+		return nil, e.ErrNotFound
+	}
+
+	return uc.FindOneFullByID(ctx, productID, nil)
+}
+
 func (uc *ProductInpl) Create(ctx context.Context, input ProductCreateIn) (*domain.Product, []*domain.ProductSliderImage, error) {
 
 	var resultSlider = make([]*domain.ProductSliderImage, 0)
 	var err error
 
 	input.SliderFilesIDs = lo.Uniq(input.SliderFilesIDs)
+	input.CategoryIDs = lo.Uniq(input.CategoryIDs)
 
 	filesIDs := make([]uuid.UUID, len(input.SliderFilesIDs))
 	copy(filesIDs, input.SliderFilesIDs)
@@ -302,11 +637,44 @@ func (uc *ProductInpl) Create(ctx context.Context, input ProductCreateIn) (*doma
 			}
 		}
 
+		err = uc.checkSKUIsFree(ctx, input.Product.SKU, input.Product.ID, 0)
+		if err != nil {
+			return err
+		}
+
+		slug, err := uc.prepareSlug(ctx, input.Product.Slug, input.Product.Name, 0)
+		if err != nil {
+			return err
+		}
+
+		err = input.Product.SetSlug(slug)
+		if err != nil {
+			return err
+		}
+
+		err = uc.checkCategoriesExist(ctx, input.CategoryIDs)
+		if err != nil {
+			return err
+		}
+
 		err = uc.repo.Create(ctx, input.Product)
 		if err != nil {
 			return err
 		}
 
+		err = uc.addInitialStockMovement(ctx, productStockKey{ProductID: input.Product.ID}, input.Product.StockAvailable, input.ActorID)
+		if err != nil {
+			return err
+		}
+
+		err = uc.addPriceHistory(ctx, input.Product, nil, priceChangeSource{
+			Reason:  domain.ProductPriceChangeReasonInitial,
+			ActorID: input.ActorID,
+		})
+		if err != nil {
+			return err
+		}
+
 		err = uc.fileUC.MarkAsAssigned(ctx, filesIDs)
 		if err != nil {
 			return err
@@ -317,6 +685,11 @@ func (uc *ProductInpl) Create(ctx context.Context, input ProductCreateIn) (*doma
 			return err
 		}
 
+		_, err = uc.productCategoryUC.SaveActualCategoriesForProduct(ctx, input.Product.ID, input.CategoryIDs)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -333,6 +706,7 @@ func (uc *ProductInpl) Update(ctx context.Context, id int64, input ProductUpdate
 	var err error
 
 	input.SliderFilesIDs = lo.Uniq(input.SliderFilesIDs)
+	input.CategoryIDs = lo.Uniq(input.CategoryIDs)
 
 	newFilesIDs := make([]uuid.UUID, len(input.SliderFilesIDs))
 	copy(newFilesIDs, input.SliderFilesIDs)
@@ -349,6 +723,11 @@ func (uc *ProductInpl) Update(ctx context.Context, id int64, input ProductUpdate
 			return err
 		}
 
+		err = product.CheckVersion(input.Version)
+		if err != nil {
+			return err
+		}
+
 		curSliderImages, err := uc.productSliderImageUC.FindSliderImagesForProduct(ctx, product.ID)
 		if err != nil {
 			return err
@@ -403,20 +782,67 @@ func (uc *ProductInpl) Update(ctx context.Context, id int64, input ProductUpdate
 			}
 		}
 
+		if input.SKU != product.SKU {
+			err = uc.checkSKUIsFree(ctx, input.SKU, product.ID, 0)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = uc.checkCategoriesExist(ctx, input.CategoryIDs)
+		if err != nil {
+			return err
+		}
+
+		if input.Slug != "" && input.Slug != product.Slug {
+			err = uc.changeSlug(ctx, product, input.Slug)
+			if err != nil {
+				return err
+			}
+		}
+
 		product.Name = input.Name
+		product.SKU = input.SKU
 		product.FullDescription = input.FullDescription
 		product.IsPublished = input.IsPublished
 		product.ImagePreviewFileID = input.ImagePreviewFileID
+		product.IncVersion()
+		oldPrice := product.Price
 		err = product.SetPrice(input.Price)
 		if err != nil {
 			return err
 		}
 
+		err = product.SetTaxRate(input.TaxRate)
+		if err != nil {
+			return err
+		}
+
+		err = product.SetLowStockThreshold(input.LowStockThreshold)
+		if err != nil {
+			return err
+		}
+
+		err = product.SetPublishPeriod(input.PublishAt, input.UnpublishAt)
+		if err != nil {
+			return err
+		}
+
 		err = uc.repo.Update(ctx, product)
 		if err != nil {
 			return err
 		}
 
+		if !oldPrice.Equal(product.Price) {
+			err = uc.addPriceHistory(ctx, product, &oldPrice, priceChangeSource{
+				Reason:  domain.ProductPriceChangeReasonManual,
+				ActorID: input.ActorID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		err = uc.fileUC.MarkAsAssigned(ctx, newFilesIDs)
 		if err != nil {
 			return err
@@ -427,6 +853,11 @@ func (uc *ProductInpl) Update(ctx context.Context, id int64, input ProductUpdate
 			return err
 		}
 
+		_, err = uc.productCategoryUC.SaveActualCategoriesForProduct(ctx, product.ID, input.CategoryIDs)
+		if err != nil {
+			return err
+		}
+
 		if toDeleteOldPreview != nil {
 			err = uc.fileUC.DeleteFilesByIDs(ctx, []uuid.UUID{*toDeleteOldPreview})
 			if err != nil {
@@ -443,96 +874,231 @@ func (uc *ProductInpl) Update(ctx context.Context, id int64, input ProductUpdate
 	return product, resultSlider, nil
 }
 
-func (uc *ProductInpl) ChangeStock(ctx context.Context, id int64, value int32, isIncrease bool) error {
+// Пустой slug генерируется из названия, при совпадении с занятым добавляется числовой суффикс
+func (uc *ProductInpl) prepareSlug(ctx context.Context, slug string, name string, productID int64) (string, error) {
+	if slug != "" {
+		isFree, err := uc.isSlugFree(ctx, slug, productID)
+		if err != nil {
+			return "", err
+		}
 
-	var product *domain.Product
-	var err error
+		if !isFree {
+			return "", ErrProductSlugAlreadyExists
+		}
 
-	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		return slug, nil
+	}
 
-		product, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
-			ForUpdate: true,
-		})
+	base := domain.GenerateSlug(name)
+	if base == "" {
+		base = "product"
+	}
+
+	for i := 1; i <= 100; i++ {
+		candidate := base
+		if i > 1 {
+			suffix := fmt.Sprintf("-%d", i)
+			candidate = strings.Trim(lo.Substring(base, 0, uint(domain.SlugMaxLength-len(suffix))), "-") + suffix
+		}
+
+		isFree, err := uc.isSlugFree(ctx, candidate, productID)
 		if err != nil {
-			return err
+			return "", err
 		}
 
-		if isIncrease {
-			err = product.IncreaseStock(int64(value))
-			if err != nil {
-				return err
-			}
-		} else {
-			err = product.DecreaseStock(int64(value))
-			if err != nil {
-				return err
-			}
+		if isFree {
+			return candidate, nil
 		}
+	}
 
-		err = uc.repo.Update(ctx, product)
-		if err != nil {
-			return err
+	return "", ErrProductSlugAlreadyExists
+}
+
+// Slug занят, если он есть у другого товара сейчас или был раньше - старые ссылки должны вести на прежний товар
+func (uc *ProductInpl) isSlugFree(ctx context.Context, slug string, productID int64) (bool, error) {
+	items, err := uc.repo.FindList(ctx, ProductListOptions{
+		Slug: &slug,
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if item.ID != productID {
+			return false, nil
 		}
+	}
 
-		return nil
-	})
+	historyProductID, isFound, err := uc.productSlugHistoryUC.FindProductIDBySlug(ctx, slug)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	if isFound && historyProductID != productID {
+		return false, nil
+	}
+
+	return true, nil
 }
 
-func (uc *ProductInpl) Delete(ctx context.Context, id int64) error {
+// Прежний slug сохраняется в истории, новый убирается из нее, если товар возвращается к старому адресу
+func (uc *ProductInpl) changeSlug(ctx context.Context, product *domain.Product, slug string) error {
+	oldSlug := product.Slug
 
-	// 1) до транзакции проверим товар
-	_, err := uc.repo.FindOneByID(ctx, id, nil)
+	err := product.SetSlug(slug)
 	if err != nil {
 		return err
 	}
 
-	// 2) проверка наличия заказов по товару, чтобы зря не создавать транзакцию и не блокировать товар, если он уже ранее был заказан
-	exists, err := uc.ordersGCL.Client.CheckOrdersExistsByProductID(ctx, id)
+	isFree, err := uc.isSlugFree(ctx, slug, product.ID)
 	if err != nil {
 		return err
 	}
 
-	if exists {
-		return ErrProductAlreadyHasOrders
+	if !isFree {
+		return ErrProductSlugAlreadyExists
 	}
 
-	// 3) создаем транзакцию
-	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+	err = uc.productSlugHistoryUC.DeleteBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
 
-		// 4) блокируем товар внутри транзакции
-		product, err := uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
-			ForUpdate: true,
-		})
-		if err != nil {
-			return err
-		}
+	if oldSlug == "" {
+		return nil
+	}
 
-		// 5) проверяем наличие блокировок на товар
-		blockIsExists, err := uc.productOrderBlockUC.CheckBlockForProduct(ctx, product.ID)
-		if err != nil {
-			return err
-		}
+	return uc.productSlugHistoryUC.Add(ctx, oldSlug, product.ID)
+}
 
-		if blockIsExists {
-			return ErrProductAlreadyHasOrders
+// Артикул должен быть уникальным среди не удаленных товаров и их вариантов, пустой артикул не проверяется.
+// Для товара variantID равен 0, для варианта productID равен 0
+func (uc *ProductInpl) checkSKUIsFree(ctx context.Context, sku string, productID int64, variantID int64) error {
+	if sku == "" {
+		return nil
+	}
+
+	items, err := uc.repo.FindList(ctx, ProductListOptions{
+		SKU: &sku,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.ID != productID {
+			return ErrProductSKUAlreadyExists
+		}
+	}
+
+	variants, err := uc.productVariantUC.FindList(ctx, ProductVariantListOptions{
+		SKU: &sku,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range variants {
+		if item.ID != variantID {
+			return ErrProductSKUAlreadyExists
+		}
+	}
+
+	return nil
+}
+
+func (uc *ProductInpl) checkCategoriesExist(ctx context.Context, categoryIDs []int64) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	categories, err := uc.categoryUC.FindList(ctx, CategoryListOptions{
+		IDs: &categoryIDs,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, categoryID := range categoryIDs {
+		_, ok := lo.Find(categories, func(item *domain.Category) bool {
+			return item.ID == categoryID
+		})
+		if !ok {
+			return e.NewErrorFrom(ErrProductCategoryIDInvalid).SetMessage(fmt.Sprintf("incorrect category_id: %d", categoryID))
+		}
+	}
+
+	return nil
+}
+
+// warehouseID = 0 - склад по умолчанию
+func (uc *ProductInpl) ChangeStock(ctx context.Context, id int64, warehouseID int64, value int32, isIncrease bool, actorID *uuid.UUID) error {
+
+	var product *domain.Product
+	var err error
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+
+		product, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		warehouse, err := uc.findWarehouseForAdjust(ctx, warehouseID, isIncrease)
+		if err != nil {
+			return err
+		}
+
+		err = uc.changeHolderStock(ctx, productStockKey{ProductID: product.ID}, product, stockDelta(value, isIncrease), stockChangeSource{
+			Reason:      domain.StockMovementReasonManualAdjust,
+			WarehouseID: warehouse.ID,
+			ActorID:     actorID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (uc *ProductInpl) Delete(ctx context.Context, id int64) error {
+
+	// 1) до транзакции проверим товар
+	_, err := uc.repo.FindOneByID(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	// 2) создаем транзакцию
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+
+		// 3) блокируем товар внутри транзакции
+		product, err := uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
 		}
 
-		// 6) Повторная проверка наличия заказов по товару
-		exists, err := uc.ordersGCL.Client.CheckOrdersExistsByProductID(ctx, id)
+		// 4) проверяем наличие блокировок на товар, оформленные заказы хранят снимок товара и удалению не мешают
+		blockIsExists, err := uc.productOrderBlockUC.CheckBlockForProduct(ctx, product.ID)
 		if err != nil {
 			return err
 		}
 
-		if exists {
+		if blockIsExists {
 			return ErrProductAlreadyHasOrders
 		}
 
-		// 7) удаляем товар
+		// 5) удаляем товар вместе с вариантами
 		err = uc.repo.DeleteByList(ctx, ProductListOptions{
 			IDs: lo.ToPtr([]int64{product.ID}),
 		})
@@ -540,6 +1106,11 @@ func (uc *ProductInpl) Delete(ctx context.Context, id int64) error {
 			return err
 		}
 
+		err = uc.productVariantUC.DeleteByProductID(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -549,126 +1120,1139 @@ func (uc *ProductInpl) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (uc *ProductInpl) SetOrderBlock(ctx context.Context, orderID int64, composition []ProductOrderBlockComposition) error {
+// Замена меток товара. Метки входят в карточку товара, поэтому версия увеличивается
+func (uc *ProductInpl) SaveTags(ctx context.Context, id int64, version int64, tags []string) (*domain.Product, []string, error) {
+	var product *domain.Product
+	var items []*domain.ProductTag
 
-	compositionProductsIDs := make([]int64, 0, len(composition))
-	for _, item := range composition {
-		compositionProductsIDs = append(compositionProductsIDs, item.ProductID)
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		product, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(version)
+		if err != nil {
+			return err
+		}
+
+		items, err = uc.productTagUC.SaveTagsForProduct(ctx, product.ID, tags)
+		if err != nil {
+			return err
+		}
+
+		product.IncVersion()
+
+		return uc.repo.Update(ctx, product)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	compositionProductsIDs = lo.Uniq(compositionProductsIDs)
+
+	return product, lo.Map(items, func(item *domain.ProductTag, _ int) string {
+		return item.Tag
+	}), nil
+}
+
+// Восстановление удаленного товара. Slug и артикул за время удаления могли занять другие товары
+func (uc *ProductInpl) Restore(ctx context.Context, id int64) (*domain.Product, error) {
+	var product *domain.Product
 
 	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
-		curOrderBlocks, err := uc.productOrderBlockUC.FindList(ctx, ProductOrderBlockListOptions{
-			OrderID: &orderID,
-		}, &uctypes.QueryGetListParams{
-			ForUpdate: true,
+		var err error
+
+		product, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate:   true,
+			WithDeleted: true,
 		})
 		if err != nil {
 			return err
 		}
 
-		//Возвращаем остаток от текущей брони если есть
-		if len(curOrderBlocks) > 0 {
-			curOrderProductsIDs := make([]int64, 0, len(curOrderBlocks))
-			for _, block := range curOrderBlocks {
-				curOrderProductsIDs = append(curOrderProductsIDs, block.ProductID)
-			}
+		if product.DeletedAt == nil {
+			return ErrProductNotDeleted
+		}
 
-			curOrderProducts, err := uc.repo.FindList(ctx, ProductListOptions{
-				IDs: lo.ToPtr(curOrderProductsIDs),
-			}, &uctypes.QueryGetListParams{
-				ForUpdate: true,
-			})
-			if err != nil {
-				return err
-			}
+		isFree, err := uc.isSlugFree(ctx, product.Slug, product.ID)
+		if err != nil {
+			return err
+		}
 
-			for i, product := range curOrderProducts {
-				blockForProduct, ok := lo.Find(curOrderBlocks, func(item *domain.ProductOrderBlock) bool {
-					return item.ProductID == product.ID
-				})
+		if !isFree {
+			return ErrProductSlugAlreadyExists
+		}
 
-				var curBlockQuantity int32
+		err = uc.checkSKUIsFree(ctx, product.SKU, product.ID, 0)
+		if err != nil {
+			return err
+		}
 
-				if ok {
-					curBlockQuantity = blockForProduct.Quantity
-				}
+		err = uc.repo.Restore(ctx, product.ID)
+		if err != nil {
+			return err
+		}
 
-				err = curOrderProducts[i].SetStockAvailable(product.StockAvailable + curBlockQuantity)
-				if err != nil {
-					return err
-				}
+		err = uc.productVariantUC.RestoreByProductID(ctx, product.ID, *product.DeletedAt)
+		if err != nil {
+			return err
+		}
 
-				err = uc.repo.Update(ctx, curOrderProducts[i])
-				if err != nil {
-					return err
-				}
-			}
+		product.DeletedAt = nil
 
-			err = uc.productOrderBlockUC.ClearBlocksForOrder(ctx, orderID)
-			if err != nil {
-				return err
-			}
+		// Клиент с данными товара до удаления не должен перезаписать восстановленный товар
+		product.IncVersion()
+
+		return uc.repo.Update(ctx, product)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// Окончательное удаление товара без заказов, его изображения помечаются к удалению из хранилища
+func (uc *ProductInpl) Purge(ctx context.Context, id int64) error {
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		product, err := uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate:   true,
+			WithDeleted: true,
+		})
+		if err != nil {
+			return err
 		}
 
-		//Создаем новую бронь, если она не пустая
-		if len(compositionProductsIDs) > 0 {
+		if product.DeletedAt == nil {
+			return ErrProductNotDeleted
+		}
 
-			compositionProducts, err := uc.repo.FindList(ctx, ProductListOptions{
-				IDs: lo.ToPtr(compositionProductsIDs),
-			}, &uctypes.QueryGetListParams{
-				ForUpdate: true,
-			})
-			if err != nil {
-				return err
-			}
+		blockIsExists, err := uc.productOrderBlockUC.CheckBlockForProduct(ctx, product.ID)
+		if err != nil {
+			return err
+		}
 
-			if len(compositionProducts) != len(compositionProductsIDs) {
-				return e.NewErrorFrom(e.ErrBadRequest).SetMessage("products not found")
-			}
+		if blockIsExists {
+			return ErrProductAlreadyHasOrders
+		}
 
-			for i, product := range compositionProducts {
-				compositionProduct, ok := lo.Find(composition, func(item ProductOrderBlockComposition) bool {
-					return item.ProductID == product.ID
-				})
+		restocks, err := uc.productReturnRestockUC.FindList(ctx, ProductReturnRestockListOptions{
+			ProductID: &product.ID,
+		}, &uctypes.QueryGetListParams{
+			Limit: 1,
+		})
+		if err != nil {
+			return err
+		}
 
-				var compositionProductBlockQuantity int32
+		if len(restocks) > 0 {
+			return ErrProductAlreadyHasOrders
+		}
 
-				if ok {
-					compositionProductBlockQuantity = compositionProduct.Quantity
-				}
+		isConsumed, err := uc.productConsumptionUC.CheckConsumptionForProduct(ctx, product.ID)
+		if err != nil {
+			return err
+		}
 
-				err = compositionProducts[i].SetStockAvailable(product.StockAvailable - compositionProductBlockQuantity)
-				if err != nil {
-					return err
-				}
+		if isConsumed {
+			return ErrProductAlreadyHasOrders
+		}
 
-				err = uc.repo.Update(ctx, compositionProducts[i])
-				if err != nil {
-					return err
-				}
+		// Журнал движения остатков хранится для учета, товар с записями журнала не удаляем
+		movements, _, err := uc.stockMovementUC.FindPagedList(ctx, StockMovementListOptions{
+			ProductID: &product.ID,
+		}, &uctypes.QueryGetListParams{
+			Limit: 1,
+		})
+		if err != nil {
+			return err
+		}
 
-				block, err := domain.NewProductOrderBlock(product.ID, orderID, compositionProductBlockQuantity)
-				if err != nil {
-					return err
-				}
+		if len(movements) > 0 {
+			return ErrProductHasStockMovements
+		}
 
-				err = uc.productOrderBlockUC.Create(ctx, block)
-				if err != nil {
-					return err
-				}
+		err = uc.productSliderImageUC.DeleteImagesForProductSlider(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+
+		if product.ImagePreviewFileID != nil {
+			err = uc.fileUC.DeleteFilesByIDs(ctx, []uuid.UUID{*product.ImagePreviewFileID})
+			if err != nil {
+				return err
 			}
 		}
 
+		err = uc.productVariantUC.PurgeByProductID(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Purge(ctx, product.ID)
+	})
+}
+
+// Срок брони зависит от статуса заказа, пустой статус - срок по умолчанию
+func (uc *ProductInpl) orderBlockExpiresAt(orderStatus string) *time.Time {
+	ttl, ok := uc.config.OrderBlock.TTLMinutesByStatus[orderStatus]
+	if !ok {
+		ttl = uc.config.OrderBlock.DefaultTTLMinutes
+	}
+
+	if ttl <= 0 {
+		return nil
+	}
+
+	return lo.ToPtr(time.Now().Add(time.Duration(ttl) * time.Minute))
+}
+
+// Возврат остатка по текущей брони заказа и ее удаление
+func (uc *ProductInpl) releaseOrderBlocks(ctx context.Context, orderID int64, blocks []*domain.ProductOrderBlock, reason domain.StockMovementReason) error {
+	if len(blocks) == 0 {
 		return nil
+	}
+
+	keys := lo.Map(blocks, func(item *domain.ProductOrderBlock, _ int) productStockKey {
+		return productStockKey{ProductID: item.ProductID, VariantID: item.VariantID}
 	})
+
+	holders, err := uc.lockStockHolders(ctx, keys, false)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	for _, block := range blocks {
+		key := productStockKey{ProductID: block.ProductID, VariantID: block.VariantID}
+		holder, ok := holders[key]
+		if !ok {
+			continue
+		}
+
+		err = uc.changeHolderStock(ctx, key, holder, int64(block.Quantity), stockChangeSource{
+			Reason:      reason,
+			OrderID:     &orderID,
+			WarehouseID: block.WarehouseID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return uc.productOrderBlockUC.ClearBlocksForOrder(ctx, orderID)
 }
 
-func (uc *ProductInpl) ApplyOrderBlock(ctx context.Context, orderID int64) error {
+// Пустой статус заказа сохраняет срок текущей брони, например при изменении состава заказа.
+// Зона доставки учитывается при выборе склада, если это предусматривает стратегия
+func (uc *ProductInpl) SetOrderBlock(ctx context.Context, orderID int64, orderStatus string, deliveryZone string, composition []ProductOrderBlockComposition) error {
+
+	// Одинаковые позиции суммируем
+	quantities := make(map[productStockKey]int32, len(composition))
+	for _, item := range composition {
+		quantities[productStockKey{ProductID: item.ProductID, VariantID: item.VariantID}] += item.Quantity
+	}
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		curOrderBlocks, err := uc.productOrderBlockUC.FindList(ctx, ProductOrderBlockListOptions{
+			OrderID: &orderID,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		var expiresAt *time.Time
+		if orderStatus == "" && len(curOrderBlocks) > 0 {
+			expiresAt = curOrderBlocks[0].ExpiresAt
+		} else {
+			expiresAt = uc.orderBlockExpiresAt(orderStatus)
+		}
+
+		//Возвращаем остаток от текущей брони если есть
+		err = uc.releaseOrderBlocks(ctx, orderID, curOrderBlocks, domain.StockMovementReasonOrderRelease)
+		if err != nil {
+			return err
+		}
+
+		//Создаем новую бронь, если она не пустая
+		if len(quantities) > 0 {
+
+			holders, err := uc.lockStockHolders(ctx, lo.Keys(quantities), true)
+			if err != nil {
+				return err
+			}
+
+			warehouses, err := uc.warehouseUC.FindForReservation(ctx, deliveryZone)
+			if err != nil {
+				return err
+			}
+
+			for key, quantity := range quantities {
+				allocation, err := uc.allocateWarehouseStock(ctx, key, quantity, warehouses)
+				if err != nil {
+					return err
+				}
+
+				for _, part := range allocation {
+					err = uc.changeHolderStock(ctx, key, holders[key], -int64(part.Quantity), stockChangeSource{
+						Reason:      domain.StockMovementReasonOrderReserve,
+						OrderID:     &orderID,
+						WarehouseID: part.WarehouseID,
+					})
+					if err != nil {
+						return err
+					}
+
+					block, err := domain.NewProductOrderBlock(key.ProductID, key.VariantID, orderID, part.WarehouseID, part.Quantity)
+					if err != nil {
+						return err
+					}
+					block.ExpiresAt = expiresAt
+
+					err = uc.productOrderBlockUC.Create(ctx, block)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Продление брони, когда заказ законно обрабатывается дольше, например ожидает оплату
+func (uc *ProductInpl) ExtendOrderBlock(ctx context.Context, orderID int64, orderStatus string) (*time.Time, error) {
+	expiresAt := uc.orderBlockExpiresAt(orderStatus)
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		blocks, err := uc.productOrderBlockUC.FindList(ctx, ProductOrderBlockListOptions{
+			OrderID: &orderID,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Истекшая бронь уже могла быть снята, продлевать нечего
+		if len(blocks) == 0 {
+			return ErrProductOrderBlockNotFound
+		}
+
+		return uc.productOrderBlockUC.SetExpiresAtForOrder(ctx, orderID, expiresAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return expiresAt, nil
+}
+
+// Снятие просроченных броней, остаток возвращается так же, как при отмене брони заказа
+func (uc *ProductInpl) TaskReleaseExpiredOrderBlocks(ctx context.Context) error {
+	now := time.Now()
+
+	// Не снятые брони остаются в начале выборки, поэтому смещение равно их количеству
+	skipped := uint64(0)
+
+	for {
+		orderIDs, err := uc.productOrderBlockUC.FindExpiredOrderIDs(ctx, now, skipped, 100)
+		if err != nil {
+			return err
+		}
+
+		if len(orderIDs) == 0 {
+			break
+		}
+
+		for _, orderID := range orderIDs {
+			isReleased, err := uc.releaseExpiredOrderBlock(ctx, orderID, now)
+			if err != nil {
+				return err
+			}
+
+			if !isReleased {
+				skipped++
+			}
+		}
+	}
+
+	return nil
+}
+
+func (uc *ProductInpl) releaseExpiredOrderBlock(ctx context.Context, orderID int64, now time.Time) (bool, error) {
+	blocks, err := uc.productOrderBlockUC.FindList(ctx, ProductOrderBlockListOptions{
+		OrderID: &orderID,
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// Бронь могли снять или продлить после выборки
+	if len(blocks) == 0 {
+		return true, nil
+	}
+
+	for _, block := range blocks {
+		if !block.IsExpired(now) {
+			return false, nil
+		}
+	}
+
+	// Заказ узнает об истечении брони до ее снятия, иначе он уйдет дальше без товаров на складе.
+	// Заказ, который уже нельзя отменить, бронь сохраняет до решения администратора
+	canRelease, err := uc.ordersGCl.Client.ExpireOrderStockReserve(ctx, orderID)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "cant expire order stock reserve in orders service", slog.Int64("order_id", orderID), slog.Any("error", err))
+		return false, nil
+	}
+
+	if !canRelease {
+		uc.logger.WarnContext(ctx, "order block expired, but order cant be canceled", slog.Int64("order_id", orderID))
+		return false, nil
+	}
+
+	// Заказ уже отменен, поэтому бронь снимаем, даже если ее успели продлить
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		blocks, err := uc.productOrderBlockUC.FindList(ctx, ProductOrderBlockListOptions{
+			OrderID: &orderID,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = uc.releaseOrderBlocks(ctx, orderID, blocks, domain.StockMovementReasonOrderExpire)
+		if err != nil {
+			return err
+		}
+
+		uc.logger.InfoContext(ctx, "order block expired", slog.Int64("order_id", orderID))
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Окончательное списание брони выполненного заказа. Остаток уже уменьшен при бронировании,
+// поэтому бронь переносится в списанные товары и удаляется, остаток не меняется
+func (uc *ProductInpl) ApplyOrderBlock(ctx context.Context, orderID int64) error {
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		blocks, err := uc.productOrderBlockUC.FindList(ctx, ProductOrderBlockListOptions{
+			OrderID: &orderID,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(blocks) == 0 {
+			// Повторный вызов после успешного списания
+			isConsumed, err := uc.productConsumptionUC.CheckConsumptionForOrder(ctx, orderID)
+			if err != nil {
+				return err
+			}
+
+			if isConsumed {
+				return nil
+			}
+
+			return ErrProductOrderBlockNotFound
+		}
+
+		for _, block := range blocks {
+			consumption, err := domain.NewProductOrderConsumptionFromBlock(block)
+			if err != nil {
+				return err
+			}
+
+			err = uc.productConsumptionUC.Create(ctx, consumption)
+			if err != nil {
+				return err
+			}
+		}
+
+		return uc.productOrderBlockUC.ClearBlocksForOrder(ctx, orderID)
+	})
+}
+
+func (uc *ProductInpl) SetReturnRestock(ctx context.Context, returnID int64, composition []ProductReturnRestockComposition) error {
+
+	quantities := make(map[productStockKey]int32, len(composition))
+	for _, item := range composition {
+		quantities[productStockKey{ProductID: item.ProductID, VariantID: item.VariantID}] = item.Quantity
+	}
+
+	if len(quantities) != len(composition) {
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("duplicate products")
+	}
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		curRestocks, err := uc.productReturnRestockUC.FindList(ctx, ProductReturnRestockListOptions{
+			ReturnID: &returnID,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		//Отменяем текущее пополнение остатка по возврату, если есть
+		if len(curRestocks) > 0 {
+			curKeys := lo.Map(curRestocks, func(item *domain.ProductReturnRestock, _ int) productStockKey {
+				return productStockKey{ProductID: item.ProductID, VariantID: item.VariantID}
+			})
+
+			holders, err := uc.lockStockHolders(ctx, curKeys, false)
+			if err != nil {
+				return err
+			}
+
+			for _, restock := range curRestocks {
+				key := productStockKey{ProductID: restock.ProductID, VariantID: restock.VariantID}
+				holder, ok := holders[key]
+				if !ok {
+					continue
+				}
+
+				err = uc.changeHolderStock(ctx, key, holder, -int64(restock.Quantity), stockChangeSource{
+					Reason:      domain.StockMovementReasonReturnCancel,
+					ReturnID:    &returnID,
+					WarehouseID: restock.WarehouseID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			err = uc.productReturnRestockUC.ClearRestocksForReturn(ctx, returnID)
+			if err != nil {
+				return err
+			}
+		}
+
+		//Возвращаем товары на склад, если список не пустой
+		if len(quantities) > 0 {
+
+			// Вариант мог быть удален после продажи, поэтому наличие вариантов у товара здесь не проверяем
+			holders, err := uc.lockStockHolders(ctx, lo.Keys(quantities), false)
+			if err != nil {
+				return err
+			}
+
+			if len(holders) != len(quantities) {
+				return e.NewErrorFrom(e.ErrBadRequest).SetMessage("products not found")
+			}
+
+			// Возвращенный товар поступает на склад по умолчанию
+			warehouse, err := uc.warehouseUC.FindDefault(ctx)
+			if err != nil {
+				return err
+			}
+
+			for key, quantity := range quantities {
+				restock, err := domain.NewProductReturnRestock(key.ProductID, key.VariantID, returnID, warehouse.ID, quantity)
+				if err != nil {
+					return err
+				}
+
+				err = uc.changeHolderStock(ctx, key, holders[key], int64(quantity), stockChangeSource{
+					Reason:      domain.StockMovementReasonReturn,
+					ReturnID:    &returnID,
+					WarehouseID: warehouse.ID,
+				})
+				if err != nil {
+					return err
+				}
+
+				err = uc.productReturnRestockUC.Create(ctx, restock)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Позиция склада: товар без вариантов (VariantID = 0) или конкретный вариант товара
+type productStockKey struct {
+	ProductID int64
+	VariantID int64
+}
+
+type productStockHolder interface {
+	IncreaseStock(value int64) error
+	DecreaseStock(value int64) error
+}
+
+// Блокирует товары и варианты, остатки которых будут меняться.
+// В строгом режиме все позиции должны существовать, а товар с вариантами нельзя указать без варианта
+func (uc *ProductInpl) lockStockHolders(ctx context.Context, keys []productStockKey, isStrict bool) (map[productStockKey]productStockHolder, error) {
+	result := make(map[productStockKey]productStockHolder, len(keys))
+
+	productIDs := lo.Uniq(lo.Map(keys, func(item productStockKey, _ int) int64 {
+		return item.ProductID
+	}))
+
+	variantIDs := lo.Uniq(lo.FilterMap(keys, func(item productStockKey, _ int) (int64, bool) {
+		return item.VariantID, item.VariantID > 0
+	}))
+
+	products, err := uc.repo.FindList(ctx, ProductListOptions{
+		IDs: &productIDs,
+	}, &uctypes.QueryGetListParams{
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	productsMap := lo.SliceToMap(products, func(item *domain.Product) (int64, *domain.Product) {
+		return item.ID, item
+	})
+
+	variantsMap := map[int64]*domain.ProductVariant{}
+	if len(variantIDs) > 0 {
+		variants, err := uc.productVariantUC.FindList(ctx, ProductVariantListOptions{
+			IDs: &variantIDs,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		variantsMap = lo.SliceToMap(variants, func(item *domain.ProductVariant) (int64, *domain.ProductVariant) {
+			return item.ID, item
+		})
+	}
+
+	var productVariants map[int64][]*domain.ProductVariant
+	if isStrict {
+		productVariants, err = uc.productVariantUC.FindListByProducts(ctx, productIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range keys {
+		product, ok := productsMap[key.ProductID]
+		if !ok {
+			if isStrict {
+				return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage("products not found")
+			}
+			continue
+		}
+
+		if key.VariantID == 0 {
+			if isStrict && len(productVariants[product.ID]) > 0 {
+				return nil, e.NewErrorFrom(ErrProductVariantRequired).SetMessage(fmt.Sprintf("product has variants, variant must be specified, product_id: %d", product.ID))
+			}
+
+			result[key] = product
+			continue
+		}
+
+		variant, ok := variantsMap[key.VariantID]
+		if !ok || variant.ProductID != product.ID {
+			if isStrict {
+				return nil, e.NewErrorFrom(ErrProductVariantInvalid).SetMessage(fmt.Sprintf("invalid variant, product_id: %d, variant_id: %d", product.ID, key.VariantID))
+			}
+			continue
+		}
+
+		result[key] = variant
+	}
+
+	return result, nil
+}
+
+func (uc *ProductInpl) saveStockHolder(ctx context.Context, holder productStockHolder) error {
+	switch item := holder.(type) {
+	case *domain.Product:
+		return uc.repo.Update(ctx, item)
+	case *domain.ProductVariant:
+		return uc.productVariantUC.Update(ctx, item)
+	}
+
+	return e.ErrInternal
+}
+
+// Источник изменения остатка для журнала движений
+type stockChangeSource struct {
+	Reason domain.StockMovementReason
+	// Склад, на котором меняется остаток
+	WarehouseID int64
+	OrderID     *int64
+	ReturnID    *int64
+	ActorID     *uuid.UUID
+}
+
+func stockDelta(value int32, isIncrease bool) int64 {
+	if isIncrease {
+		return int64(value)
+	}
+
+	return -int64(value)
+}
+
+// Меняет остаток заблокированной позиции на складе и общий остаток, записывает движение в той же транзакции
+func (uc *ProductInpl) changeHolderStock(ctx context.Context, key productStockKey, holder productStockHolder, delta int64, source stockChangeSource) error {
+	_, err := uc.warehouseStockUC.ChangeStock(ctx, source.WarehouseID, key.ProductID, key.VariantID, delta)
+	if err != nil {
+		return err
+	}
+
+	if delta >= 0 {
+		err = holder.IncreaseStock(delta)
+	} else {
+		err = holder.DecreaseStock(-delta)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = uc.saveStockHolder(ctx, holder)
+	if err != nil {
+		return err
+	}
+
+	var product *domain.Product
+	var quantity int32
+	var sku string
+	switch item := holder.(type) {
+	case *domain.Product:
+		product = item
+		quantity = item.StockAvailable
+		sku = item.SKU
+	case *domain.ProductVariant:
+		// Порог низкого остатка варианта задается у товара
+		product, err = uc.repo.FindOneByID(ctx, key.ProductID, nil)
+		if err != nil {
+			return err
+		}
+		quantity = item.StockAvailable
+		sku = lo.Ternary(item.SKU != "", item.SKU, product.SKU)
+	}
+
+	movement := domain.NewStockMovement(key.ProductID, key.VariantID, int32(delta), quantity, source.Reason)
+	movement.WarehouseID = &source.WarehouseID
+	movement.OrderID = source.OrderID
+	movement.ReturnID = source.ReturnID
+	movement.ActorID = source.ActorID
+
+	err = uc.stockMovementUC.Add(ctx, movement)
+	if err != nil {
+		return err
+	}
+
+	return uc.lowStockAlertUC.Check(ctx, LowStockAlertCheckIn{
+		ProductID: key.ProductID,
+		VariantID: key.VariantID,
+		Name:      product.Name,
+		SKU:       sku,
+		Quantity:  quantity,
+		Threshold: product.GetLowStockThreshold(uc.config.LowStock.DefaultThreshold),
+	})
+}
+
+// Начальный остаток поступает на склад по умолчанию
+func (uc *ProductInpl) addInitialStockMovement(ctx context.Context, key productStockKey, quantity int32, actorID *uuid.UUID) error {
+	if quantity == 0 {
+		return nil
+	}
+
+	warehouse, err := uc.warehouseUC.FindDefault(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.warehouseStockUC.ChangeStock(ctx, warehouse.ID, key.ProductID, key.VariantID, int64(quantity))
+	if err != nil {
+		return err
+	}
+
+	movement := domain.NewStockMovement(key.ProductID, key.VariantID, quantity, quantity, domain.StockMovementReasonInitial)
+	movement.WarehouseID = &warehouse.ID
+	movement.ActorID = actorID
+
+	return uc.stockMovementUC.Add(ctx, movement)
+}
+
+// Часть брони позиции, приходящаяся на один склад
+type warehouseAllocation struct {
+	WarehouseID int64
+	Quantity    int32
+}
+
+// Распределяет бронируемое количество по складам в заданном порядке.
+// Если на первом складе товара не хватает, недостающее берется со следующих
+func (uc *ProductInpl) allocateWarehouseStock(ctx context.Context, key productStockKey, quantity int32, warehouses []*domain.Warehouse) ([]warehouseAllocation, error) {
+	stocks, err := uc.warehouseStockUC.FindList(ctx, WarehouseStockListOptions{
+		ProductID:    &key.ProductID,
+		VariantID:    &key.VariantID,
+		OnlyPositive: true,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stocksMap := lo.SliceToMap(stocks, func(item *domain.WarehouseStock) (int64, int32) {
+		return item.WarehouseID, item.Quantity
+	})
+
+	result := []warehouseAllocation{}
+	rest := quantity
+
+	for _, warehouse := range warehouses {
+		if rest == 0 {
+			break
+		}
+
+		part := min(rest, stocksMap[warehouse.ID])
+		if part == 0 {
+			continue
+		}
+
+		result = append(result, warehouseAllocation{
+			WarehouseID: warehouse.ID,
+			Quantity:    part,
+		})
+		rest -= part
+	}
+
+	if rest > 0 {
+		return nil, e.NewErrorFrom(domain.ErrProductStockLowerZero).SetMessage(fmt.Sprintf("not enough stock, product_id: %d, variant_id: %d", key.ProductID, key.VariantID))
+	}
+
+	return result, nil
+}
+
+// Склад для ручной корректировки остатка. Поступать товар может только на активный склад,
+// иначе его остаток был бы виден на витрине, но не бронировался
+func (uc *ProductInpl) findWarehouseForAdjust(ctx context.Context, warehouseID int64, isIncrease bool) (*domain.Warehouse, error) {
+	if warehouseID == 0 {
+		return uc.warehouseUC.FindDefault(ctx)
+	}
+
+	warehouse, err := uc.warehouseUC.FindOneByID(ctx, warehouseID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if isIncrease && !warehouse.IsActive {
+		return nil, ErrWarehouseInactive
+	}
+
+	return warehouse, nil
+}
+
+// Перемещение не меняет общий остаток, поэтому в журнал пишутся расход с одного склада и приход на другой
+func (uc *ProductInpl) TransferStock(ctx context.Context, input ProductStockTransferIn) error {
+	if input.Quantity < 1 {
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("quantity must be greater than zero")
+	}
+
+	if input.FromWarehouseID == input.ToWarehouseID {
+		return e.NewErrorFrom(e.ErrBadRequest).SetMessage("warehouses must be different")
+	}
+
+	key := productStockKey{ProductID: input.ProductID, VariantID: input.VariantID}
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		holders, err := uc.lockStockHolders(ctx, []productStockKey{key}, true)
+		if err != nil {
+			return err
+		}
+
+		_, err = uc.warehouseUC.FindOneByID(ctx, input.FromWarehouseID, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = uc.findWarehouseForAdjust(ctx, input.ToWarehouseID, true)
+		if err != nil {
+			return err
+		}
+
+		var quantity int32
+		switch item := holders[key].(type) {
+		case *domain.Product:
+			quantity = item.StockAvailable
+		case *domain.ProductVariant:
+			quantity = item.StockAvailable
+		}
+
+		moves := []struct {
+			WarehouseID int64
+			Delta       int32
+			Reason      domain.StockMovementReason
+		}{
+			{WarehouseID: input.FromWarehouseID, Delta: -input.Quantity, Reason: domain.StockMovementReasonTransferOut},
+			{WarehouseID: input.ToWarehouseID, Delta: input.Quantity, Reason: domain.StockMovementReasonTransferIn},
+		}
+
+		for _, move := range moves {
+			_, err = uc.warehouseStockUC.ChangeStock(ctx, move.WarehouseID, key.ProductID, key.VariantID, int64(move.Delta))
+			if err != nil {
+				return err
+			}
+
+			movement := domain.NewStockMovement(key.ProductID, key.VariantID, move.Delta, quantity, move.Reason)
+			movement.WarehouseID = &move.WarehouseID
+			movement.ActorID = input.ActorID
+
+			err = uc.stockMovementUC.Add(ctx, movement)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type priceChangeSource struct {
+	Reason     domain.ProductPriceChangeReason
+	ScheduleID *int64
+	ActorID    *uuid.UUID
+}
+
+func (uc *ProductInpl) addPriceHistory(ctx context.Context, product *domain.Product, oldPrice *decimal.Decimal, source priceChangeSource) error {
+	item := domain.NewProductPriceHistory(product.ID, oldPrice, product.Price, product.CompareAtPrice, source.Reason)
+	item.ScheduleID = source.ScheduleID
+	item.ActorID = source.ActorID
+
+	return uc.productPriceHistoryUC.Add(ctx, item)
+}
+
+// Применяет запланированную цену к товару. Статус расписания сохраняет вызывающая сторона в той же транзакции
+func (uc *ProductInpl) ApplyPriceSchedule(ctx context.Context, schedule *domain.ProductPriceSchedule) error {
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		product, err := uc.repo.FindOneByID(ctx, schedule.ProductID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		oldPrice := product.Price
+		product.ApplyScheduledPrice(schedule)
+		product.IncVersion()
+
+		err = uc.repo.Update(ctx, product)
+		if err != nil {
+			return err
+		}
+
+		return uc.addPriceHistory(ctx, product, &oldPrice, priceChangeSource{
+			Reason:     domain.ProductPriceChangeReasonScheduleStart,
+			ScheduleID: &schedule.ID,
+			ActorID:    schedule.ActorID,
+		})
+	})
+}
+
+func (uc *ProductInpl) RevertPriceSchedule(ctx context.Context, schedule *domain.ProductPriceSchedule) error {
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		product, err := uc.repo.FindOneByID(ctx, schedule.ProductID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		oldPrice := product.Price
+		product.RevertScheduledPrice(schedule)
+		product.IncVersion()
+
+		err = uc.repo.Update(ctx, product)
+		if err != nil {
+			return err
+		}
+
+		return uc.addPriceHistory(ctx, product, &oldPrice, priceChangeSource{
+			Reason:     domain.ProductPriceChangeReasonScheduleEnd,
+			ScheduleID: &schedule.ID,
+			ActorID:    schedule.ActorID,
+		})
+	})
+}
+
+func (uc *ProductInpl) CreateVariant(ctx context.Context, productID int64, input ProductVariantIn) (*domain.ProductVariant, error) {
+	variant := domain.NewProductVariant(0, productID)
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		_, err := uc.repo.FindOneByID(ctx, productID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = uc.setVariantData(ctx, variant, input)
+		if err != nil {
+			return err
+		}
+
+		err = variant.SetStockAvailable(input.StockAvailable)
+		if err != nil {
+			return err
+		}
+
+		err = uc.productVariantUC.Create(ctx, variant)
+		if err != nil {
+			return err
+		}
+
+		return uc.addInitialStockMovement(ctx, productStockKey{ProductID: productID, VariantID: variant.ID}, variant.StockAvailable, input.ActorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (uc *ProductInpl) UpdateVariant(ctx context.Context, productID int64, variantID int64, input ProductVariantIn) (*domain.ProductVariant, error) {
+	var variant *domain.ProductVariant
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		variant, err = uc.findProductVariantForUpdate(ctx, productID, variantID)
+		if err != nil {
+			return err
+		}
+
+		err = uc.setVariantData(ctx, variant, input)
+		if err != nil {
+			return err
+		}
+
+		return uc.productVariantUC.Update(ctx, variant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (uc *ProductInpl) setVariantData(ctx context.Context, variant *domain.ProductVariant, input ProductVariantIn) error {
+	if input.SKU != variant.SKU {
+		err := uc.checkSKUIsFree(ctx, input.SKU, 0, variant.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := variant.SetOptions(input.Options)
+	if err != nil {
+		return err
+	}
+
+	err = variant.SetPrice(input.Price)
+	if err != nil {
+		return err
+	}
+
+	variant.SKU = input.SKU
+	variant.Sort = input.Sort
+
+	return nil
+}
+
+func (uc *ProductInpl) findProductVariantForUpdate(ctx context.Context, productID int64, variantID int64) (*domain.ProductVariant, error) {
+	variant, err := uc.productVariantUC.FindOneByID(ctx, variantID, &uctypes.QueryGetOneParams{
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if variant.ProductID != productID {
+		return nil, e.ErrNotFound
+	}
+
+	return variant, nil
+}
+
+// warehouseID = 0 - склад по умолчанию
+func (uc *ProductInpl) ChangeVariantStock(ctx context.Context, productID int64, variantID int64, warehouseID int64, value int32, isIncrease bool, actorID *uuid.UUID) error {
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		variant, err := uc.findProductVariantForUpdate(ctx, productID, variantID)
+		if err != nil {
+			return err
+		}
+
+		warehouse, err := uc.findWarehouseForAdjust(ctx, warehouseID, isIncrease)
+		if err != nil {
+			return err
+		}
+
+		return uc.changeHolderStock(ctx, productStockKey{ProductID: productID, VariantID: variant.ID}, variant, stockDelta(value, isIncrease), stockChangeSource{
+			Reason:      domain.StockMovementReasonManualAdjust,
+			WarehouseID: warehouse.ID,
+			ActorID:     actorID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (uc *ProductInpl) DeleteVariant(ctx context.Context, productID int64, variantID int64) error {
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		variant, err := uc.findProductVariantForUpdate(ctx, productID, variantID)
+		if err != nil {
+			return err
+		}
+
+		blockIsExists, err := uc.productOrderBlockUC.CheckBlockForVariant(ctx, variant.ID)
+		if err != nil {
+			return err
+		}
+
+		if blockIsExists {
+			return ErrProductAlreadyHasOrders
+		}
+
+		return uc.productVariantUC.DeleteByIDs(ctx, []int64{variant.ID})
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	orderscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/orders"
+	ordersgcl "github.com/m11ano/mipt-webdev-course/backend/services/products/internal/clients/grpc/orders"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+const productBoughtTogetherBatchSize = 100
+
+type ProductBoughtTogetherListOptions struct {
+	ProductID  *int64
+	ProductIDs *[]int64
+}
+
+//go:generate mockery --name=ProductBoughtTogether --output=../../tests/mocks --case=underscore
+type ProductBoughtTogether interface {
+	FindBoughtTogetherFullForProduct(ctx context.Context, productID int64, visibleAt *time.Time) (out []*ProductFullOut, err error)
+	TaskRefresh(ctx context.Context, createdFrom time.Time) (err error)
+}
+
+//go:generate mockery --name=ProductBoughtTogetherRepository --output=../../tests/mocks --case=underscore
+type ProductBoughtTogetherRepository interface {
+	FindList(ctx context.Context, listOptions ProductBoughtTogetherListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductBoughtTogether, err error)
+	Create(ctx context.Context, item *domain.ProductBoughtTogether) (err error)
+	DeleteByList(ctx context.Context, listOptions ProductBoughtTogetherListOptions) (err error)
+}
+
+type ProductBoughtTogetherInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductBoughtTogetherRepository
+	txManager *manager.Manager
+	productUC Product
+	ordersGCl *ordersgcl.ClientConn
+}
+
+func NewProductBoughtTogetherInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductBoughtTogetherRepository, productUC Product, ordersGCl *ordersgcl.ClientConn) *ProductBoughtTogetherInpl {
+	uc := &ProductBoughtTogetherInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+		productUC: productUC,
+		ordersGCl: ordersGCl,
+	}
+	return uc
+}
+
+// Часто покупаемые вместе товары по убыванию количества заказов. Если передан visibleAt, то только опубликованные на этот момент
+func (uc *ProductBoughtTogetherInpl) FindBoughtTogetherFullForProduct(ctx context.Context, productID int64, visibleAt *time.Time) ([]*ProductFullOut, error) {
+	items, err := uc.repo.FindList(ctx, ProductBoughtTogetherListOptions{
+		ProductID: &productID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := lo.Map(items, func(item *domain.ProductBoughtTogether, _ int) int64 {
+		return item.RelatedProductID
+	})
+
+	return findProductsFullByIDsInOrder(ctx, uc.productUC, ids, visibleAt)
+}
+
+// Пересчет часто покупаемых вместе товаров по заказам, созданным не раньше createdFrom
+func (uc *ProductBoughtTogetherInpl) TaskRefresh(ctx context.Context, createdFrom time.Time) error {
+
+	offset := uint64(0)
+
+	for {
+		items, err := uc.productUC.FindList(ctx, ProductListOptions{
+			Sort: &[]ProductListSort{
+				{
+					Field: ProductListSortFieldID,
+				},
+			},
+		}, &uctypes.QueryGetListParams{
+			Limit:  productBoughtTogetherBatchSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			break
+		}
+
+		productIDs := lo.Map(items, func(item *domain.Product, _ int) int64 {
+			return item.ID
+		})
+
+		err = uc.refreshBatch(ctx, productIDs, createdFrom)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "refresh bought together products", slog.Any("product_ids", productIDs), slog.Any("error", err))
+		}
+
+		offset += uint64(len(items))
+	}
+
+	return nil
+}
+
+func (uc *ProductBoughtTogetherInpl) refreshBatch(ctx context.Context, productIDs []int64, createdFrom time.Time) error {
+
+	coPurchases, err := uc.ordersGCl.Client.GetProductCoPurchases(ctx, orderscl.GetProductCoPurchasesIn{
+		ProductIDs:      productIDs,
+		CreatedFrom:     createdFrom,
+		MinOrders:       int32(uc.config.Cron.Tasks.RefreshBoughtTogether.MinOrders),
+		LimitPerProduct: int32(uc.config.Cron.Tasks.RefreshBoughtTogether.LimitPerProduct),
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		relatedProductIDs := lo.Uniq(lo.Map(coPurchases, func(item orderscl.ProductCoPurchase, _ int) int64 {
+			return item.RelatedProductID
+		}))
+
+		// Заказы ссылаются и на товары, которых уже нет в каталоге, такие пропускаем
+		relatedProducts, err := uc.productUC.FindList(ctx, ProductListOptions{
+			IDs: &relatedProductIDs,
+		}, &uctypes.QueryGetListParams{
+			ForShare: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		existsIDs := lo.SliceToMap(relatedProducts, func(item *domain.Product) (int64, struct{}) {
+			return item.ID, struct{}{}
+		})
+
+		err = uc.repo.DeleteByList(ctx, ProductBoughtTogetherListOptions{
+			ProductIDs: &productIDs,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, coPurchase := range coPurchases {
+			if _, ok := existsIDs[coPurchase.RelatedProductID]; !ok {
+				continue
+			}
+
+			err = uc.repo.Create(ctx, domain.NewProductBoughtTogether(coPurchase.ProductID, coPurchase.RelatedProductID, coPurchase.OrdersCount))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
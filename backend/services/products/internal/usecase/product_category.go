@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type ProductCategoryListOptions struct {
+	ProductID  *int64
+	ProductIDs *[]int64
+	CategoryID *int64
+}
+
+//go:generate mockery --name=ProductCategory --output=../../tests/mocks --case=underscore
+type ProductCategory interface {
+	FindCategoryIDsForProduct(ctx context.Context, productID int64) (ids []int64, err error)
+	FindCategoryIDsForProducts(ctx context.Context, productIDs []int64) (ids map[int64][]int64, err error)
+	SaveActualCategoriesForProduct(ctx context.Context, productID int64, categoryIDs []int64) (items []*domain.ProductCategory, err error)
+}
+
+//go:generate mockery --name=ProductCategoryRepository --output=../../tests/mocks --case=underscore
+type ProductCategoryRepository interface {
+	FindList(ctx context.Context, listOptions ProductCategoryListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductCategory, err error)
+	Create(ctx context.Context, item *domain.ProductCategory) (err error)
+	DeleteByList(ctx context.Context, listOptions ProductCategoryListOptions) (err error)
+}
+
+type ProductCategoryInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductCategoryRepository
+	txManager *manager.Manager
+}
+
+func NewProductCategoryInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductCategoryRepository) *ProductCategoryInpl {
+	uc := &ProductCategoryInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *ProductCategoryInpl) FindCategoryIDsForProduct(ctx context.Context, productID int64) ([]int64, error) {
+	items, err := uc.repo.FindList(ctx, ProductCategoryListOptions{
+		ProductID: &productID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(items, func(item *domain.ProductCategory, _ int) int64 {
+		return item.CategoryID
+	}), nil
+}
+
+func (uc *ProductCategoryInpl) FindCategoryIDsForProducts(ctx context.Context, productIDs []int64) (map[int64][]int64, error) {
+	items, err := uc.repo.FindList(ctx, ProductCategoryListOptions{
+		ProductIDs: &productIDs,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64][]int64, len(productIDs))
+	for _, item := range items {
+		result[item.ProductID] = append(result[item.ProductID], item.CategoryID)
+	}
+
+	return result, nil
+}
+
+func (uc *ProductCategoryInpl) SaveActualCategoriesForProduct(ctx context.Context, productID int64, categoryIDs []int64) ([]*domain.ProductCategory, error) {
+
+	result := make([]*domain.ProductCategory, 0, len(categoryIDs))
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		err := uc.repo.DeleteByList(ctx, ProductCategoryListOptions{
+			ProductID: &productID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, categoryID := range lo.Uniq(categoryIDs) {
+			item := domain.NewProductCategory(productID, categoryID)
+
+			err = uc.repo.Create(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type ProductDraftSaveIn struct {
+	Name               string
+	FullDescription    string
+	Price              decimal.Decimal
+	ImagePreviewFileID *uuid.UUID
+	SliderFilesIDs     []uuid.UUID
+	ActorID            *uuid.UUID
+}
+
+//go:generate mockery --name=ProductDraft --output=../../tests/mocks --case=underscore
+type ProductDraft interface {
+	FindOneByProductID(ctx context.Context, productID int64) (draft *domain.ProductDraft, err error)
+	Save(ctx context.Context, productID int64, input ProductDraftSaveIn) (draft *domain.ProductDraft, err error)
+	Preview(ctx context.Context, productID int64) (draft *domain.ProductDraft, out *ProductOneFullOut, err error)
+	Publish(ctx context.Context, productID int64, actorID *uuid.UUID) (product *domain.Product, err error)
+	Discard(ctx context.Context, productID int64) (err error)
+}
+
+//go:generate mockery --name=ProductDraftRepository --output=../../tests/mocks --case=underscore
+type ProductDraftRepository interface {
+	FindOneByProductID(ctx context.Context, productID int64, queryParams *uctypes.QueryGetOneParams) (draft *domain.ProductDraft, err error)
+	Create(ctx context.Context, item *domain.ProductDraft) (err error)
+	Update(ctx context.Context, item *domain.ProductDraft) (err error)
+	Delete(ctx context.Context, productID int64) (err error)
+}
+
+type ProductDraftInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductDraftRepository
+	txManager *manager.Manager
+	productUC Product
+	fileUC    File
+}
+
+func NewProductDraftInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductDraftRepository, productUC Product, fileUC File) *ProductDraftInpl {
+	uc := &ProductDraftInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+		productUC: productUC,
+		fileUC:    fileUC,
+	}
+	return uc
+}
+
+func (uc *ProductDraftInpl) FindOneByProductID(ctx context.Context, productID int64) (*domain.ProductDraft, error) {
+	return uc.repo.FindOneByProductID(ctx, productID, nil)
+}
+
+// Файлы, которые использует сам товар, черновик не удаляет
+func productFilesIDs(product *ProductOneFullOut) []uuid.UUID {
+	result := lo.Map(product.SliderFiles, func(file *domain.File, _ int) uuid.UUID {
+		return file.ID
+	})
+	if product.Product.ImagePreviewFileID != nil {
+		result = append(result, *product.Product.ImagePreviewFileID)
+	}
+
+	return result
+}
+
+// Сохранение черновика. Новые изображения помечаются привязанными, чтобы их не удалила очистка непривязанных файлов
+func (uc *ProductDraftInpl) Save(ctx context.Context, productID int64, input ProductDraftSaveIn) (*domain.ProductDraft, error) {
+	var draft *domain.ProductDraft
+
+	input.SliderFilesIDs = lo.Uniq(input.SliderFilesIDs)
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		product, err := uc.productUC.FindOneFullByID(ctx, productID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		isNew := false
+		draft, err = uc.repo.FindOneByProductID(ctx, productID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			if !errors.Is(err, e.ErrNotFound) {
+				return err
+			}
+			isNew = true
+			draft = domain.NewProductDraft(productID)
+		}
+
+		usedFilesIDs := append(productFilesIDs(product), draft.FilesIDs()...)
+		prevDraftFilesIDs := draft.FilesIDs()
+
+		draft.Name = input.Name
+		draft.FullDescription = input.FullDescription
+		draft.ImagePreviewFileID = input.ImagePreviewFileID
+		draft.SliderFilesIDs = input.SliderFilesIDs
+		draft.BaseVersion = product.Product.Version
+		draft.ActorID = input.ActorID
+
+		err = draft.SetPrice(input.Price)
+		if err != nil {
+			return err
+		}
+
+		err = uc.checkDraftFiles(ctx, draft, usedFilesIDs)
+		if err != nil {
+			return err
+		}
+
+		err = uc.fileUC.MarkAsAssigned(ctx, draft.FilesIDs())
+		if err != nil {
+			return err
+		}
+
+		// Изображения, убранные из черновика и не используемые товаром
+		removedFilesIDs, _ := lo.Difference(prevDraftFilesIDs, append(draft.FilesIDs(), productFilesIDs(product)...))
+		if len(removedFilesIDs) > 0 {
+			err = uc.fileUC.DeleteFilesByIDs(ctx, removedFilesIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		if isNew {
+			return uc.repo.Create(ctx, draft)
+		}
+
+		draft.UpdatedAt = lo.ToPtr(time.Now())
+
+		return uc.repo.Update(ctx, draft)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+func (uc *ProductDraftInpl) checkDraftFiles(ctx context.Context, draft *domain.ProductDraft, usedFilesIDs []uuid.UUID) error {
+	filesIDs := draft.FilesIDs()
+
+	files, err := uc.fileUC.FindListInMap(ctx, FileListOptions{
+		IDs: &filesIDs,
+	}, &uctypes.QueryGetListParams{
+		ForUpdate: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	check := func(fileID uuid.UUID, target domain.FileTarget) error {
+		file, ok := files[fileID]
+		if !ok {
+			return e.NewErrorFrom(ErrProductFileIDInvalid).SetMessage(fmt.Sprintf("incorrect file_id: %s", fileID.String()))
+		}
+
+		if file.Target != target {
+			return e.NewErrorFrom(ErrProductFileIDInvalid).SetMessage(fmt.Sprintf("incorrect target, file_id: %s", fileID.String()))
+		}
+
+		if file.AssignedToTarget && !lo.Contains(usedFilesIDs, fileID) {
+			return e.NewErrorFrom(ErrProductFileIDInvalid).SetMessage(fmt.Sprintf("already assigned to target, file_id: %s", fileID.String()))
+		}
+
+		return nil
+	}
+
+	if draft.ImagePreviewFileID != nil {
+		err = check(*draft.ImagePreviewFileID, domain.FileTargetProductPreview)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, fileID := range draft.SliderFilesIDs {
+		err = check(fileID, domain.FileTargetProductSlider)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Товар с примененными правками черновика, как его увидят покупатели после публикации
+func (uc *ProductDraftInpl) Preview(ctx context.Context, productID int64) (*domain.ProductDraft, *ProductOneFullOut, error) {
+	draft, err := uc.repo.FindOneByProductID(ctx, productID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	product, err := uc.productUC.FindOneFullByID(ctx, productID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filesIDs := draft.FilesIDs()
+
+	files, err := uc.fileUC.FindListInMap(ctx, FileListOptions{
+		IDs: &filesIDs,
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := *product
+	out.Product = draft.ApplyTo(product.Product)
+	out.ProductPreviewFile = nil
+	out.SliderFiles = make([]*domain.File, 0, len(draft.SliderFilesIDs))
+
+	if draft.ImagePreviewFileID != nil {
+		out.ProductPreviewFile = files[*draft.ImagePreviewFileID]
+	}
+
+	for _, fileID := range draft.SliderFilesIDs {
+		if file, ok := files[fileID]; ok {
+			out.SliderFiles = append(out.SliderFiles, file)
+		}
+	}
+
+	return draft, &out, nil
+}
+
+// Публикация черновика одним изменением товара в транзакции, покупатели не видят промежуточного состояния
+func (uc *ProductDraftInpl) Publish(ctx context.Context, productID int64, actorID *uuid.UUID) (*domain.Product, error) {
+	var result *domain.Product
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		draft, err := uc.repo.FindOneByProductID(ctx, productID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		product, err := uc.productUC.FindOneFullByID(ctx, productID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = draft.CheckBaseVersion(product.Product)
+		if err != nil {
+			return err
+		}
+
+		// Update принимает только непривязанные файлы или файлы самого товара
+		draftOnlyFilesIDs, _ := lo.Difference(draft.FilesIDs(), productFilesIDs(product))
+		if len(draftOnlyFilesIDs) > 0 {
+			err = uc.fileUC.MarkAsNotAssigned(ctx, draftOnlyFilesIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, _, err = uc.productUC.Update(ctx, productID, ProductUpdateIn{
+			Version:            product.Product.Version,
+			Name:               draft.Name,
+			SKU:                product.Product.SKU,
+			IsPublished:        product.Product.IsPublished,
+			PublishAt:          product.Product.PublishAt,
+			UnpublishAt:        product.Product.UnpublishAt,
+			FullDescription:    draft.FullDescription,
+			Price:              draft.Price,
+			TaxRate:            product.Product.TaxRate,
+			ImagePreviewFileID: draft.ImagePreviewFileID,
+			SliderFilesIDs:     draft.SliderFilesIDs,
+			CategoryIDs:        product.CategoryIDs,
+			LowStockThreshold:  product.Product.LowStockThreshold,
+			ActorID:            actorID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Delete(ctx, productID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Удаление черновика вместе с изображениями, которые товар не использует
+func (uc *ProductDraftInpl) Discard(ctx context.Context, productID int64) error {
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		draft, err := uc.repo.FindOneByProductID(ctx, productID, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		product, err := uc.productUC.FindOneFullByID(ctx, productID, nil)
+		if err != nil {
+			return err
+		}
+
+		draftOnlyFilesIDs, _ := lo.Difference(draft.FilesIDs(), productFilesIDs(product))
+		if len(draftOnlyFilesIDs) > 0 {
+			err = uc.fileUC.DeleteFilesByIDs(ctx, draftOnlyFilesIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return uc.repo.Delete(ctx, productID)
+	})
+}
@@ -0,0 +1,834 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/pkg/sheet"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+var ErrProductImportEmptyFile = e.NewErrorFrom(e.ErrBadRequest).SetMessage("import file is empty")
+var ErrProductImportInvalidFile = e.NewErrorFrom(e.ErrBadRequest).SetMessage("cannot read import file")
+var ErrProductImportInvalidArchive = e.NewErrorFrom(e.ErrBadRequest).SetMessage("cannot read images archive")
+var ErrProductImportSKUColumnRequired = e.NewErrorFrom(e.ErrBadRequest).SetMessage("column `sku` is required")
+
+const (
+	productImportNameMaxLength = 150
+	productImportSKUMaxLength  = 64
+	// Разделитель списка изображений в ячейке
+	productImportImagesSeparator = "|"
+)
+
+// Колонки файла импорта, порядок совпадает с экспортом
+const (
+	productImportColumnSKU          = "sku"
+	productImportColumnName         = "name"
+	productImportColumnSlug         = "slug"
+	productImportColumnDescription  = "description"
+	productImportColumnPrice        = "price"
+	productImportColumnTaxRate      = "tax_rate"
+	productImportColumnStock        = "stock"
+	productImportColumnPublished    = "published"
+	productImportColumnImagePreview = "image_preview"
+	productImportColumnImages       = "images"
+)
+
+var productImportColumns = []string{
+	productImportColumnSKU,
+	productImportColumnName,
+	productImportColumnSlug,
+	productImportColumnDescription,
+	productImportColumnPrice,
+	productImportColumnTaxRate,
+	productImportColumnStock,
+	productImportColumnPublished,
+	productImportColumnImagePreview,
+	productImportColumnImages,
+}
+
+type ProductImportIn struct {
+	Format sheet.Format
+	Data   []byte
+	// zip-архив с изображениями, пути к которым указаны в файле
+	Archive []byte
+	// Только проверить файл, без сохранения
+	DryRun bool
+	// Аккаунт, запустивший импорт
+	ActorID *uuid.UUID
+}
+
+type ProductImportRowError struct {
+	// Номер строки в файле, начиная с 1 (строка заголовков)
+	Row      int
+	Messages []string
+}
+
+type ProductImportOut struct {
+	Total   int
+	Created int
+	Updated int
+	Errors  []ProductImportRowError
+}
+
+//go:generate mockery --name=ProductImport --output=../../tests/mocks --case=underscore
+type ProductImport interface {
+	Import(ctx context.Context, input ProductImportIn) (out *ProductImportOut, err error)
+	Export(ctx context.Context, format sheet.Format) (data []byte, err error)
+}
+
+type ProductImportInpl struct {
+	logger               *slog.Logger
+	config               config.Config
+	txManager            *manager.Manager
+	productUC            Product
+	fileUC               File
+	productSliderImageUC ProductSliderImage
+	httpClient           *http.Client
+}
+
+func NewProductImportInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, productUC Product, fileUC File, productSliderImageUC ProductSliderImage) *ProductImportInpl {
+	uc := &ProductImportInpl{
+		logger:               logger,
+		config:               config,
+		txManager:            txManager,
+		productUC:            productUC,
+		fileUC:               fileUC,
+		productSliderImageUC: productSliderImageUC,
+		httpClient:           newImportHTTPClient(time.Duration(config.Import.ImageDownloadTimeoutSec) * time.Second),
+	}
+	return uc
+}
+
+// Ссылки на изображения приходят из файла пользователя, поэтому запросы во внутреннюю сеть запрещены.
+// Адрес проверяется при подключении, уже после разрешения имени, в том числе при переадресации
+func newImportHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !isImportPublicIP(ip) {
+				return fmt.Errorf("address %s is not allowed", host)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// Диапазоны одноадресных адресов, которые не маршрутизируются в интернете или ведут во внутреннюю сеть
+var importNonPublicNets = mustParseCIDRs(
+	"0.0.0.0/8",       // "эта" сеть
+	"10.0.0.0/8",      // частная сеть
+	"100.64.0.0/10",   // общее адресное пространство CGNAT
+	"172.16.0.0/12",   // частная сеть
+	"192.0.0.0/24",    // назначения протоколов IETF
+	"192.0.2.0/24",    // документация TEST-NET-1
+	"192.88.99.0/24",  // ретрансляторы 6to4
+	"192.168.0.0/16",  // частная сеть
+	"198.18.0.0/15",   // тестирование производительности
+	"198.51.100.0/24", // документация TEST-NET-2
+	"203.0.113.0/24",  // документация TEST-NET-3
+	"240.0.0.0/4",     // зарезервировано
+	"64:ff9b::/96",    // трансляция NAT64
+	"64:ff9b:1::/48",  // локальная трансляция NAT64
+	"100::/64",        // отбрасываемые адреса
+	"2001::/23",       // назначения протоколов IETF, в том числе Teredo
+	"2001:db8::/32",   // документация
+	"2002::/16",       // 6to4, содержит произвольный IPv4 адрес
+	"fc00::/7",        // уникальные локальные адреса ULA
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = ipNet
+	}
+	return nets
+}
+
+// Разрешены только глобальные одноадресные адреса вне служебных диапазонов
+func isImportPublicIP(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() {
+		return false
+	}
+
+	for _, ipNet := range importNonPublicNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Строка файла импорта. Отсутствующая колонка - nil, значение товара при обновлении не меняется
+type productImportRow struct {
+	Row          int
+	SKU          string
+	Name         *string
+	Slug         *string
+	Description  *string
+	Price        *string
+	TaxRate      *string
+	Stock        *string
+	Published    *string
+	ImagePreview *string
+	Images       *string
+}
+
+// Проверенная строка, готовая к сохранению
+type productImportItem struct {
+	Row          int
+	Product      *domain.Product
+	Current      *ProductOneFullOut
+	ImagePreview string
+	Images       []string
+	// Изменение остатка при обновлении
+	StockDelta int64
+}
+
+func (uc *ProductImportInpl) Import(ctx context.Context, input ProductImportIn) (*ProductImportOut, error) {
+	table, err := sheet.Read(input.Format, input.Data)
+	if err != nil {
+		return nil, e.NewErrorFrom(ErrProductImportInvalidFile).Wrap(err)
+	}
+
+	var archive *zip.Reader
+	if len(input.Archive) > 0 {
+		archive, err = zip.NewReader(bytes.NewReader(input.Archive), int64(len(input.Archive)))
+		if err != nil {
+			return nil, e.NewErrorFrom(ErrProductImportInvalidArchive).Wrap(err)
+		}
+	}
+
+	rows, err := uc.parseTable(table)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ProductImportOut{
+		Total:  len(rows),
+		Errors: make([]ProductImportRowError, 0),
+	}
+
+	skus := lo.Map(rows, func(row *productImportRow, _ int) string {
+		return row.SKU
+	})
+
+	current, err := uc.findCurrentProducts(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+
+	skuRows := make(map[string]int, len(rows))
+	items := make([]*productImportItem, 0, len(rows))
+
+	for _, row := range rows {
+		if firstRow, ok := skuRows[row.SKU]; ok {
+			out.Errors = append(out.Errors, ProductImportRowError{
+				Row:      row.Row,
+				Messages: []string{fmt.Sprintf("sku is duplicated, first occurrence in row %d", firstRow)},
+			})
+			continue
+		}
+		if row.SKU != "" {
+			skuRows[row.SKU] = row.Row
+		}
+
+		item, messages := uc.prepareItem(row, current[row.SKU], archive)
+		if len(messages) > 0 {
+			out.Errors = append(out.Errors, ProductImportRowError{
+				Row:      row.Row,
+				Messages: messages,
+			})
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	for _, item := range items {
+		if input.DryRun {
+			if item.Current == nil {
+				out.Created++
+			} else {
+				out.Updated++
+			}
+			continue
+		}
+
+		err = uc.saveItem(ctx, item, archive, input.ActorID)
+		if err != nil {
+			out.Errors = append(out.Errors, ProductImportRowError{
+				Row:      item.Row,
+				Messages: []string{errorMessage(err)},
+			})
+			continue
+		}
+
+		if item.Current == nil {
+			out.Created++
+		} else {
+			out.Updated++
+		}
+	}
+
+	return out, nil
+}
+
+func (uc *ProductImportInpl) parseTable(table [][]string) ([]*productImportRow, error) {
+	if len(table) < 2 {
+		return nil, ErrProductImportEmptyFile
+	}
+
+	if len(table)-1 > uc.config.Import.MaxRows {
+		return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("import file must contain no more than %d rows", uc.config.Import.MaxRows))
+	}
+
+	// Колонки сопоставляются по заголовку, неизвестные колонки пропускаются
+	columns := make(map[string]int, len(table[0]))
+	for i, title := range table[0] {
+		title = strings.ToLower(strings.TrimSpace(title))
+		if lo.Contains(productImportColumns, title) {
+			columns[title] = i
+		}
+	}
+
+	if _, ok := columns[productImportColumnSKU]; !ok {
+		return nil, ErrProductImportSKUColumnRequired
+	}
+
+	rows := make([]*productImportRow, 0, len(table)-1)
+	for i, values := range table[1:] {
+		if lo.EveryBy(values, func(value string) bool { return strings.TrimSpace(value) == "" }) {
+			continue
+		}
+
+		cell := func(column string) *string {
+			idx, ok := columns[column]
+			if !ok {
+				return nil
+			}
+
+			value := ""
+			if idx < len(values) {
+				value = strings.TrimSpace(values[idx])
+			}
+
+			return &value
+		}
+
+		rows = append(rows, &productImportRow{
+			Row:          i + 2,
+			SKU:          *cell(productImportColumnSKU),
+			Name:         cell(productImportColumnName),
+			Slug:         cell(productImportColumnSlug),
+			Description:  cell(productImportColumnDescription),
+			Price:        cell(productImportColumnPrice),
+			TaxRate:      cell(productImportColumnTaxRate),
+			Stock:        cell(productImportColumnStock),
+			Published:    cell(productImportColumnPublished),
+			ImagePreview: cell(productImportColumnImagePreview),
+			Images:       cell(productImportColumnImages),
+		})
+	}
+
+	return rows, nil
+}
+
+func (uc *ProductImportInpl) findCurrentProducts(ctx context.Context, skus []string) (map[string]*ProductOneFullOut, error) {
+	skus = lo.Uniq(lo.Compact(skus))
+
+	list, err := uc.productUC.FindOneFullList(ctx, ProductListOptions{
+		SKUs: &skus,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.SliceToMap(list, func(item *ProductOneFullOut) (string, *ProductOneFullOut) {
+		return item.Product.SKU, item
+	}), nil
+}
+
+// Проверка строки доменными сеттерами. Для обновления проверяется копия текущего товара
+func (uc *ProductImportInpl) prepareItem(row *productImportRow, current *ProductOneFullOut, archive *zip.Reader) (*productImportItem, []string) {
+	messages := make([]string, 0)
+	addError := func(err error) {
+		messages = append(messages, errorMessage(err))
+	}
+
+	item := &productImportItem{
+		Row:     row.Row,
+		Current: current,
+	}
+
+	if current == nil {
+		item.Product = domain.NewProduct(0)
+	} else {
+		product := *current.Product
+		item.Product = &product
+	}
+
+	isCreate := current == nil
+
+	if row.SKU == "" {
+		messages = append(messages, "sku is required")
+	} else if utf8.RuneCountInString(row.SKU) > productImportSKUMaxLength {
+		messages = append(messages, fmt.Sprintf("sku must be no longer than %d characters", productImportSKUMaxLength))
+	}
+	item.Product.SKU = row.SKU
+
+	if row.Name != nil && *row.Name != "" {
+		if utf8.RuneCountInString(*row.Name) > productImportNameMaxLength {
+			messages = append(messages, fmt.Sprintf("name must be no longer than %d characters", productImportNameMaxLength))
+		}
+		item.Product.Name = *row.Name
+	} else if isCreate || row.Name != nil {
+		messages = append(messages, "name is required")
+	}
+
+	// Пустой slug при создании генерируется из названия, при обновлении не меняется
+	if row.Slug != nil && *row.Slug != "" {
+		err := item.Product.SetSlug(*row.Slug)
+		if err != nil {
+			addError(err)
+		}
+	} else if isCreate {
+		item.Product.Slug = ""
+	}
+
+	if row.Description != nil {
+		item.Product.FullDescription = *row.Description
+	}
+
+	if row.Price != nil && *row.Price != "" {
+		price, err := parseImportDecimal(*row.Price)
+		if err == nil {
+			err = item.Product.SetPrice(price)
+		}
+		if err != nil {
+			addError(domain.ErrProductInvalidPrice)
+		}
+	} else if isCreate || row.Price != nil {
+		messages = append(messages, "price is required")
+	}
+
+	// Пустая ставка - ставка НДС по умолчанию
+	if row.TaxRate != nil {
+		var rate *decimal.Decimal
+		var err error
+		if *row.TaxRate != "" {
+			var value decimal.Decimal
+			value, err = parseImportDecimal(*row.TaxRate)
+			value = value.Round(2)
+			rate = &value
+		}
+
+		if err == nil {
+			err = item.Product.SetTaxRate(rate)
+		}
+		if err != nil {
+			addError(domain.ErrProductInvalidTaxRate)
+		}
+	}
+
+	if row.Stock != nil && *row.Stock != "" {
+		stock, err := strconv.ParseInt(*row.Stock, 10, 32)
+		if err != nil {
+			messages = append(messages, "invalid stock")
+		} else {
+			err = item.Product.SetStockAvailable(int32(stock))
+			if err != nil {
+				addError(err)
+			}
+		}
+
+		if current != nil {
+			item.StockDelta = int64(item.Product.StockAvailable) - int64(current.Product.StockAvailable)
+			item.Product.StockAvailable = current.Product.StockAvailable
+		}
+	}
+
+	if row.Published != nil && *row.Published != "" {
+		published, ok := parseImportBool(*row.Published)
+		if !ok {
+			messages = append(messages, "invalid published, expected true or false")
+		}
+		item.Product.IsPublished = published
+	}
+
+	// Пустые изображения при обновлении не меняются
+	if row.ImagePreview != nil && *row.ImagePreview != "" {
+		item.ImagePreview = *row.ImagePreview
+		err := uc.checkImageSource(item.ImagePreview, archive)
+		if err != nil {
+			addError(err)
+		}
+	} else if isCreate {
+		messages = append(messages, "image_preview is required")
+	}
+
+	if row.Images != nil && *row.Images != "" {
+		item.Images = lo.Uniq(lo.Compact(lo.Map(strings.Split(*row.Images, productImportImagesSeparator), func(value string, _ int) string {
+			return strings.TrimSpace(value)
+		})))
+		for _, source := range item.Images {
+			err := uc.checkImageSource(source, archive)
+			if err != nil {
+				addError(err)
+			}
+		}
+	}
+
+	if isCreate && len(item.Images) == 0 {
+		messages = append(messages, "images is required")
+	}
+
+	return item, messages
+}
+
+func (uc *ProductImportInpl) saveItem(ctx context.Context, item *productImportItem, archive *zip.Reader, actorID *uuid.UUID) error {
+	if item.Current == nil {
+		previewFileID, err := uc.uploadImage(ctx, domain.FileTargetProductPreview, item.ImagePreview, nil, archive)
+		if err != nil {
+			return err
+		}
+
+		sliderFilesIDs, err := uc.uploadSliderImages(ctx, item.Images, nil, archive)
+		if err != nil {
+			return err
+		}
+
+		item.Product.ImagePreviewFileID = &previewFileID
+
+		_, _, err = uc.productUC.Create(ctx, ProductCreateIn{
+			Product:        item.Product,
+			SliderFilesIDs: sliderFilesIDs,
+			ActorID:        actorID,
+		})
+
+		return err
+	}
+
+	current := item.Current
+
+	updateIn := ProductUpdateIn{
+		Version:            current.Product.Version,
+		Name:               item.Product.Name,
+		SKU:                item.Product.SKU,
+		IsPublished:        item.Product.IsPublished,
+		PublishAt:          current.Product.PublishAt,
+		UnpublishAt:        current.Product.UnpublishAt,
+		FullDescription:    item.Product.FullDescription,
+		Price:              item.Product.Price,
+		TaxRate:            item.Product.TaxRate,
+		ImagePreviewFileID: current.Product.ImagePreviewFileID,
+		SliderFilesIDs: lo.Map(current.SliderFiles, func(file *domain.File, _ int) uuid.UUID {
+			return file.ID
+		}),
+		CategoryIDs:       current.CategoryIDs,
+		Slug:              item.Product.Slug,
+		LowStockThreshold: current.Product.LowStockThreshold,
+		ActorID:           actorID,
+	}
+
+	if item.ImagePreview != "" {
+		previewFiles := []*domain.File{}
+		if current.ProductPreviewFile != nil {
+			previewFiles = append(previewFiles, current.ProductPreviewFile)
+		}
+
+		previewFileID, err := uc.uploadImage(ctx, domain.FileTargetProductPreview, item.ImagePreview, previewFiles, archive)
+		if err != nil {
+			return err
+		}
+		updateIn.ImagePreviewFileID = &previewFileID
+	}
+
+	if len(item.Images) > 0 {
+		sliderFilesIDs, err := uc.uploadSliderImages(ctx, item.Images, current.SliderFiles, archive)
+		if err != nil {
+			return err
+		}
+		updateIn.SliderFilesIDs = sliderFilesIDs
+	}
+
+	// Данные и остаток строки сохраняются вместе, чтобы строка не применилась наполовину
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		_, _, err := uc.productUC.Update(ctx, current.Product.ID, updateIn)
+		if err != nil {
+			return err
+		}
+
+		if item.StockDelta != 0 {
+			err = uc.productUC.ChangeStock(ctx, current.Product.ID, 0, int32(lo.Ternary(item.StockDelta > 0, item.StockDelta, -item.StockDelta)), item.StockDelta > 0, actorID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (uc *ProductImportInpl) uploadSliderImages(ctx context.Context, sources []string, currentFiles []*domain.File, archive *zip.Reader) ([]uuid.UUID, error) {
+	result := make([]uuid.UUID, 0, len(sources))
+
+	for _, source := range sources {
+		fileID, err := uc.uploadImage(ctx, domain.FileTargetProductSlider, source, currentFiles, archive)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, fileID)
+	}
+
+	return result, nil
+}
+
+// Изображение со ссылкой на уже загруженный файл товара (например, из экспорта) повторно не загружается.
+// Файлы, не привязанные к товару из-за ошибки сохранения, удалит задача очистки
+func (uc *ProductImportInpl) uploadImage(ctx context.Context, target domain.FileTarget, source string, currentFiles []*domain.File, archive *zip.Reader) (uuid.UUID, error) {
+	for _, file := range currentFiles {
+		if file.GetURL(&uc.config) == source {
+			return file.ID, nil
+		}
+	}
+
+	data, err := uc.loadImage(ctx, source, archive)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	file, err := uc.fileUC.UploadImageFile(ctx, target, path.Base(source), data)
+	if err != nil {
+		if isAppErr, appErr := e.IsAppError(err); isAppErr {
+			return uuid.Nil, e.NewErrorFrom(appErr).SetMessage(fmt.Sprintf("%s: %s", appErr.Message(), source))
+		}
+		return uuid.Nil, err
+	}
+
+	return file.ID, nil
+}
+
+func isImportImageURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+func (uc *ProductImportInpl) checkImageSource(source string, archive *zip.Reader) error {
+	if isImportImageURL(source) {
+		u, err := url.ParseRequestURI(source)
+		if err != nil || u.Host == "" {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("invalid image url: %s", source))
+		}
+
+		// Имя хоста проверяется только при загрузке, адрес в ссылке можно отклонить сразу
+		if ip := net.ParseIP(u.Hostname()); ip != nil && !isImportPublicIP(ip) {
+			return e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("image url host is not allowed: %s", source))
+		}
+
+		return nil
+	}
+
+	_, err := findImportArchiveFile(archive, source)
+
+	return err
+}
+
+func (uc *ProductImportInpl) loadImage(ctx context.Context, source string, archive *zip.Reader) ([]byte, error) {
+	maxSize := int64(uc.config.Import.MaxImageSizeMB) * 1024 * 1024
+
+	var reader io.ReadCloser
+
+	if isImportImageURL(source) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			return nil, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage(fmt.Sprintf("invalid image url: %s", source))
+		}
+
+		resp, err := uc.httpClient.Do(req)
+		if err != nil {
+			return nil, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage(fmt.Sprintf("cannot download image: %s", source))
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("cannot download image, status %d: %s", resp.StatusCode, source))
+		}
+
+		reader = resp.Body
+	} else {
+		file, err := findImportArchiveFile(archive, source)
+		if err != nil {
+			return nil, err
+		}
+
+		reader, err = file.Open()
+		if err != nil {
+			return nil, e.NewErrorFrom(ErrProductImportInvalidArchive).Wrap(err)
+		}
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, e.NewErrorFrom(e.ErrBadRequest).Wrap(err).SetMessage(fmt.Sprintf("cannot read image: %s", source))
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("image size must be no more than %d MB: %s", uc.config.Import.MaxImageSizeMB, source))
+	}
+
+	return data, nil
+}
+
+func findImportArchiveFile(archive *zip.Reader, source string) (*zip.File, error) {
+	if archive == nil {
+		return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("images archive is required for path: %s", source))
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(source, "\\", "/")), "/")
+	for _, file := range archive.File {
+		if file.Name == name {
+			return file, nil
+		}
+	}
+
+	return nil, e.NewErrorFrom(e.ErrBadRequest).SetMessage(fmt.Sprintf("file not found in images archive: %s", source))
+}
+
+func (uc *ProductImportInpl) Export(ctx context.Context, format sheet.Format) ([]byte, error) {
+	list, err := uc.productUC.FindFullList(ctx, ProductListOptions{
+		Sort: &[]ProductListSort{
+			{Field: ProductListSortFieldID},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := lo.Map(list, func(item *ProductFullOut, _ int) int64 {
+		return item.Product.ID
+	})
+
+	sliderImages, err := uc.productSliderImageUC.FindSliderImagesForProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	filesIDs := make([]uuid.UUID, 0)
+	for _, images := range sliderImages {
+		for _, image := range images {
+			filesIDs = append(filesIDs, image.FileID)
+		}
+	}
+
+	files, err := uc.fileUC.FindListInMap(ctx, FileListOptions{
+		IDs: &filesIDs,
+	}, &uctypes.QueryGetListParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(list)+1)
+	rows = append(rows, productImportColumns)
+
+	for _, item := range list {
+		product := item.Product
+
+		taxRate := ""
+		if product.TaxRate != nil {
+			taxRate = product.TaxRate.String()
+		}
+
+		imagePreview := ""
+		if item.ProductPreviewFile != nil {
+			imagePreview = item.ProductPreviewFile.GetURL(&uc.config)
+		}
+
+		images := make([]string, 0, len(sliderImages[product.ID]))
+		for _, image := range sliderImages[product.ID] {
+			if file, ok := files[image.FileID]; ok {
+				images = append(images, file.GetURL(&uc.config))
+			}
+		}
+
+		rows = append(rows, []string{
+			product.SKU,
+			product.Name,
+			product.Slug,
+			product.FullDescription,
+			product.Price.String(),
+			taxRate,
+			strconv.FormatInt(int64(product.StockAvailable), 10),
+			strconv.FormatBool(product.IsPublished),
+			imagePreview,
+			strings.Join(images, productImportImagesSeparator),
+		})
+	}
+
+	return sheet.Write(format, rows)
+}
+
+// Excel с русской локалью сохраняет дробные числа через запятую
+func parseImportDecimal(value string) (decimal.Decimal, error) {
+	return decimal.NewFromString(strings.ReplaceAll(value, ",", "."))
+}
+
+func parseImportBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "1", "true", "yes", "да":
+		return true, true
+	case "0", "false", "no", "нет":
+		return false, true
+	}
+
+	return false, false
+}
+
+func errorMessage(err error) string {
+	if isAppErr, appErr := e.IsAppError(err); isAppErr {
+		return appErr.Message()
+	}
+
+	return err.Error()
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
@@ -11,12 +12,17 @@ import (
 )
 
 type ProductOrderBlockListOptions struct {
-	ProductID *int64
-	OrderID   *int64
+	ProductID   *int64
+	VariantID   *int64
+	OrderID     *int64
+	WarehouseID *int64
+	// Брони со сроком, истекшим к указанному моменту
+	ExpiresAtTo *time.Time
 }
 
 type ProductOrderBlockComposition struct {
 	ProductID int64
+	VariantID int64
 	Quantity  int32
 }
 
@@ -27,6 +33,9 @@ type ProductOrderBlock interface {
 	ClearBlocksForOrder(ctx context.Context, orderID int64) (err error)
 	GetOrderBlockedProducts(ctx context.Context, orderID int64) (items []*domain.ProductOrderBlock, err error)
 	CheckBlockForProduct(ctx context.Context, productID int64) (result bool, err error)
+	CheckBlockForVariant(ctx context.Context, variantID int64) (result bool, err error)
+	FindExpiredOrderIDs(ctx context.Context, now time.Time, offset uint64, limit uint64) (orderIDs []int64, err error)
+	SetExpiresAtForOrder(ctx context.Context, orderID int64, expiresAt *time.Time) (err error)
 }
 
 //go:generate mockery --name=ProductOrderBlockRepository --output=../../tests/mocks --case=underscore
@@ -34,6 +43,8 @@ type ProductOrderBlockRepository interface {
 	FindList(ctx context.Context, listOptions ProductOrderBlockListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductOrderBlock, err error)
 	Create(ctx context.Context, item *domain.ProductOrderBlock) (err error)
 	DeleteByList(ctx context.Context, listOptions ProductOrderBlockListOptions) (err error)
+	FindOrderIDs(ctx context.Context, listOptions ProductOrderBlockListOptions, offset uint64, limit uint64) (orderIDs []int64, err error)
+	UpdateExpiresAtByOrderID(ctx context.Context, orderID int64, expiresAt *time.Time) (err error)
 }
 
 type ProductOrderBlockInpl struct {
@@ -67,10 +78,31 @@ func (uc *ProductOrderBlockInpl) ClearBlocksForOrder(ctx context.Context, orderI
 	})
 }
 
+// Позиция, забронированная на нескольких складах, возвращается одной записью с суммарным количеством
 func (uc *ProductOrderBlockInpl) GetOrderBlockedProducts(ctx context.Context, orderID int64) ([]*domain.ProductOrderBlock, error) {
-	return uc.repo.FindList(ctx, ProductOrderBlockListOptions{
+	blocks, err := uc.repo.FindList(ctx, ProductOrderBlockListOptions{
 		OrderID: &orderID,
 	}, &uctypes.QueryGetListParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.ProductOrderBlock, 0, len(blocks))
+	index := make(map[[2]int64]*domain.ProductOrderBlock, len(blocks))
+
+	for _, block := range blocks {
+		key := [2]int64{block.ProductID, block.VariantID}
+
+		if item, ok := index[key]; ok {
+			item.Quantity += block.Quantity
+			continue
+		}
+
+		index[key] = block
+		result = append(result, block)
+	}
+
+	return result, nil
 }
 
 func (uc *ProductOrderBlockInpl) CheckBlockForProduct(ctx context.Context, productID int64) (bool, error) {
@@ -89,3 +121,26 @@ func (uc *ProductOrderBlockInpl) CheckBlockForProduct(ctx context.Context, produ
 
 	return false, nil
 }
+
+func (uc *ProductOrderBlockInpl) CheckBlockForVariant(ctx context.Context, variantID int64) (bool, error) {
+	check, err := uc.repo.FindList(ctx, ProductOrderBlockListOptions{
+		VariantID: &variantID,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(check) > 0, nil
+}
+
+func (uc *ProductOrderBlockInpl) FindExpiredOrderIDs(ctx context.Context, now time.Time, offset uint64, limit uint64) ([]int64, error) {
+	return uc.repo.FindOrderIDs(ctx, ProductOrderBlockListOptions{
+		ExpiresAtTo: &now,
+	}, offset, limit)
+}
+
+func (uc *ProductOrderBlockInpl) SetExpiresAtForOrder(ctx context.Context, orderID int64, expiresAt *time.Time) error {
+	return uc.repo.UpdateExpiresAtByOrderID(ctx, orderID, expiresAt)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+)
+
+type ProductOrderConsumptionListOptions struct {
+	ProductID   *int64
+	VariantID   *int64
+	OrderID     *int64
+	WarehouseID *int64
+}
+
+//go:generate mockery --name=ProductOrderConsumption --output=../../tests/mocks --case=underscore
+type ProductOrderConsumption interface {
+	FindList(ctx context.Context, listOptions ProductOrderConsumptionListOptions, queryParams *uctypes.QueryGetListParams) (out []*domain.ProductOrderConsumption, err error)
+	Create(ctx context.Context, item *domain.ProductOrderConsumption) (err error)
+	CheckConsumptionForOrder(ctx context.Context, orderID int64) (result bool, err error)
+	CheckConsumptionForProduct(ctx context.Context, productID int64) (result bool, err error)
+}
+
+//go:generate mockery --name=ProductOrderConsumptionRepository --output=../../tests/mocks --case=underscore
+type ProductOrderConsumptionRepository interface {
+	FindList(ctx context.Context, listOptions ProductOrderConsumptionListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductOrderConsumption, err error)
+	Create(ctx context.Context, item *domain.ProductOrderConsumption) (err error)
+}
+
+type ProductOrderConsumptionInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductOrderConsumptionRepository
+	txManager *manager.Manager
+}
+
+func NewProductOrderConsumptionInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductOrderConsumptionRepository) *ProductOrderConsumptionInpl {
+	uc := &ProductOrderConsumptionInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *ProductOrderConsumptionInpl) FindList(ctx context.Context, listOptions ProductOrderConsumptionListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductOrderConsumption, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *ProductOrderConsumptionInpl) Create(ctx context.Context, item *domain.ProductOrderConsumption) error {
+	return uc.repo.Create(ctx, item)
+}
+
+func (uc *ProductOrderConsumptionInpl) CheckConsumptionForOrder(ctx context.Context, orderID int64) (bool, error) {
+	check, err := uc.repo.FindList(ctx, ProductOrderConsumptionListOptions{
+		OrderID: &orderID,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(check) > 0, nil
+}
+
+func (uc *ProductOrderConsumptionInpl) CheckConsumptionForProduct(ctx context.Context, productID int64) (bool, error) {
+	check, err := uc.repo.FindList(ctx, ProductOrderConsumptionListOptions{
+		ProductID: &productID,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(check) > 0, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+)
+
+type ProductPriceHistoryListOptions struct {
+	ProductID *int64
+	Reason    *domain.ProductPriceChangeReason
+}
+
+//go:generate mockery --name=ProductPriceHistory --output=../../tests/mocks --case=underscore
+type ProductPriceHistory interface {
+	FindPagedList(ctx context.Context, listOptions ProductPriceHistoryListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductPriceHistory, total int64, err error)
+	Add(ctx context.Context, item *domain.ProductPriceHistory) (err error)
+}
+
+//go:generate mockery --name=ProductPriceHistoryRepository --output=../../tests/mocks --case=underscore
+type ProductPriceHistoryRepository interface {
+	FindPagedList(ctx context.Context, listOptions ProductPriceHistoryListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductPriceHistory, total int64, err error)
+	Create(ctx context.Context, item *domain.ProductPriceHistory) (err error)
+}
+
+type ProductPriceHistoryInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductPriceHistoryRepository
+	txManager *manager.Manager
+}
+
+func NewProductPriceHistoryInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductPriceHistoryRepository) *ProductPriceHistoryInpl {
+	uc := &ProductPriceHistoryInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *ProductPriceHistoryInpl) FindPagedList(ctx context.Context, listOptions ProductPriceHistoryListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductPriceHistory, int64, error) {
+	return uc.repo.FindPagedList(ctx, listOptions, queryParams)
+}
+
+// Запись добавляется в транзакции изменения цены из контекста
+func (uc *ProductPriceHistoryInpl) Add(ctx context.Context, item *domain.ProductPriceHistory) error {
+	return uc.repo.Create(ctx, item)
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/shopspring/decimal"
+)
+
+var ErrProductPriceScheduleOverlaps = e.NewErrorFrom(e.ErrConflict).SetMessage("price schedule overlaps with another schedule of the product")
+
+type ProductPriceScheduleListOptions struct {
+	ProductID *int64
+	Statuses  *[]domain.ProductPriceScheduleStatus
+	// Начало действия не позже указанного времени
+	StartsAtTo *time.Time
+	// Окончание действия не позже указанного времени
+	EndsAtTo *time.Time
+}
+
+type ProductPriceScheduleCreateIn struct {
+	ProductID int64
+	Price     decimal.Decimal
+	StartsAt  time.Time
+	EndsAt    *time.Time
+	ActorID   *uuid.UUID
+}
+
+//go:generate mockery --name=ProductPriceSchedule --output=../../tests/mocks --case=underscore
+type ProductPriceSchedule interface {
+	FindList(ctx context.Context, listOptions ProductPriceScheduleListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductPriceSchedule, err error)
+	Create(ctx context.Context, input ProductPriceScheduleCreateIn) (item *domain.ProductPriceSchedule, err error)
+	Cancel(ctx context.Context, productID int64, id int64) (item *domain.ProductPriceSchedule, err error)
+	TaskApplySchedules(ctx context.Context) (err error)
+}
+
+//go:generate mockery --name=ProductPriceScheduleRepository --output=../../tests/mocks --case=underscore
+type ProductPriceScheduleRepository interface {
+	FindList(ctx context.Context, listOptions ProductPriceScheduleListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductPriceSchedule, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.ProductPriceSchedule, err error)
+	Create(ctx context.Context, item *domain.ProductPriceSchedule) (err error)
+	Update(ctx context.Context, item *domain.ProductPriceSchedule) (err error)
+}
+
+type ProductPriceScheduleInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductPriceScheduleRepository
+	txManager *manager.Manager
+	productUC Product
+}
+
+func NewProductPriceScheduleInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductPriceScheduleRepository, productUC Product) *ProductPriceScheduleInpl {
+	uc := &ProductPriceScheduleInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+		productUC: productUC,
+	}
+	return uc
+}
+
+func (uc *ProductPriceScheduleInpl) FindList(ctx context.Context, listOptions ProductPriceScheduleListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductPriceSchedule, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+// У товара не может быть двух ожидающих или действующих цен с пересекающимися периодами
+func (uc *ProductPriceScheduleInpl) Create(ctx context.Context, input ProductPriceScheduleCreateIn) (*domain.ProductPriceSchedule, error) {
+	item, err := domain.NewProductPriceSchedule(input.ProductID, input.StartsAt, input.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = item.SetPrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	item.ActorID = input.ActorID
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		products, err := uc.productUC.FindFullList(ctx, ProductListOptions{
+			IDs: &[]int64{input.ProductID},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		if len(products) == 0 {
+			return e.ErrNotFound
+		}
+
+		current, err := uc.repo.FindList(ctx, ProductPriceScheduleListOptions{
+			ProductID: &input.ProductID,
+			Statuses: &[]domain.ProductPriceScheduleStatus{
+				domain.ProductPriceScheduleStatusScheduled,
+				domain.ProductPriceScheduleStatusActive,
+			},
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, other := range current {
+			if item.Overlaps(other) {
+				return ErrProductPriceScheduleOverlaps
+			}
+		}
+
+		return uc.repo.Create(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Отмена ожидающей цены, действующая цена при отмене сразу возвращается
+func (uc *ProductPriceScheduleInpl) Cancel(ctx context.Context, productID int64, id int64) (*domain.ProductPriceSchedule, error) {
+	var item *domain.ProductPriceSchedule
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		item, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if item.ProductID != productID {
+			return e.ErrNotFound
+		}
+
+		wasActive := item.Status == domain.ProductPriceScheduleStatusActive
+
+		err = item.Cancel()
+		if err != nil {
+			return err
+		}
+
+		if wasActive {
+			err = uc.productUC.RevertPriceSchedule(ctx, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return uc.repo.Update(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Окончание действующих и применение наступивших цен. Ошибка по одному расписанию не прерывает обработку остальных,
+// расписание будет обработано при следующем запуске
+func (uc *ProductPriceScheduleInpl) TaskApplySchedules(ctx context.Context) error {
+	now := time.Now()
+
+	expired, err := uc.repo.FindList(ctx, ProductPriceScheduleListOptions{
+		Statuses: &[]domain.ProductPriceScheduleStatus{domain.ProductPriceScheduleStatusActive},
+		EndsAtTo: &now,
+	}, nil)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "find expired price schedules", slog.Any("error", err))
+		return err
+	}
+
+	for _, item := range expired {
+		err = uc.processSchedule(ctx, item.ID, now)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "finish price schedule", slog.Any("error", err), slog.Int64("schedule_id", item.ID))
+		}
+	}
+
+	started, err := uc.repo.FindList(ctx, ProductPriceScheduleListOptions{
+		Statuses:   &[]domain.ProductPriceScheduleStatus{domain.ProductPriceScheduleStatusScheduled},
+		StartsAtTo: &now,
+	}, nil)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "find started price schedules", slog.Any("error", err))
+		return err
+	}
+
+	for _, item := range started {
+		err = uc.processSchedule(ctx, item.ID, now)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "apply price schedule", slog.Any("error", err), slog.Int64("schedule_id", item.ID))
+		}
+	}
+
+	return nil
+}
+
+func (uc *ProductPriceScheduleInpl) processSchedule(ctx context.Context, id int64, now time.Time) error {
+	return uc.txManager.Do(ctx, func(ctx context.Context) error {
+		item, err := uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case item.Status == domain.ProductPriceScheduleStatusActive && item.IsExpired(now):
+			err = uc.productUC.RevertPriceSchedule(ctx, item)
+			item.Finish()
+		case item.Status == domain.ProductPriceScheduleStatusScheduled && item.IsExpired(now):
+			// Период целиком пропущен, например задача не запускалась
+			item.Finish()
+		case item.Status == domain.ProductPriceScheduleStatusScheduled && !item.StartsAt.After(now):
+			err = uc.productUC.ApplyPriceSchedule(ctx, item)
+		default:
+			return nil
+		}
+
+		if err != nil {
+			if !errors.Is(err, e.ErrNotFound) {
+				return err
+			}
+
+			// Товар удален, цену применять некуда
+			_ = item.Cancel()
+		}
+
+		return uc.repo.Update(ctx, item)
+	})
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/e"
+	ordersgcl "github.com/m11ano/mipt-webdev-course/backend/services/products/internal/clients/grpc/orders"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+const productPurgeBatchSize = 100
+
+//go:generate mockery --name=ProductPurge --output=../../tests/mocks --case=underscore
+type ProductPurge interface {
+	TaskPurgeDeletedProducts(ctx context.Context, maxDeletedAt time.Time) (err error)
+}
+
+type ProductPurgeInpl struct {
+	logger         *slog.Logger
+	config         config.Config
+	txManager      *manager.Manager
+	productUC      Product
+	productDraftUC ProductDraft
+	fileUC         File
+	ordersGCl      *ordersgcl.ClientConn
+}
+
+func NewProductPurgeInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, productUC Product, productDraftUC ProductDraft, fileUC File, ordersGCl *ordersgcl.ClientConn) *ProductPurgeInpl {
+	uc := &ProductPurgeInpl{
+		logger:         logger,
+		config:         config,
+		txManager:      txManager,
+		productUC:      productUC,
+		productDraftUC: productDraftUC,
+		fileUC:         fileUC,
+		ordersGCl:      ordersGCl,
+	}
+	return uc
+}
+
+// Окончательное удаление товаров, удаленных раньше maxDeletedAt. Товары с заказами остаются в архиве
+func (uc *ProductPurgeInpl) TaskPurgeDeletedProducts(ctx context.Context, maxDeletedAt time.Time) error {
+
+	// Пропущенные товары остаются в начале выборки, поэтому смещение равно их количеству
+	skipped := uint64(0)
+
+	for {
+		items, err := uc.productUC.FindList(ctx, ProductListOptions{
+			IsDeleted:   lo.ToPtr(true),
+			DeletedAtTo: &maxDeletedAt,
+			Sort: &[]ProductListSort{
+				{
+					Field: ProductListSortFieldID,
+				},
+			},
+		}, &uctypes.QueryGetListParams{
+			Limit:       productPurgeBatchSize,
+			Offset:      skipped,
+			WithDeleted: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			break
+		}
+
+		for _, item := range items {
+			isPurged, err := uc.purgeProduct(ctx, item.ID)
+			if err != nil {
+				uc.logger.ErrorContext(ctx, "purge deleted product", slog.Int64("product_id", item.ID), slog.Any("error", err))
+			}
+
+			if !isPurged {
+				skipped++
+			}
+		}
+	}
+
+	return nil
+}
+
+func (uc *ProductPurgeInpl) purgeProduct(ctx context.Context, productID int64) (bool, error) {
+
+	// Заказы хранят снимок товара, но ссылаются на его ID, поэтому такие товары не удаляем
+	hasOrders, err := uc.ordersGCl.Client.CheckOrdersExistsByProductID(ctx, productID)
+	if err != nil {
+		return false, err
+	}
+
+	if hasOrders {
+		return false, nil
+	}
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		draft, err := uc.productDraftUC.FindOneByProductID(ctx, productID)
+		if err != nil && !errors.Is(err, e.ErrNotFound) {
+			return err
+		}
+
+		err = uc.productUC.Purge(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		// Черновик удаляется каскадно, его изображения помечаем к удалению из хранилища
+		if draft != nil {
+			err = uc.fileUC.DeleteFilesByIDs(ctx, draft.FilesIDs())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrProductAlreadyHasOrders) || errors.Is(err, ErrProductHasStockMovements) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
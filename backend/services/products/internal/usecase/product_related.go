@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+var ErrProductRelatedSelf = e.NewErrorFrom(e.ErrBadRequest).SetMessage("product cannot be related to itself")
+var ErrProductRelatedProductInvalid = e.NewErrorFrom(e.ErrBadRequest).SetMessage("invalid related product id")
+
+type ProductRelatedListOptions struct {
+	ProductID        *int64
+	ProductIDs       *[]int64
+	RelatedProductID *int64
+}
+
+//go:generate mockery --name=ProductRelated --output=../../tests/mocks --case=underscore
+type ProductRelated interface {
+	FindRelatedIDsForProduct(ctx context.Context, productID int64) (ids []int64, err error)
+	FindRelatedFullForProduct(ctx context.Context, productID int64, visibleAt *time.Time) (out []*ProductFullOut, err error)
+	SaveRelatedForProduct(ctx context.Context, productID int64, relatedProductIDs []int64) (items []*domain.ProductRelated, err error)
+}
+
+//go:generate mockery --name=ProductRelatedRepository --output=../../tests/mocks --case=underscore
+type ProductRelatedRepository interface {
+	FindList(ctx context.Context, listOptions ProductRelatedListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductRelated, err error)
+	Create(ctx context.Context, item *domain.ProductRelated) (err error)
+	DeleteByList(ctx context.Context, listOptions ProductRelatedListOptions) (err error)
+}
+
+type ProductRelatedInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductRelatedRepository
+	txManager *manager.Manager
+	productUC Product
+}
+
+func NewProductRelatedInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductRelatedRepository, productUC Product) *ProductRelatedInpl {
+	uc := &ProductRelatedInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+		productUC: productUC,
+	}
+	return uc
+}
+
+func (uc *ProductRelatedInpl) FindRelatedIDsForProduct(ctx context.Context, productID int64) ([]int64, error) {
+	items, err := uc.repo.FindList(ctx, ProductRelatedListOptions{
+		ProductID: &productID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(items, func(item *domain.ProductRelated, _ int) int64 {
+		return item.RelatedProductID
+	}), nil
+}
+
+// Сопутствующие товары в заданном порядке. Если передан visibleAt, то только опубликованные на этот момент
+func (uc *ProductRelatedInpl) FindRelatedFullForProduct(ctx context.Context, productID int64, visibleAt *time.Time) ([]*ProductFullOut, error) {
+	ids, err := uc.FindRelatedIDsForProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findProductsFullByIDsInOrder(ctx, uc.productUC, ids, visibleAt)
+}
+
+// Заменяет список сопутствующих товаров, порядок в списке - порядок вывода
+func (uc *ProductRelatedInpl) SaveRelatedForProduct(ctx context.Context, productID int64, relatedProductIDs []int64) ([]*domain.ProductRelated, error) {
+
+	relatedProductIDs = lo.Uniq(relatedProductIDs)
+
+	if lo.Contains(relatedProductIDs, productID) {
+		return nil, ErrProductRelatedSelf
+	}
+
+	result := make([]*domain.ProductRelated, 0, len(relatedProductIDs))
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		products, err := uc.productUC.FindList(ctx, ProductListOptions{
+			IDs: lo.ToPtr(append([]int64{productID}, relatedProductIDs...)),
+		}, &uctypes.QueryGetListParams{
+			ForShare: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		existsIDs := lo.SliceToMap(products, func(item *domain.Product) (int64, struct{}) {
+			return item.ID, struct{}{}
+		})
+
+		if _, ok := existsIDs[productID]; !ok {
+			return e.NewErrorFrom(e.ErrNotFound)
+		}
+
+		for _, relatedProductID := range relatedProductIDs {
+			if _, ok := existsIDs[relatedProductID]; !ok {
+				return ErrProductRelatedProductInvalid
+			}
+		}
+
+		err = uc.repo.DeleteByList(ctx, ProductRelatedListOptions{
+			ProductID: &productID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, relatedProductID := range relatedProductIDs {
+			item := domain.NewProductRelated(productID, relatedProductID, int32(i))
+
+			err = uc.repo.Create(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Товары по списку ID в порядке списка, удаленные и (при переданном visibleAt) неопубликованные пропускаются
+func findProductsFullByIDsInOrder(ctx context.Context, productUC Product, ids []int64, visibleAt *time.Time) ([]*ProductFullOut, error) {
+	if len(ids) == 0 {
+		return []*ProductFullOut{}, nil
+	}
+
+	items, err := productUC.FindFullList(ctx, ProductListOptions{
+		IDs:       &ids,
+		VisibleAt: visibleAt,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsMap := lo.SliceToMap(items, func(item *ProductFullOut) (int64, *ProductFullOut) {
+		return item.Product.ID, item
+	})
+
+	result := make([]*ProductFullOut, 0, len(items))
+	for _, id := range ids {
+		if item, ok := itemsMap[id]; ok {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+)
+
+type ProductReturnRestockListOptions struct {
+	ProductID   *int64
+	VariantID   *int64
+	ReturnID    *int64
+	WarehouseID *int64
+}
+
+type ProductReturnRestockComposition struct {
+	ProductID int64
+	VariantID int64
+	Quantity  int32
+}
+
+//go:generate mockery --name=ProductReturnRestock --output=../../tests/mocks --case=underscore
+type ProductReturnRestock interface {
+	FindList(ctx context.Context, listOptions ProductReturnRestockListOptions, queryParams *uctypes.QueryGetListParams) (out []*domain.ProductReturnRestock, err error)
+	Create(ctx context.Context, item *domain.ProductReturnRestock) (err error)
+	ClearRestocksForReturn(ctx context.Context, returnID int64) (err error)
+}
+
+//go:generate mockery --name=ProductReturnRestockRepository --output=../../tests/mocks --case=underscore
+type ProductReturnRestockRepository interface {
+	FindList(ctx context.Context, listOptions ProductReturnRestockListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductReturnRestock, err error)
+	Create(ctx context.Context, item *domain.ProductReturnRestock) (err error)
+	DeleteByList(ctx context.Context, listOptions ProductReturnRestockListOptions) (err error)
+}
+
+type ProductReturnRestockInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductReturnRestockRepository
+	txManager *manager.Manager
+}
+
+func NewProductReturnRestockInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductReturnRestockRepository) *ProductReturnRestockInpl {
+	uc := &ProductReturnRestockInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *ProductReturnRestockInpl) FindList(ctx context.Context, listOptions ProductReturnRestockListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductReturnRestock, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *ProductReturnRestockInpl) Create(ctx context.Context, item *domain.ProductReturnRestock) error {
+	return uc.repo.Create(ctx, item)
+}
+
+func (uc *ProductReturnRestockInpl) ClearRestocksForReturn(ctx context.Context, returnID int64) error {
+	return uc.repo.DeleteByList(ctx, ProductReturnRestockListOptions{
+		ReturnID: &returnID,
+	})
+}
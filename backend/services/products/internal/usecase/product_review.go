@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/m11ano/e"
+	orderscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/orders"
+	ordersgcl "github.com/m11ano/mipt-webdev-course/backend/services/products/internal/clients/grpc/orders"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+var ErrProductReviewOrderNotFinished = e.NewErrorFrom(e.ErrForbidden).SetMessage("order is not finished")
+var ErrProductReviewProductNotInOrder = e.NewErrorFrom(e.ErrForbidden).SetMessage("product is not in order")
+var ErrProductReviewAlreadyExists = e.NewErrorFrom(e.ErrConflict).SetMessage("review for this product and order already exists")
+
+type ProductReviewListOptions struct {
+	ProductID *int64
+	OrderID   *int64
+	Status    *domain.ProductReviewStatus
+}
+
+type ProductReviewCreateIn struct {
+	ProductID  int64
+	OrderID    int64
+	SecretKey  uuid.UUID
+	AuthorName string
+	Rating     int16
+	Text       string
+}
+
+//go:generate mockery --name=ProductReview --output=../../tests/mocks --case=underscore
+type ProductReview interface {
+	FindPagedList(ctx context.Context, listOptions ProductReviewListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductReview, total int64, err error)
+	FindRatingsInMap(ctx context.Context, productIDs []int64) (out map[int64]*domain.ProductRating, err error)
+	Create(ctx context.Context, input ProductReviewCreateIn) (item *domain.ProductReview, err error)
+	SetStatus(ctx context.Context, id int64, status domain.ProductReviewStatus, moderatorID uuid.UUID) (item *domain.ProductReview, err error)
+	Delete(ctx context.Context, id int64) (err error)
+}
+
+//go:generate mockery --name=ProductReviewRepository --output=../../tests/mocks --case=underscore
+type ProductReviewRepository interface {
+	FindPagedList(ctx context.Context, listOptions ProductReviewListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductReview, total int64, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.ProductReview, err error)
+	FindRatingsByProducts(ctx context.Context, productIDs []int64) (items []*domain.ProductRating, err error)
+	Create(ctx context.Context, item *domain.ProductReview) (err error)
+	Update(ctx context.Context, item *domain.ProductReview) (err error)
+	Delete(ctx context.Context, id int64) (err error)
+}
+
+type ProductReviewInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductReviewRepository
+	txManager *manager.Manager
+	ordersGCl *ordersgcl.ClientConn
+}
+
+func NewProductReviewInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductReviewRepository, ordersGCl *ordersgcl.ClientConn) *ProductReviewInpl {
+	uc := &ProductReviewInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+		ordersGCl: ordersGCl,
+	}
+	return uc
+}
+
+func (uc *ProductReviewInpl) FindPagedList(ctx context.Context, listOptions ProductReviewListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductReview, int64, error) {
+	return uc.repo.FindPagedList(ctx, listOptions, queryParams)
+}
+
+// Рейтинг по товарам, у товаров без одобренных отзывов записи в карте нет
+func (uc *ProductReviewInpl) FindRatingsInMap(ctx context.Context, productIDs []int64) (map[int64]*domain.ProductRating, error) {
+	items, err := uc.repo.FindRatingsByProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.SliceToMap(items, func(item *domain.ProductRating) (int64, *domain.ProductRating) {
+		return item.ProductID, item
+	}), nil
+}
+
+// Отзыв может оставить только покупатель выполненного заказа, в котором есть товар.
+// Покупатель подтверждается секретным ключом заказа
+func (uc *ProductReviewInpl) Create(ctx context.Context, input ProductReviewCreateIn) (*domain.ProductReview, error) {
+	item := domain.NewProductReview(input.ProductID, input.OrderID)
+
+	if err := item.SetAuthorName(input.AuthorName); err != nil {
+		return nil, err
+	}
+
+	if err := item.SetRating(input.Rating); err != nil {
+		return nil, err
+	}
+
+	if err := item.SetText(input.Text); err != nil {
+		return nil, err
+	}
+
+	order, err := uc.ordersGCl.Client.GetOrderWithSecretKey(ctx, input.OrderID, input.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.CanReview {
+		return nil, ErrProductReviewOrderNotFinished
+	}
+
+	_, inOrder := lo.Find(order.Products, func(product orderscl.OrderProductRef) bool {
+		return product.ProductID == input.ProductID
+	})
+	if !inOrder {
+		return nil, ErrProductReviewProductNotInOrder
+	}
+
+	_, total, err := uc.repo.FindPagedList(ctx, ProductReviewListOptions{
+		ProductID: &input.ProductID,
+		OrderID:   &input.OrderID,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if total > 0 {
+		return nil, ErrProductReviewAlreadyExists
+	}
+
+	err = uc.repo.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (uc *ProductReviewInpl) SetStatus(ctx context.Context, id int64, status domain.ProductReviewStatus, moderatorID uuid.UUID) (*domain.ProductReview, error) {
+	var item *domain.ProductReview
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		item, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := item.SetStatus(status, moderatorID); err != nil {
+			return err
+		}
+
+		return uc.repo.Update(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (uc *ProductReviewInpl) Delete(ctx context.Context, id int64) error {
+	_, err := uc.repo.FindOneByID(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.Delete(ctx, id)
+}
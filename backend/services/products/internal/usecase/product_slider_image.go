@@ -13,13 +13,15 @@ import (
 )
 
 type ProductSliderImageListOptions struct {
-	ProductID *int64
-	FileID    *uuid.UUID
+	ProductID  *int64
+	ProductIDs *[]int64
+	FileID     *uuid.UUID
 }
 
 //go:generate mockery --name=ProductSliderImage --output=../../tests/mocks --case=underscore
 type ProductSliderImage interface {
 	FindSliderImagesForProduct(ctx context.Context, productID int64) (items []*domain.ProductSliderImage, err error)
+	FindSliderImagesForProducts(ctx context.Context, productIDs []int64) (items map[int64][]*domain.ProductSliderImage, err error)
 	SaveActualImagesForProductSlider(ctx context.Context, productID int64, filesIDs []uuid.UUID, markFilesAsAssigned bool) (items []*domain.ProductSliderImage, err error)
 	DeleteImagesForProductSlider(ctx context.Context, productID int64) (err error)
 }
@@ -56,6 +58,19 @@ func (uc *ProductSliderImageInpl) FindSliderImagesForProduct(ctx context.Context
 	}, nil)
 }
 
+func (uc *ProductSliderImageInpl) FindSliderImagesForProducts(ctx context.Context, productIDs []int64) (map[int64][]*domain.ProductSliderImage, error) {
+	list, err := uc.repo.FindList(ctx, ProductSliderImageListOptions{
+		ProductIDs: &productIDs,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.GroupBy(list, func(item *domain.ProductSliderImage) int64 {
+		return item.ProductID
+	}), nil
+}
+
 func (uc *ProductSliderImageInpl) SaveActualImagesForProductSlider(ctx context.Context, productID int64, filesIDs []uuid.UUID, markFilesAsAssigned bool) ([]*domain.ProductSliderImage, error) {
 
 	result := make([]*domain.ProductSliderImage, 0)
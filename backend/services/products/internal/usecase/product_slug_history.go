@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type ProductSlugHistoryListOptions struct {
+	ProductID *int64
+	Slug      *string
+}
+
+//go:generate mockery --name=ProductSlugHistory --output=../../tests/mocks --case=underscore
+type ProductSlugHistory interface {
+	FindProductIDBySlug(ctx context.Context, slug string) (productID int64, isFound bool, err error)
+	Add(ctx context.Context, slug string, productID int64) (err error)
+	DeleteBySlug(ctx context.Context, slug string) (err error)
+}
+
+//go:generate mockery --name=ProductSlugHistoryRepository --output=../../tests/mocks --case=underscore
+type ProductSlugHistoryRepository interface {
+	FindList(ctx context.Context, listOptions ProductSlugHistoryListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductSlugHistory, err error)
+	Create(ctx context.Context, item *domain.ProductSlugHistory) (err error)
+	DeleteByList(ctx context.Context, listOptions ProductSlugHistoryListOptions) (err error)
+}
+
+type ProductSlugHistoryInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductSlugHistoryRepository
+	txManager *manager.Manager
+}
+
+func NewProductSlugHistoryInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductSlugHistoryRepository) *ProductSlugHistoryInpl {
+	uc := &ProductSlugHistoryInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *ProductSlugHistoryInpl) FindProductIDBySlug(ctx context.Context, slug string) (int64, bool, error) {
+	items, err := uc.repo.FindList(ctx, ProductSlugHistoryListOptions{
+		Slug: &slug,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	item, ok := lo.First(items)
+	if !ok {
+		return 0, false, nil
+	}
+
+	return item.ProductID, true, nil
+}
+
+func (uc *ProductSlugHistoryInpl) Add(ctx context.Context, slug string, productID int64) error {
+	return uc.repo.Create(ctx, domain.NewProductSlugHistory(slug, productID))
+}
+
+func (uc *ProductSlugHistoryInpl) DeleteBySlug(ctx context.Context, slug string) error {
+	return uc.repo.DeleteByList(ctx, ProductSlugHistoryListOptions{
+		Slug: &slug,
+	})
+}
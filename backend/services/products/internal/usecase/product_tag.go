@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+type ProductTagListOptions struct {
+	ProductID  *int64
+	ProductIDs *[]int64
+	Tag        *string
+}
+
+//go:generate mockery --name=ProductTag --output=../../tests/mocks --case=underscore
+type ProductTag interface {
+	FindTagsForProduct(ctx context.Context, productID int64) (tags []string, err error)
+	FindTagsForProducts(ctx context.Context, productIDs []int64) (tags map[int64][]string, err error)
+	SaveTagsForProduct(ctx context.Context, productID int64, tags []string) (items []*domain.ProductTag, err error)
+}
+
+//go:generate mockery --name=ProductTagRepository --output=../../tests/mocks --case=underscore
+type ProductTagRepository interface {
+	FindList(ctx context.Context, listOptions ProductTagListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductTag, err error)
+	Create(ctx context.Context, item *domain.ProductTag) (err error)
+	DeleteByList(ctx context.Context, listOptions ProductTagListOptions) (err error)
+}
+
+type ProductTagInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductTagRepository
+	txManager *manager.Manager
+}
+
+func NewProductTagInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductTagRepository) *ProductTagInpl {
+	uc := &ProductTagInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *ProductTagInpl) FindTagsForProduct(ctx context.Context, productID int64) ([]string, error) {
+	items, err := uc.repo.FindList(ctx, ProductTagListOptions{
+		ProductID: &productID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(items, func(item *domain.ProductTag, _ int) string {
+		return item.Tag
+	}), nil
+}
+
+func (uc *ProductTagInpl) FindTagsForProducts(ctx context.Context, productIDs []int64) (map[int64][]string, error) {
+	items, err := uc.repo.FindList(ctx, ProductTagListOptions{
+		ProductIDs: &productIDs,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64][]string, len(productIDs))
+	for _, item := range items {
+		result[item.ProductID] = append(result[item.ProductID], item.Tag)
+	}
+
+	return result, nil
+}
+
+// Заменяет метки товара, метки нормализуются, повторы после нормализации отбрасываются
+func (uc *ProductTagInpl) SaveTagsForProduct(ctx context.Context, productID int64, tags []string) ([]*domain.ProductTag, error) {
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag, err := domain.NormalizeProductTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, tag)
+	}
+
+	result := make([]*domain.ProductTag, 0, len(normalized))
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		err := uc.repo.DeleteByList(ctx, ProductTagListOptions{
+			ProductID: &productID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range lo.Uniq(normalized) {
+			item := domain.NewProductTag(productID, tag)
+
+			err = uc.repo.Create(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type ProductVariantListOptions struct {
+	IDs        *[]int64
+	ProductID  *int64
+	ProductIDs *[]int64
+	SKU        *string
+}
+
+type ProductVariantIn struct {
+	SKU            string
+	Options        map[string]string
+	Price          decimal.Decimal
+	StockAvailable int32
+	Sort           int32
+	// Аккаунт, задавший начальный остаток при создании варианта
+	ActorID *uuid.UUID
+}
+
+//go:generate mockery --name=ProductVariant --output=../../tests/mocks --case=underscore
+type ProductVariant interface {
+	FindList(ctx context.Context, listOptions ProductVariantListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductVariant, err error)
+	FindListByProducts(ctx context.Context, productIDs []int64) (items map[int64][]*domain.ProductVariant, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.ProductVariant, err error)
+	Create(ctx context.Context, item *domain.ProductVariant) (err error)
+	Update(ctx context.Context, item *domain.ProductVariant) (err error)
+	DeleteByIDs(ctx context.Context, ids []int64) (err error)
+	DeleteByProductID(ctx context.Context, productID int64) (err error)
+	RestoreByProductID(ctx context.Context, productID int64, minDeletedAt time.Time) (err error)
+	PurgeByProductID(ctx context.Context, productID int64) (err error)
+}
+
+//go:generate mockery --name=ProductVariantRepository --output=../../tests/mocks --case=underscore
+type ProductVariantRepository interface {
+	FindList(ctx context.Context, listOptions ProductVariantListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.ProductVariant, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.ProductVariant, err error)
+	Create(ctx context.Context, item *domain.ProductVariant) (err error)
+	Update(ctx context.Context, item *domain.ProductVariant) (err error)
+	DeleteByList(ctx context.Context, listOptions ProductVariantListOptions) (err error)
+	RestoreByProductID(ctx context.Context, productID int64, minDeletedAt time.Time) (err error)
+	PurgeByProductID(ctx context.Context, productID int64) (err error)
+}
+
+type ProductVariantInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      ProductVariantRepository
+	txManager *manager.Manager
+}
+
+func NewProductVariantInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo ProductVariantRepository) *ProductVariantInpl {
+	uc := &ProductVariantInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *ProductVariantInpl) FindList(ctx context.Context, listOptions ProductVariantListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.ProductVariant, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *ProductVariantInpl) FindListByProducts(ctx context.Context, productIDs []int64) (map[int64][]*domain.ProductVariant, error) {
+	if len(productIDs) == 0 {
+		return map[int64][]*domain.ProductVariant{}, nil
+	}
+
+	items, err := uc.repo.FindList(ctx, ProductVariantListOptions{
+		ProductIDs: &productIDs,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.GroupBy(items, func(item *domain.ProductVariant) int64 {
+		return item.ProductID
+	}), nil
+}
+
+func (uc *ProductVariantInpl) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.ProductVariant, error) {
+	return uc.repo.FindOneByID(ctx, id, queryParams)
+}
+
+func (uc *ProductVariantInpl) Create(ctx context.Context, item *domain.ProductVariant) error {
+	return uc.repo.Create(ctx, item)
+}
+
+func (uc *ProductVariantInpl) Update(ctx context.Context, item *domain.ProductVariant) error {
+	return uc.repo.Update(ctx, item)
+}
+
+func (uc *ProductVariantInpl) DeleteByIDs(ctx context.Context, ids []int64) error {
+	return uc.repo.DeleteByList(ctx, ProductVariantListOptions{
+		IDs: &ids,
+	})
+}
+
+func (uc *ProductVariantInpl) DeleteByProductID(ctx context.Context, productID int64) error {
+	return uc.repo.DeleteByList(ctx, ProductVariantListOptions{
+		ProductID: &productID,
+	})
+}
+
+// Восстанавливаются только варианты, удаленные вместе с товаром, а не удаленные по отдельности раньше
+func (uc *ProductVariantInpl) RestoreByProductID(ctx context.Context, productID int64, minDeletedAt time.Time) error {
+	return uc.repo.RestoreByProductID(ctx, productID, minDeletedAt)
+}
+
+func (uc *ProductVariantInpl) PurgeByProductID(ctx context.Context, productID int64) error {
+	return uc.repo.PurgeByProductID(ctx, productID)
+}
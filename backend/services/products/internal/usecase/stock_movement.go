@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+)
+
+type StockMovementListOptions struct {
+	ProductID   *int64
+	VariantID   *int64
+	OrderID     *int64
+	WarehouseID *int64
+	Reason      *domain.StockMovementReason
+}
+
+//go:generate mockery --name=StockMovement --output=../../tests/mocks --case=underscore
+type StockMovement interface {
+	FindPagedList(ctx context.Context, listOptions StockMovementListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.StockMovement, total int64, err error)
+	Add(ctx context.Context, item *domain.StockMovement) (err error)
+}
+
+//go:generate mockery --name=StockMovementRepository --output=../../tests/mocks --case=underscore
+type StockMovementRepository interface {
+	FindPagedList(ctx context.Context, listOptions StockMovementListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.StockMovement, total int64, err error)
+	Create(ctx context.Context, item *domain.StockMovement) (err error)
+}
+
+type StockMovementInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      StockMovementRepository
+	txManager *manager.Manager
+}
+
+func NewStockMovementInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo StockMovementRepository) *StockMovementInpl {
+	uc := &StockMovementInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *StockMovementInpl) FindPagedList(ctx context.Context, listOptions StockMovementListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.StockMovement, int64, error) {
+	return uc.repo.FindPagedList(ctx, listOptions, queryParams)
+}
+
+// Запись добавляется в транзакции изменения остатка из контекста
+func (uc *StockMovementInpl) Add(ctx context.Context, item *domain.StockMovement) error {
+	return uc.repo.Create(ctx, item)
+}
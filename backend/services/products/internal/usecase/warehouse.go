@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/e"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+	"github.com/samber/lo"
+)
+
+var ErrWarehouseCodeAlreadyExists = e.NewErrorFrom(e.ErrConflict).SetMessage("warehouse with this code already exists")
+var ErrWarehouseNotEmpty = e.NewErrorFrom(e.ErrBadRequest).SetMessage("warehouse has stock or reserved products")
+var ErrWarehouseInactive = e.NewErrorFrom(e.ErrBadRequest).SetMessage("warehouse is inactive")
+var ErrWarehouseNoActive = e.NewErrorFrom(e.ErrBadRequest).SetMessage("no active warehouses")
+
+// Стратегии выбора склада при бронировании
+const (
+	// Склады по приоритету
+	WarehouseStrategyPriority = "priority"
+	// Сначала склады, обслуживающие зону доставки, от ближнего к дальнему, затем остальные по приоритету
+	WarehouseStrategyClosest = "closest"
+)
+
+type WarehouseListOptions struct {
+	IDs      *[]int64
+	Code     *string
+	IsActive *bool
+}
+
+type WarehouseIn struct {
+	Name          string
+	Code          string
+	Priority      int32
+	DeliveryZones map[string]int32
+	IsActive      bool
+}
+
+//go:generate mockery --name=Warehouse --output=../../tests/mocks --case=underscore
+type Warehouse interface {
+	FindList(ctx context.Context, listOptions WarehouseListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Warehouse, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.Warehouse, err error)
+	FindDefault(ctx context.Context) (item *domain.Warehouse, err error)
+	FindForReservation(ctx context.Context, deliveryZone string) (items []*domain.Warehouse, err error)
+	Create(ctx context.Context, input WarehouseIn) (item *domain.Warehouse, err error)
+	Update(ctx context.Context, id int64, input WarehouseIn) (item *domain.Warehouse, err error)
+}
+
+//go:generate mockery --name=WarehouseRepository --output=../../tests/mocks --case=underscore
+type WarehouseRepository interface {
+	FindList(ctx context.Context, listOptions WarehouseListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.Warehouse, err error)
+	FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (item *domain.Warehouse, err error)
+	Create(ctx context.Context, item *domain.Warehouse) (err error)
+	Update(ctx context.Context, item *domain.Warehouse) (err error)
+}
+
+type WarehouseInpl struct {
+	logger              *slog.Logger
+	config              config.Config
+	repo                WarehouseRepository
+	txManager           *manager.Manager
+	warehouseStockUC    WarehouseStock
+	productOrderBlockUC ProductOrderBlock
+}
+
+func NewWarehouseInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo WarehouseRepository, warehouseStockUC WarehouseStock, productOrderBlockUC ProductOrderBlock) *WarehouseInpl {
+	uc := &WarehouseInpl{
+		logger:              logger,
+		config:              config,
+		txManager:           txManager,
+		repo:                repo,
+		warehouseStockUC:    warehouseStockUC,
+		productOrderBlockUC: productOrderBlockUC,
+	}
+	return uc
+}
+
+func (uc *WarehouseInpl) FindList(ctx context.Context, listOptions WarehouseListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.Warehouse, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+func (uc *WarehouseInpl) FindOneByID(ctx context.Context, id int64, queryParams *uctypes.QueryGetOneParams) (*domain.Warehouse, error) {
+	return uc.repo.FindOneByID(ctx, id, queryParams)
+}
+
+// Склад по умолчанию - активный склад с наивысшим приоритетом.
+// На него поступает товар, когда склад не указан явно
+func (uc *WarehouseInpl) FindDefault(ctx context.Context) (*domain.Warehouse, error) {
+	items, err := uc.repo.FindList(ctx, WarehouseListOptions{
+		IsActive: lo.ToPtr(true),
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, ErrWarehouseNoActive
+	}
+
+	return items[0], nil
+}
+
+// Активные склады в порядке, в котором с них бронируется товар
+func (uc *WarehouseInpl) FindForReservation(ctx context.Context, deliveryZone string) ([]*domain.Warehouse, error) {
+	items, err := uc.repo.FindList(ctx, WarehouseListOptions{
+		IsActive: lo.ToPtr(true),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, ErrWarehouseNoActive
+	}
+
+	// Неизвестная стратегия работает как выбор по приоритету
+	if uc.config.Warehouse.Strategy != WarehouseStrategyClosest || deliveryZone == "" {
+		return items, nil
+	}
+
+	// Склады уже отсортированы по приоритету, стабильная сортировка сохраняет его при равном расстоянии
+	sort.SliceStable(items, func(i, j int) bool {
+		distanceI, okI := items[i].DistanceTo(deliveryZone)
+		distanceJ, okJ := items[j].DistanceTo(deliveryZone)
+
+		if okI != okJ {
+			return okI
+		}
+
+		return okI && distanceI < distanceJ
+	})
+
+	return items, nil
+}
+
+func (uc *WarehouseInpl) Create(ctx context.Context, input WarehouseIn) (*domain.Warehouse, error) {
+	warehouse := domain.NewWarehouse(0)
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		err := uc.setData(ctx, warehouse, input)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Create(ctx, warehouse)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (uc *WarehouseInpl) Update(ctx context.Context, id int64, input WarehouseIn) (*domain.Warehouse, error) {
+	var warehouse *domain.Warehouse
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		warehouse, err = uc.repo.FindOneByID(ctx, id, &uctypes.QueryGetOneParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Отключить можно только пустой склад, иначе его остаток был бы виден на витрине, но не бронировался
+		if warehouse.IsActive && !input.IsActive {
+			err = uc.checkIsEmpty(ctx, warehouse.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = uc.setData(ctx, warehouse, input)
+		if err != nil {
+			return err
+		}
+
+		return uc.repo.Update(ctx, warehouse)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (uc *WarehouseInpl) setData(ctx context.Context, warehouse *domain.Warehouse, input WarehouseIn) error {
+	err := warehouse.SetCode(input.Code)
+	if err != nil {
+		return err
+	}
+
+	err = warehouse.SetDeliveryZones(input.DeliveryZones)
+	if err != nil {
+		return err
+	}
+
+	items, err := uc.repo.FindList(ctx, WarehouseListOptions{
+		Code: &input.Code,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.ID != warehouse.ID {
+			return ErrWarehouseCodeAlreadyExists
+		}
+	}
+
+	warehouse.Name = input.Name
+	warehouse.Priority = input.Priority
+	warehouse.IsActive = input.IsActive
+
+	return nil
+}
+
+func (uc *WarehouseInpl) checkIsEmpty(ctx context.Context, warehouseID int64) error {
+	stocks, err := uc.warehouseStockUC.FindList(ctx, WarehouseStockListOptions{
+		WarehouseID:  &warehouseID,
+		OnlyPositive: true,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(stocks) > 0 {
+		return ErrWarehouseNotEmpty
+	}
+
+	blocks, err := uc.productOrderBlockUC.FindList(ctx, ProductOrderBlockListOptions{
+		WarehouseID: &warehouseID,
+	}, &uctypes.QueryGetListParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(blocks) > 0 {
+		return ErrWarehouseNotEmpty
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/domain"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/infra/config"
+	"github.com/m11ano/mipt-webdev-course/backend/services/products/internal/usecase/uctypes"
+)
+
+type WarehouseStockListOptions struct {
+	WarehouseID  *int64
+	ProductID    *int64
+	ProductIDs   *[]int64
+	VariantID    *int64
+	OnlyPositive bool
+}
+
+//go:generate mockery --name=WarehouseStock --output=../../tests/mocks --case=underscore
+type WarehouseStock interface {
+	FindList(ctx context.Context, listOptions WarehouseStockListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.WarehouseStock, err error)
+	ChangeStock(ctx context.Context, warehouseID int64, productID int64, variantID int64, delta int64) (item *domain.WarehouseStock, err error)
+}
+
+//go:generate mockery --name=WarehouseStockRepository --output=../../tests/mocks --case=underscore
+type WarehouseStockRepository interface {
+	FindList(ctx context.Context, listOptions WarehouseStockListOptions, queryParams *uctypes.QueryGetListParams) (items []*domain.WarehouseStock, err error)
+	Create(ctx context.Context, item *domain.WarehouseStock) (err error)
+	Update(ctx context.Context, item *domain.WarehouseStock) (err error)
+}
+
+type WarehouseStockInpl struct {
+	logger    *slog.Logger
+	config    config.Config
+	repo      WarehouseStockRepository
+	txManager *manager.Manager
+}
+
+func NewWarehouseStockInpl(logger *slog.Logger, config config.Config, txManager *manager.Manager, repo WarehouseStockRepository) *WarehouseStockInpl {
+	uc := &WarehouseStockInpl{
+		logger:    logger,
+		config:    config,
+		txManager: txManager,
+		repo:      repo,
+	}
+	return uc
+}
+
+func (uc *WarehouseStockInpl) FindList(ctx context.Context, listOptions WarehouseStockListOptions, queryParams *uctypes.QueryGetListParams) ([]*domain.WarehouseStock, error) {
+	return uc.repo.FindList(ctx, listOptions, queryParams)
+}
+
+// Меняет остаток позиции на складе, запись остатка создается при первом поступлении.
+// Вызывается при заблокированном товаре или варианте, поэтому параллельного создания записи не бывает
+func (uc *WarehouseStockInpl) ChangeStock(ctx context.Context, warehouseID int64, productID int64, variantID int64, delta int64) (*domain.WarehouseStock, error) {
+	var stock *domain.WarehouseStock
+
+	err := uc.txManager.Do(ctx, func(ctx context.Context) error {
+		items, err := uc.repo.FindList(ctx, WarehouseStockListOptions{
+			WarehouseID: &warehouseID,
+			ProductID:   &productID,
+			VariantID:   &variantID,
+		}, &uctypes.QueryGetListParams{
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		isNew := len(items) == 0
+		if isNew {
+			stock = domain.NewWarehouseStock(warehouseID, productID, variantID)
+		} else {
+			stock = items[0]
+		}
+
+		if delta >= 0 {
+			err = stock.IncreaseStock(delta)
+		} else {
+			err = stock.DecreaseStock(-delta)
+		}
+		if err != nil {
+			return err
+		}
+
+		if isNew {
+			return uc.repo.Create(ctx, stock)
+		}
+
+		return uc.repo.Update(ctx, stock)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- Таблица product_return_restock: товары, возвращенные на склад по возвратам заказов
+CREATE TABLE product_return_restock (
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE RESTRICT,
+    return_id  BIGINT NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity >= 1),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, return_id)
+);
+CREATE INDEX idx_product_return_restock_return_id ON product_return_restock(return_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_return_restock_return_id;
+DROP TABLE IF EXISTS product_return_restock;
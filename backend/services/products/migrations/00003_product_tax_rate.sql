@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Ставка НДС товара в процентах, NULL - используется ставка по умолчанию
+ALTER TABLE product ADD COLUMN tax_rate NUMERIC(5, 2) NULL CHECK (tax_rate >= 0 AND tax_rate <= 100);
+
+-- +goose Down
+
+ALTER TABLE product DROP COLUMN IF EXISTS tax_rate;
@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- Артикул товара, пустая строка - артикул не задан
+ALTER TABLE product ADD COLUMN sku VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_product_sku ON product(sku) WHERE sku <> '' AND deleted_at IS NULL;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_sku;
+
+ALTER TABLE product DROP COLUMN IF EXISTS sku;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Версия товара для оптимистической блокировки при редактировании
+ALTER TABLE product ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+
+ALTER TABLE product DROP COLUMN IF EXISTS version;
@@ -0,0 +1,40 @@
+-- +goose Up
+
+-- Категории каталога
+CREATE TABLE category (
+    id              BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    parent_id       BIGINT NULL REFERENCES category(id) ON DELETE RESTRICT,
+    name            VARCHAR(150) NOT NULL,
+    slug            VARCHAR(150) NOT NULL,
+    sort            INTEGER NOT NULL DEFAULT 0,
+    is_published    BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX idx_category_slug ON category(slug);
+CREATE INDEX idx_category_parent_id ON category(parent_id);
+CREATE TRIGGER trigger_set_updated_at_on_category
+BEFORE UPDATE ON category
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Привязка товаров к категориям
+CREATE TABLE product_category (
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    category_id     BIGINT NOT NULL REFERENCES category(id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, category_id)
+);
+CREATE INDEX idx_product_category_category_id ON product_category(category_id);
+
+-- +goose Down
+
+-- Удаление привязки товаров к категориям
+DROP INDEX IF EXISTS idx_product_category_category_id;
+DROP TABLE IF EXISTS product_category;
+
+-- Удаление категорий
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_category ON category;
+DROP INDEX IF EXISTS idx_category_parent_id;
+DROP INDEX IF EXISTS idx_category_slug;
+DROP TABLE IF EXISTS category;
@@ -0,0 +1,45 @@
+-- +goose Up
+
+-- Варианты товара со своим артикулом, ценой и остатком
+CREATE TABLE product_variant (
+    id              BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE RESTRICT,
+    sku             VARCHAR(64) NOT NULL DEFAULT '',
+    options         JSONB NOT NULL DEFAULT '{}',
+    price           NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    stock_available INTEGER NOT NULL DEFAULT 0 CHECK (stock_available >= 0),
+    sort            INTEGER NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL,
+    deleted_at      TIMESTAMPTZ NULL
+);
+CREATE INDEX idx_product_variant_product_id ON product_variant(product_id);
+CREATE UNIQUE INDEX idx_product_variant_sku ON product_variant(sku) WHERE sku <> '' AND deleted_at IS NULL;
+CREATE TRIGGER trigger_set_updated_at_on_product_variant
+BEFORE UPDATE ON product_variant
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Бронь и возврат на склад учитывают вариант, 0 - товар без вариантов
+ALTER TABLE product_order_block ADD COLUMN variant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE product_order_block DROP CONSTRAINT product_order_block_pkey;
+ALTER TABLE product_order_block ADD PRIMARY KEY (product_id, variant_id, order_id);
+
+ALTER TABLE product_return_restock ADD COLUMN variant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE product_return_restock DROP CONSTRAINT product_return_restock_pkey;
+ALTER TABLE product_return_restock ADD PRIMARY KEY (product_id, variant_id, return_id);
+
+-- +goose Down
+
+ALTER TABLE product_return_restock DROP CONSTRAINT product_return_restock_pkey;
+ALTER TABLE product_return_restock DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE product_return_restock ADD PRIMARY KEY (product_id, return_id);
+
+ALTER TABLE product_order_block DROP CONSTRAINT product_order_block_pkey;
+ALTER TABLE product_order_block DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE product_order_block ADD PRIMARY KEY (product_id, order_id);
+
+-- Удаление вариантов
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_product_variant ON product_variant;
+DROP INDEX IF EXISTS idx_product_variant_sku;
+DROP INDEX IF EXISTS idx_product_variant_product_id;
+DROP TABLE IF EXISTS product_variant;
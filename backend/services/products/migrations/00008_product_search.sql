@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Полнотекстовый поиск по названию и описанию товара, название весит больше
+ALTER TABLE product ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(full_description, '')), 'B')
+) STORED;
+CREATE INDEX idx_product_search_vector ON product USING GIN (search_vector);
+
+-- Индексы для фильтра и сортировки каталога
+CREATE INDEX idx_product_price ON product(price);
+CREATE INDEX idx_product_name ON product(name);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_name;
+DROP INDEX IF EXISTS idx_product_price;
+DROP INDEX IF EXISTS idx_product_search_vector;
+ALTER TABLE product DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- ЧПУ товара, существующим товарам назначается технический slug, его можно поменять в админке
+ALTER TABLE product ADD COLUMN slug VARCHAR(150) NOT NULL DEFAULT '';
+UPDATE product SET slug = 'product-' || id;
+CREATE UNIQUE INDEX idx_product_slug ON product(slug) WHERE deleted_at IS NULL;
+
+-- Прежние slug товаров для перенаправления со старых ссылок
+CREATE TABLE product_slug_history (
+    slug            VARCHAR(150) PRIMARY KEY,
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_product_slug_history_product_id ON product_slug_history(product_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_slug_history_product_id;
+DROP TABLE IF EXISTS product_slug_history;
+
+DROP INDEX IF EXISTS idx_product_slug;
+ALTER TABLE product DROP COLUMN IF EXISTS slug;
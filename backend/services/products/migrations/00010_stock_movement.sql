@@ -0,0 +1,38 @@
+-- +goose Up
+
+-- Журнал движения остатков товаров и вариантов, записи только добавляются
+CREATE TABLE stock_movement (
+    id              BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    variant_id      BIGINT NOT NULL DEFAULT 0,
+    delta           INTEGER NOT NULL,
+    quantity        INTEGER NOT NULL CHECK (quantity >= 0),
+    reason          VARCHAR(32) NOT NULL,
+    order_id        BIGINT NULL,
+    return_id       BIGINT NULL,
+    actor_id        UUID NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_stock_movement_product_id ON stock_movement(product_id, variant_id, id);
+CREATE INDEX idx_stock_movement_order_id ON stock_movement(order_id) WHERE order_id IS NOT NULL;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION stock_movement_forbid_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movement is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trigger_forbid_update_on_stock_movement
+BEFORE UPDATE ON stock_movement
+FOR EACH ROW EXECUTE FUNCTION stock_movement_forbid_update();
+
+-- +goose Down
+
+DROP TRIGGER IF EXISTS trigger_forbid_update_on_stock_movement ON stock_movement;
+DROP FUNCTION IF EXISTS stock_movement_forbid_update();
+DROP INDEX IF EXISTS idx_stock_movement_order_id;
+DROP INDEX IF EXISTS idx_stock_movement_product_id;
+DROP TABLE IF EXISTS stock_movement;
@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- Порог низкого остатка товара, NULL - порог по умолчанию из конфигурации
+ALTER TABLE product ADD COLUMN low_stock_threshold INTEGER NULL CHECK (low_stock_threshold >= 0);
+
+-- Уведомления о низком остатке. Запись существует, пока остаток ниже порога, поэтому на одно пересечение порога уходит одно уведомление
+CREATE TABLE low_stock_alert (
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    variant_id      BIGINT NOT NULL DEFAULT 0,
+    name            VARCHAR(255) NOT NULL,
+    sku             VARCHAR(64) NOT NULL DEFAULT '',
+    quantity        INTEGER NOT NULL,
+    threshold       INTEGER NOT NULL,
+    notified_at     TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, variant_id)
+);
+CREATE INDEX idx_low_stock_alert_not_notified ON low_stock_alert(created_at) WHERE notified_at IS NULL;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_low_stock_alert_not_notified;
+DROP TABLE IF EXISTS low_stock_alert;
+
+ALTER TABLE product DROP COLUMN IF EXISTS low_stock_threshold;
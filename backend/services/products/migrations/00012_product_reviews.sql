@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- Отзывы покупателей о товарах, один отзыв на товар в рамках заказа
+CREATE TABLE product_review (
+    id              BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    order_id        BIGINT NOT NULL,
+    author_name     VARCHAR(150) NOT NULL,
+    rating          SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text            TEXT NOT NULL DEFAULT '',
+    status          VARCHAR(16) NOT NULL,
+    moderated_by    UUID NULL,
+    moderated_at    TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX idx_product_review_product_order ON product_review(product_id, order_id);
+CREATE INDEX idx_product_review_status ON product_review(status, id);
+-- Для подсчета рейтинга учитываются только одобренные отзывы
+CREATE INDEX idx_product_review_approved ON product_review(product_id) WHERE status = 'approved';
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_review_approved;
+DROP INDEX IF EXISTS idx_product_review_status;
+DROP INDEX IF EXISTS idx_product_review_product_order;
+DROP TABLE IF EXISTS product_review;
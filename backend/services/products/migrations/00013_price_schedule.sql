@@ -0,0 +1,50 @@
+-- +goose Up
+
+-- Старая цена для отображения зачеркнутой, выставляется на время действия запланированной цены
+ALTER TABLE product ADD COLUMN compare_at_price NUMERIC(10, 2) NULL CHECK (compare_at_price >= 0);
+
+-- Запланированные изменения цены товара. На время действия (starts_at - ends_at) цена товара заменяется,
+-- после окончания возвращается цена, действовавшая до начала
+CREATE TABLE product_price_schedule (
+    id                      BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    product_id              BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    price                   NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    starts_at               TIMESTAMPTZ NOT NULL,
+    ends_at                 TIMESTAMPTZ NULL CHECK (ends_at IS NULL OR ends_at > starts_at),
+    status                  VARCHAR(16) NOT NULL,
+    prev_price              NUMERIC(10, 2) NULL,
+    prev_compare_at_price   NUMERIC(10, 2) NULL,
+    actor_id                UUID NULL,
+    applied_at              TIMESTAMPTZ NULL,
+    finished_at             TIMESTAMPTZ NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_product_price_schedule_product_id ON product_price_schedule(product_id, starts_at);
+CREATE INDEX idx_product_price_schedule_pending ON product_price_schedule(starts_at) WHERE status = 'scheduled';
+CREATE INDEX idx_product_price_schedule_active ON product_price_schedule(ends_at) WHERE status = 'active';
+
+-- История изменения цены товара, записи только добавляются
+CREATE TABLE product_price_history (
+    id                      BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    product_id              BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    old_price               NUMERIC(10, 2) NULL,
+    new_price               NUMERIC(10, 2) NOT NULL,
+    compare_at_price        NUMERIC(10, 2) NULL,
+    reason                  VARCHAR(32) NOT NULL,
+    schedule_id             BIGINT NULL,
+    actor_id                UUID NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_product_price_history_product_id ON product_price_history(product_id, id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_price_history_product_id;
+DROP TABLE IF EXISTS product_price_history;
+
+DROP INDEX IF EXISTS idx_product_price_schedule_active;
+DROP INDEX IF EXISTS idx_product_price_schedule_pending;
+DROP INDEX IF EXISTS idx_product_price_schedule_product_id;
+DROP TABLE IF EXISTS product_price_schedule;
+
+ALTER TABLE product DROP COLUMN IF EXISTS compare_at_price;
@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Период публикации товара, NULL - без ограничения. Учитывается вместе с is_published
+ALTER TABLE product ADD COLUMN publish_at TIMESTAMPTZ NULL;
+ALTER TABLE product ADD COLUMN unpublish_at TIMESTAMPTZ NULL;
+ALTER TABLE product ADD CONSTRAINT product_publish_period_check CHECK (publish_at IS NULL OR unpublish_at IS NULL OR unpublish_at > publish_at);
+
+-- Черновик изменений товара, один на товар. Применяется к товару целиком при публикации
+CREATE TABLE product_draft (
+    product_id              BIGINT PRIMARY KEY REFERENCES product(id) ON DELETE CASCADE,
+    name                    VARCHAR(150) NOT NULL,
+    full_description        TEXT NOT NULL DEFAULT '',
+    price                   NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    image_preview_file_id   UUID NULL,
+    slider_files_ids        JSONB NOT NULL DEFAULT '[]',
+    base_version            BIGINT NOT NULL,
+    actor_id                UUID NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at              TIMESTAMPTZ NULL
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS product_draft;
+
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_publish_period_check;
+ALTER TABLE product DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE product DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Срок брони товаров под заказ, NULL - бронь бессрочная
+ALTER TABLE product_order_block ADD COLUMN expires_at TIMESTAMPTZ NULL;
+CREATE INDEX idx_product_order_block_expires_at ON product_order_block(expires_at) WHERE expires_at IS NOT NULL;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_order_block_expires_at;
+ALTER TABLE product_order_block DROP COLUMN IF EXISTS expires_at;
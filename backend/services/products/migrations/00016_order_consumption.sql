@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Таблица product_order_consumption: товары, списанные со склада по выполненным заказам
+CREATE TABLE product_order_consumption (
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE RESTRICT,
+    variant_id BIGINT NOT NULL DEFAULT 0,
+    order_id   BIGINT NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity >= 1),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, variant_id, order_id)
+);
+CREATE INDEX idx_product_order_consumption_order_id ON product_order_consumption(order_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_product_order_consumption_order_id;
+DROP TABLE IF EXISTS product_order_consumption;
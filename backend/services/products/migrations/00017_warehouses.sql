@@ -0,0 +1,88 @@
+-- +goose Up
+
+-- Склады
+CREATE TABLE warehouse (
+    id              BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name            VARCHAR(150) NOT NULL,
+    code            VARCHAR(150) NOT NULL,
+    priority        INTEGER NOT NULL DEFAULT 0,
+    delivery_zones  JSONB NOT NULL DEFAULT '{}',
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX idx_warehouse_code ON warehouse(code);
+CREATE TRIGGER trigger_set_updated_at_on_warehouse
+BEFORE UPDATE ON warehouse
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Остатки товаров и вариантов по складам, остаток товара - сумма по всем складам
+CREATE TABLE warehouse_stock (
+    warehouse_id    BIGINT NOT NULL REFERENCES warehouse(id) ON DELETE RESTRICT,
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    variant_id      BIGINT NOT NULL DEFAULT 0,
+    quantity        INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NULL,
+
+    PRIMARY KEY (warehouse_id, product_id, variant_id)
+);
+CREATE INDEX idx_warehouse_stock_product_id ON warehouse_stock(product_id, variant_id);
+CREATE TRIGGER trigger_set_updated_at_on_warehouse_stock
+BEFORE UPDATE ON warehouse_stock
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Текущие остатки переносятся на основной склад
+INSERT INTO warehouse (name, code) VALUES ('Основной склад', 'main');
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, p.id, 0, p.stock_available
+FROM product p, warehouse w
+WHERE w.code = 'main' AND p.stock_available > 0;
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, v.product_id, v.id, v.stock_available
+FROM product_variant v, warehouse w
+WHERE w.code = 'main' AND v.stock_available > 0;
+
+-- Бронь, списание и возврат на склад учитывают склад
+ALTER TABLE product_order_block ADD COLUMN warehouse_id BIGINT NULL REFERENCES warehouse(id) ON DELETE RESTRICT;
+UPDATE product_order_block SET warehouse_id = (SELECT id FROM warehouse WHERE code = 'main');
+ALTER TABLE product_order_block ALTER COLUMN warehouse_id SET NOT NULL;
+ALTER TABLE product_order_block DROP CONSTRAINT product_order_block_pkey;
+ALTER TABLE product_order_block ADD PRIMARY KEY (product_id, variant_id, order_id, warehouse_id);
+
+ALTER TABLE product_order_consumption ADD COLUMN warehouse_id BIGINT NULL REFERENCES warehouse(id) ON DELETE RESTRICT;
+UPDATE product_order_consumption SET warehouse_id = (SELECT id FROM warehouse WHERE code = 'main');
+ALTER TABLE product_order_consumption ALTER COLUMN warehouse_id SET NOT NULL;
+ALTER TABLE product_order_consumption DROP CONSTRAINT product_order_consumption_pkey;
+ALTER TABLE product_order_consumption ADD PRIMARY KEY (product_id, variant_id, order_id, warehouse_id);
+
+ALTER TABLE product_return_restock ADD COLUMN warehouse_id BIGINT NULL REFERENCES warehouse(id) ON DELETE RESTRICT;
+UPDATE product_return_restock SET warehouse_id = (SELECT id FROM warehouse WHERE code = 'main');
+ALTER TABLE product_return_restock ALTER COLUMN warehouse_id SET NOT NULL;
+
+-- Записи журнала до появления складов остаются без склада
+ALTER TABLE stock_movement ADD COLUMN warehouse_id BIGINT NULL;
+
+-- +goose Down
+
+ALTER TABLE stock_movement DROP COLUMN IF EXISTS warehouse_id;
+
+ALTER TABLE product_return_restock DROP COLUMN IF EXISTS warehouse_id;
+
+ALTER TABLE product_order_consumption DROP CONSTRAINT product_order_consumption_pkey;
+ALTER TABLE product_order_consumption DROP COLUMN IF EXISTS warehouse_id;
+ALTER TABLE product_order_consumption ADD PRIMARY KEY (product_id, variant_id, order_id);
+
+ALTER TABLE product_order_block DROP CONSTRAINT product_order_block_pkey;
+ALTER TABLE product_order_block DROP COLUMN IF EXISTS warehouse_id;
+ALTER TABLE product_order_block ADD PRIMARY KEY (product_id, variant_id, order_id);
+
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_warehouse_stock ON warehouse_stock;
+DROP INDEX IF EXISTS idx_warehouse_stock_product_id;
+DROP TABLE IF EXISTS warehouse_stock;
+
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_warehouse ON warehouse;
+DROP INDEX IF EXISTS idx_warehouse_code;
+DROP TABLE IF EXISTS warehouse;
@@ -0,0 +1,34 @@
+-- +goose Up
+
+-- Сопутствующие товары, подобранные вручную
+CREATE TABLE product_related (
+    product_id          BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    related_product_id  BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    sort                INTEGER NOT NULL DEFAULT 0,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, related_product_id),
+    CHECK (product_id <> related_product_id)
+);
+CREATE INDEX idx_product_related_related_product_id ON product_related(related_product_id);
+
+-- Товары, которые часто покупают вместе, пересчитываются периодической задачей по заказам
+CREATE TABLE product_bought_together (
+    product_id          BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    related_product_id  BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    orders_count        BIGINT NOT NULL DEFAULT 0,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, related_product_id)
+);
+CREATE INDEX idx_product_bought_together_related_product_id ON product_bought_together(related_product_id);
+
+-- +goose Down
+
+-- Удаление часто покупаемых вместе товаров
+DROP INDEX IF EXISTS idx_product_bought_together_related_product_id;
+DROP TABLE IF EXISTS product_bought_together;
+
+-- Удаление сопутствующих товаров
+DROP INDEX IF EXISTS idx_product_related_related_product_id;
+DROP TABLE IF EXISTS product_related;
@@ -0,0 +1,58 @@
+-- +goose Up
+
+-- Произвольные метки товаров
+CREATE TABLE product_tag (
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    tag             VARCHAR(100) NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, tag)
+);
+CREATE INDEX idx_product_tag_tag ON product_tag(tag);
+
+-- Подборки товаров: ручные или по правилам
+CREATE TABLE collection (
+    id                  BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name                VARCHAR(150) NOT NULL,
+    slug                VARCHAR(150) NOT NULL,
+    description         TEXT NOT NULL DEFAULT '',
+    kind                VARCHAR(20) NOT NULL CHECK (kind IN ('manual', 'rule')),
+    rule_tag            VARCHAR(100) NULL,
+    rule_price_from     NUMERIC(10, 2) NULL,
+    rule_price_to       NUMERIC(10, 2) NULL,
+    rule_created_after  TIMESTAMPTZ NULL,
+    sort                INTEGER NOT NULL DEFAULT 0,
+    is_published        BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at          TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX idx_collection_slug ON collection(slug);
+CREATE TRIGGER trigger_set_updated_at_on_collection
+BEFORE UPDATE ON collection
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Товары ручных подборок
+CREATE TABLE collection_product (
+    collection_id   BIGINT NOT NULL REFERENCES collection(id) ON DELETE CASCADE,
+    product_id      BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    sort            INTEGER NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (collection_id, product_id)
+);
+CREATE INDEX idx_collection_product_product_id ON collection_product(product_id);
+
+-- +goose Down
+
+-- Удаление товаров ручных подборок
+DROP INDEX IF EXISTS idx_collection_product_product_id;
+DROP TABLE IF EXISTS collection_product;
+
+-- Удаление подборок
+DROP TRIGGER IF EXISTS trigger_set_updated_at_on_collection ON collection;
+DROP INDEX IF EXISTS idx_collection_slug;
+DROP TABLE IF EXISTS collection;
+
+-- Удаление меток товаров
+DROP INDEX IF EXISTS idx_product_tag_tag;
+DROP TABLE IF EXISTS product_tag;
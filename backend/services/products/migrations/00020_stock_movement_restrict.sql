@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- Журнал движения остатков не удаляется вместе с товаром, товар с записями журнала удалить окончательно нельзя
+ALTER TABLE stock_movement DROP CONSTRAINT IF EXISTS stock_movement_product_id_fkey;
+ALTER TABLE stock_movement ADD CONSTRAINT stock_movement_product_id_fkey
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE RESTRICT;
+
+-- +goose Down
+
+ALTER TABLE stock_movement DROP CONSTRAINT IF EXISTS stock_movement_product_id_fkey;
+ALTER TABLE stock_movement ADD CONSTRAINT stock_movement_product_id_fkey
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE;
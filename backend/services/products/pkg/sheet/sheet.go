@@ -0,0 +1,85 @@
+package sheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown sheet format")
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Формат по имени файла
+func FormatFromFileName(name string) (Format, bool) {
+	switch {
+	case strings.HasSuffix(strings.ToLower(name), ".csv"):
+		return FormatCSV, true
+	case strings.HasSuffix(strings.ToLower(name), ".xlsx"):
+		return FormatXLSX, true
+	}
+
+	return "", false
+}
+
+// Читает таблицу, для xlsx - первый лист книги
+func Read(format Format, data []byte) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(data)
+	case FormatXLSX:
+		return readXLSX(data)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+func Write(format Format, rows [][]string) ([]byte, error) {
+	switch format {
+	case FormatCSV:
+		return writeCSV(rows)
+	case FormatXLSX:
+		return writeXLSX(rows)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// Разделитель определяется по первой строке: Excel с русской локалью сохраняет CSV через точку с запятой
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		r.Comma = ';'
+	}
+
+	return r.ReadAll()
+}
+
+// BOM и точка с запятой нужны, чтобы файл корректно открывался в Excel
+func writeCSV(rows [][]string) ([]byte, error) {
+	buf := bytes.NewBuffer(utf8BOM)
+
+	w := csv.NewWriter(buf)
+	w.Comma = ';'
+
+	err := w.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
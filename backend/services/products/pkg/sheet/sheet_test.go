@@ -0,0 +1,46 @@
+package sheet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWriteRoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"sku", "name", "price"},
+		{"A-1", "Чайник \"Лебедь\"; 1,5 л", "1999.90"},
+		{"A-2", "<b>&</b>", ""},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatXLSX} {
+		data, err := Write(format, rows)
+		require.NoError(t, err)
+
+		res, err := Read(format, data)
+		require.NoError(t, err)
+
+		assert.Equal(t, rows, res, format)
+	}
+}
+
+func TestReadCSVDelimiter(t *testing.T) {
+	res, err := Read(FormatCSV, []byte("\xEF\xBB\xBFsku,name\nA-1,Test\n"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"sku", "name"}, {"A-1", "Test"}}, res)
+
+	res, err = Read(FormatCSV, []byte("sku;name\nA-1;Test, again\n"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"sku", "name"}, {"A-1", "Test, again"}}, res)
+}
+
+func TestXLSXColumns(t *testing.T) {
+	for idx, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, name, xlsxColumnName(idx))
+
+		res, err := xlsxColumnIndex(name + "12")
+		require.NoError(t, err)
+		assert.Equal(t, idx, res)
+	}
+}
@@ -39,6 +39,7 @@ func RegisterWorkers(tClient temporal.TemporalClient, productsActivities *activi
 	productsWorker := productsw.NewWorker(tClient)
 
 	productsWorker.RegisterWorkflow(workflows.SetOrderProductsAndStatus)
+	productsWorker.RegisterWorkflow(workflows.ProcessOrderReturn)
 
 	productsWorker.RegisterActivity(productsActivities)
 
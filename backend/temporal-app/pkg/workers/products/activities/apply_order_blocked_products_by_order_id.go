@@ -0,0 +1,22 @@
+package activities
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/e2temperr"
+)
+
+func (c *Controller) ApplyOrderBlockedProductsByOrderID(ctx context.Context, orderID int64) error {
+
+	err := c.productsGRPC.Client.ApplyOrderBlockByOrderID(ctx, orderID)
+	if err != nil {
+		c.logger.Error("failed to apply order blocked products by order id", slog.Int64("order_id", orderID), slog.Any("error", err.Error()))
+
+		return e2temperr.ErrToTempErr(err)
+	}
+
+	c.logger.Info("apply order blocked products by order id", slog.Int64("order_id", orderID))
+
+	return nil
+}
@@ -11,9 +11,14 @@ import (
 )
 
 type InformOrdersServiceAboutOrderCompositionItem struct {
-	ProductID int64
-	Quantity  int32
-	Price     decimal.Decimal
+	ProductID       int64
+	VariantID       int64
+	Quantity        int32
+	Price           decimal.Decimal
+	TaxRate         decimal.Decimal
+	Name            string
+	SKU             string
+	ImagePreviewURL string
 }
 
 type InformOrdersServiceAboutOrderCompositionIn struct {
@@ -21,22 +26,31 @@ type InformOrdersServiceAboutOrderCompositionIn struct {
 	IsOk          bool
 	OrderProducts *[]InformOrdersServiceAboutOrderCompositionItem
 	OrderStatus   *string
+	OrderVersion  *int64
+	OrderDetails  *orderscl.OrderDetails
 }
 
 func (c *Controller) InformOrdersServiceAboutOrderComposition(ctx context.Context, input InformOrdersServiceAboutOrderCompositionIn) error {
 
 	req := orderscl.SetOrderCompositionIn{
-		OrderID:     input.OrderID,
-		IsOk:        input.IsOk,
-		OrderStatus: input.OrderStatus,
+		OrderID:      input.OrderID,
+		IsOk:         input.IsOk,
+		OrderStatus:  input.OrderStatus,
+		OrderVersion: input.OrderVersion,
+		OrderDetails: input.OrderDetails,
 	}
 
 	if input.OrderProducts != nil {
 		req.OrderProducts = lo.ToPtr(lo.Map(*input.OrderProducts, func(item InformOrdersServiceAboutOrderCompositionItem, _ int) orderscl.OrderCompositionItem {
 			return orderscl.OrderCompositionItem{
-				ProductID: item.ProductID,
-				Quantity:  item.Quantity,
-				Price:     item.Price,
+				ProductID:       item.ProductID,
+				VariantID:       item.VariantID,
+				Quantity:        item.Quantity,
+				Price:           item.Price,
+				TaxRate:         item.TaxRate,
+				Name:            item.Name,
+				SKU:             item.SKU,
+				ImagePreviewURL: item.ImagePreviewURL,
 			}
 		}))
 	}
@@ -0,0 +1,31 @@
+package activities
+
+import (
+	"context"
+	"log/slog"
+
+	orderscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/orders"
+	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/e2temperr"
+)
+
+type InformOrdersServiceAboutReturnIn struct {
+	ReturnID int64
+	IsOk     bool
+}
+
+func (c *Controller) InformOrdersServiceAboutReturn(ctx context.Context, input InformOrdersServiceAboutReturnIn) error {
+
+	err := c.ordersGRPC.Client.SetOrderReturnResult(ctx, orderscl.SetOrderReturnResultIn{
+		ReturnID: input.ReturnID,
+		IsOk:     input.IsOk,
+	})
+	if err != nil {
+		c.logger.Error("failed to inform orders service about return", slog.Any("error", err.Error()))
+
+		return e2temperr.ErrToTempErr(err)
+	}
+
+	c.logger.Info("inform orders service about return", slog.Int64("return_id", input.ReturnID))
+
+	return nil
+}
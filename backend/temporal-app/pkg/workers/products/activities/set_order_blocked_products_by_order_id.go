@@ -11,21 +11,29 @@ import (
 
 type SetOrderBlockedProductsByOrderIDItem struct {
 	ProductID int64
+	VariantID int64
 	Quantity  int32
 }
 
 type SetOrderBlockedProductsByOrderIDIn struct {
 	OrderID       int64
 	OrderProducts []SetOrderBlockedProductsByOrderIDItem
+	// Статус заказа, от которого зависит срок брони
+	OrderStatus string
+	// Зона доставки, по которой выбирается склад
+	DeliveryZone string
 }
 
 func (c *Controller) SetOrderBlockedProductsByOrderID(ctx context.Context, in SetOrderBlockedProductsByOrderIDIn) error {
 
 	err := c.productsGRPC.Client.SetOrderBlockedProductsByOrderID(ctx, productscl.SetOrderBlockedProductsByOrderIDIn{
-		OrderID: in.OrderID,
+		OrderID:      in.OrderID,
+		OrderStatus:  in.OrderStatus,
+		DeliveryZone: in.DeliveryZone,
 		OrderProducts: lo.Map(in.OrderProducts, func(item SetOrderBlockedProductsByOrderIDItem, _ int) productscl.OrderBlockedProduct {
 			return productscl.OrderBlockedProduct{
 				ProductID: item.ProductID,
+				VariantID: item.VariantID,
 				Quantity:  item.Quantity,
 			}
 		}),
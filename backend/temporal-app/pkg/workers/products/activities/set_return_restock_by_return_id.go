@@ -0,0 +1,44 @@
+package activities
+
+import (
+	"context"
+	"log/slog"
+
+	productscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/products"
+	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/e2temperr"
+	"github.com/samber/lo"
+)
+
+type SetReturnRestockByReturnIDItem struct {
+	ProductID int64
+	VariantID int64
+	Quantity  int32
+}
+
+type SetReturnRestockByReturnIDIn struct {
+	ReturnID int64
+	Products []SetReturnRestockByReturnIDItem
+}
+
+func (c *Controller) SetReturnRestockByReturnID(ctx context.Context, in SetReturnRestockByReturnIDIn) error {
+
+	err := c.productsGRPC.Client.SetReturnRestockByReturnID(ctx, productscl.SetReturnRestockByReturnIDIn{
+		ReturnID: in.ReturnID,
+		Products: lo.Map(in.Products, func(item SetReturnRestockByReturnIDItem, _ int) productscl.ReturnRestockProduct {
+			return productscl.ReturnRestockProduct{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Quantity:  item.Quantity,
+			}
+		}),
+	})
+	if err != nil {
+		c.logger.Error("failed to set return restock by return id", slog.Any("error", err.Error()))
+
+		return e2temperr.ErrToTempErr(err)
+	}
+
+	c.logger.Info("set return restock by return id", slog.Int64("return_id", in.ReturnID))
+
+	return nil
+}
@@ -3,24 +3,64 @@ package productstc
 import (
 	"context"
 
+	orderscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/orders"
+	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products/workflows"
 	"github.com/shopspring/decimal"
 )
 
+// Действие с бронью товаров заказа при смене статуса
+type OrderStockAction = workflows.OrderStockAction
+
+const (
+	OrderStockActionNone    = workflows.OrderStockActionNone
+	OrderStockActionRelease = workflows.OrderStockActionRelease
+	OrderStockActionConsume = workflows.OrderStockActionConsume
+)
+
 type SetOrderProductsAndStatusIn struct {
 	NotWait       bool
 	OrderID       int64
 	OrderProducts *[]OrderProductsItem
 	OrderStatus   *string
+	// Действие с бронью по переходу статуса
+	StockAction OrderStockAction
+	// Зона доставки, по которой выбирается склад при бронировании
+	DeliveryZone string
+	// Версия заказа, которую видел клиент, изменение применится только к ней
+	OrderVersion *int64
+	// Данные заказа, сохраняются вместе с составом
+	OrderDetails *OrderDetails
 }
 
+type OrderDetails = orderscl.OrderDetails
+
 type OrderProductsItem struct {
+	ProductID       int64
+	VariantID       int64
+	Quantity        int32
+	Price           decimal.Decimal
+	TaxRate         decimal.Decimal
+	Name            string
+	SKU             string
+	ImagePreviewURL string
+}
+
+type ProcessOrderReturnIn struct {
+	NotWait        bool
+	ReturnID       int64
+	ReturnProducts []ReturnProductsItem
+}
+
+type ReturnProductsItem struct {
 	ProductID int64
+	VariantID int64
 	Quantity  int32
-	Price     decimal.Decimal
 }
 
 type Client interface {
 	SetOrderProductsAndStatus(ctx context.Context, input SetOrderProductsAndStatusIn) error
+	ProcessOrderReturn(ctx context.Context, input ProcessOrderReturnIn) error
 }
 
 var WorkflowOrderProductsPrefx = "order_products"
+var WorkflowOrderReturnPrefx = "order_return"
@@ -0,0 +1,94 @@
+package productstc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/m11ano/e"
+	productsw "github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products"
+	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products/workflows"
+	"github.com/samber/lo"
+	tclient "go.temporal.io/sdk/client"
+)
+
+var ErrProcessOrderReturnCantRestock = e.NewErrorFrom(e.ErrBadRequest).SetMessage("can't restock products")
+var ErrProcessOrderReturnEmptyRequest = e.NewErrorFrom(e.ErrBadRequest).SetMessage("empty request")
+var ErrProcessOrderReturnTimeout = e.NewErrorFrom(e.ErrBadRequest).SetMessage("req timeout")
+
+type ProcessOrderReturnFlowResult struct {
+	we     tclient.WorkflowRun
+	result workflows.ProcessOrderReturnOut
+	err    error
+}
+
+func (c *ClientImpl) ProcessOrderReturn(ctx context.Context, input ProcessOrderReturnIn) error {
+	options := tclient.StartWorkflowOptions{
+		ID:        fmt.Sprintf("%s_%d", WorkflowOrderReturnPrefx, input.ReturnID),
+		TaskQueue: productsw.ProductsQueue,
+	}
+
+	workIn := workflows.ProcessOrderReturnIn{
+		ReturnID: input.ReturnID,
+		ReturnProducts: lo.Map(input.ReturnProducts, func(item ReturnProductsItem, _ int) workflows.ReturnProductsItem {
+			return workflows.ReturnProductsItem{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Quantity:  item.Quantity,
+			}
+		}),
+	}
+
+	execCtx := context.Background()
+
+	startCh := make(chan ProcessOrderReturnFlowResult, 1)
+
+	go func() {
+		we, err := c.client.ExecuteWorkflow(execCtx, options, workflows.ProcessOrderReturn, workIn)
+		if err != nil {
+			startCh <- ProcessOrderReturnFlowResult{err: e.NewErrorFrom(ErrWorkflowCantStart).Wrap(err)}
+			return
+		}
+
+		if input.NotWait {
+			startCh <- ProcessOrderReturnFlowResult{we: we}
+			return
+		}
+
+		var result workflows.ProcessOrderReturnOut
+
+		err = we.Get(execCtx, &result)
+		if err != nil {
+			startCh <- ProcessOrderReturnFlowResult{err: e.NewErrorFrom(ErrWorkflowResutError).Wrap(err)}
+			return
+		}
+
+		startCh <- ProcessOrderReturnFlowResult{we: we, result: result}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return e.NewErrorFrom(ErrProcessOrderReturnTimeout).Wrap(ctx.Err())
+	case res := <-startCh:
+		if res.err != nil {
+			return res.err
+		}
+
+		if input.NotWait {
+			return nil
+		}
+
+		if !res.result.IsOk {
+			if res.result.ErrorCode == 1 {
+				return ErrProcessOrderReturnCantRestock
+			}
+
+			if res.result.ErrorCode == 2 {
+				return ErrProcessOrderReturnEmptyRequest
+			}
+
+			return e.NewErrorFrom(ErrWorkflowResutError)
+		}
+
+		return nil
+	}
+}
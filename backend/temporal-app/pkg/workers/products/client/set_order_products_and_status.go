@@ -8,11 +8,13 @@ import (
 	productsw "github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products"
 	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products/workflows"
 	tclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
 )
 
 var ErrSetOrderProductsAndStatusCantReserve = e.NewErrorFrom(e.ErrBadRequest).SetMessage("can't reserve product")
 var ErrSetOrderProductsAndStatusEmptyRequest = e.NewErrorFrom(e.ErrBadRequest).SetMessage("empty request")
 var ErrSetOrderProductsAndStatusTimeout = e.NewErrorFrom(e.ErrBadRequest).SetMessage("req timeout")
+var ErrSetOrderProductsAndStatusVersionMismatch = e.NewErrorFrom(e.ErrConflict).SetMessage("order was changed concurrently")
 
 type SetOrderProductsAndStatusFlowResult struct {
 	we     tclient.WorkflowRun
@@ -24,19 +26,30 @@ func (c *ClientImpl) SetOrderProductsAndStatus(ctx context.Context, input SetOrd
 	options := tclient.StartWorkflowOptions{
 		ID:        fmt.Sprintf("%s_%d", WorkflowOrderProductsPrefx, input.OrderID),
 		TaskQueue: productsw.ProductsQueue,
+		// Пока идет изменение заказа, параллельное изменение не должно подхватывать чужой результат
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
 	}
 
 	workIn := workflows.SetOrderProductsAndStatusIn{
-		OrderID: input.OrderID,
+		OrderID:      input.OrderID,
+		StockAction:  input.StockAction,
+		DeliveryZone: input.DeliveryZone,
+		OrderVersion: input.OrderVersion,
+		OrderDetails: input.OrderDetails,
 	}
 
 	if input.OrderProducts != nil {
 		workInOrderProducts := make([]workflows.OrderProductsItem, len(*input.OrderProducts))
 		for i, item := range *input.OrderProducts {
 			workInOrderProducts[i] = workflows.OrderProductsItem{
-				ProductID: item.ProductID,
-				Quantity:  item.Quantity,
-				Price:     item.Price,
+				ProductID:       item.ProductID,
+				VariantID:       item.VariantID,
+				Quantity:        item.Quantity,
+				Price:           item.Price,
+				TaxRate:         item.TaxRate,
+				Name:            item.Name,
+				SKU:             item.SKU,
+				ImagePreviewURL: item.ImagePreviewURL,
 			}
 		}
 
@@ -54,6 +67,11 @@ func (c *ClientImpl) SetOrderProductsAndStatus(ctx context.Context, input SetOrd
 	go func() {
 		we, err := c.client.ExecuteWorkflow(execCtx, options, workflows.SetOrderProductsAndStatus, workIn)
 		if err != nil {
+			if temporal.IsWorkflowExecutionAlreadyStartedError(err) {
+				startCh <- SetOrderProductsAndStatusFlowResult{err: e.NewErrorFrom(ErrSetOrderProductsAndStatusVersionMismatch).Wrap(err)}
+				return
+			}
+
 			startCh <- SetOrderProductsAndStatusFlowResult{err: e.NewErrorFrom(ErrWorkflowCantStart).Wrap(err)}
 			return
 		}
@@ -95,6 +113,10 @@ func (c *ClientImpl) SetOrderProductsAndStatus(ctx context.Context, input SetOrd
 				return ErrSetOrderProductsAndStatusEmptyRequest
 			}
 
+			if res.result.ErrorCode == 3 {
+				return ErrSetOrderProductsAndStatusVersionMismatch
+			}
+
 			return e.NewErrorFrom(ErrWorkflowResutError)
 		}
 
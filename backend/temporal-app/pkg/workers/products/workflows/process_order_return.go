@@ -0,0 +1,136 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/e2temperr"
+	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products/activities"
+	"github.com/samber/lo"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+type ReturnProductsItem struct {
+	ProductID int64
+	VariantID int64
+	Quantity  int32
+}
+
+type ProcessOrderReturnIn struct {
+	ReturnID       int64
+	ReturnProducts []ReturnProductsItem
+}
+
+type ProcessOrderReturnOut struct {
+	IsOk      bool
+	ErrorCode int
+}
+
+func ProcessOrderReturn(ctx workflow.Context, input ProcessOrderReturnIn) (*ProcessOrderReturnOut, error) {
+
+	// Лимитированные попытки
+	limTryOpts := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Second * 2,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	onceTryCtx := workflow.WithActivityOptions(ctx, limTryOpts)
+
+	// Бесконечный попытки пока не будет успеха или ответа 4xx
+	unlimTryOpts := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Second * 2,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second * 1,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Second * 30,
+			MaximumAttempts:    0,
+		},
+	}
+	unlimTryCtx := workflow.WithActivityOptions(ctx, unlimTryOpts)
+
+	infBadInput := activities.InformOrdersServiceAboutReturnIn{
+		ReturnID: input.ReturnID,
+		IsOk:     false,
+	}
+
+	// Отмена пополнения остатков по возврату
+	cancelRestockInput := activities.SetReturnRestockByReturnIDIn{
+		ReturnID: input.ReturnID,
+		Products: []activities.SetReturnRestockByReturnIDItem{},
+	}
+
+	if len(input.ReturnProducts) == 0 {
+		// Уведомим микросервис заказов, чтобы возврат не остался в обработке
+		_ = workflow.ExecuteActivity(unlimTryCtx, "InformOrdersServiceAboutReturn", infBadInput).Get(unlimTryCtx, nil)
+
+		return &ProcessOrderReturnOut{
+			IsOk:      false,
+			ErrorCode: 2,
+		}, nil
+	}
+
+	//Возвращаем товары на склад
+	restockInput := activities.SetReturnRestockByReturnIDIn{
+		ReturnID: input.ReturnID,
+		Products: lo.Map(input.ReturnProducts, func(item ReturnProductsItem, _ int) activities.SetReturnRestockByReturnIDItem {
+			return activities.SetReturnRestockByReturnIDItem{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Quantity:  item.Quantity,
+			}
+		}),
+	}
+
+	err := workflow.ExecuteActivity(onceTryCtx, "SetReturnRestockByReturnID", restockInput).Get(onceTryCtx, nil)
+	if err != nil {
+		// Если истек таймаут или 500 - мы не знаем, пополнился остаток или нет, нужно отменять пока не будет успех
+		needToCancel := false
+
+		if ok, lgErr := e2temperr.TempErrConvertToLogicError(err); ok {
+			if lgErr.Code() >= 500 && lgErr.Code() < 600 {
+				needToCancel = true
+			}
+		} else if temporal.IsTimeoutError(err) {
+			needToCancel = true
+		}
+
+		if needToCancel {
+			_ = workflow.ExecuteActivity(unlimTryCtx, "SetReturnRestockByReturnID", cancelRestockInput).Get(unlimTryCtx, nil)
+		}
+
+		// Уведомим микросервис заказов о неуспешном возврате товаров на склад
+		_ = workflow.ExecuteActivity(unlimTryCtx, "InformOrdersServiceAboutReturn", infBadInput).Get(unlimTryCtx, nil)
+
+		return &ProcessOrderReturnOut{
+			IsOk:      false,
+			ErrorCode: 1,
+		}, nil
+	}
+
+	infSuccessInput := activities.InformOrdersServiceAboutReturnIn{
+		ReturnID: input.ReturnID,
+		IsOk:     true,
+	}
+
+	//Уведомим микросервис заказов
+	err = workflow.ExecuteActivity(unlimTryCtx, "InformOrdersServiceAboutReturn", infSuccessInput).Get(unlimTryCtx, nil)
+	if err != nil {
+		//Если ошибка - забираем возвращенные товары со склада назад
+		_ = workflow.ExecuteActivity(unlimTryCtx, "SetReturnRestockByReturnID", cancelRestockInput).Get(unlimTryCtx, nil)
+
+		_ = workflow.ExecuteActivity(unlimTryCtx, "InformOrdersServiceAboutReturn", infBadInput).Get(unlimTryCtx, nil)
+
+		return &ProcessOrderReturnOut{
+			IsOk:      false,
+			ErrorCode: 99,
+		}, nil
+	}
+
+	out := &ProcessOrderReturnOut{
+		IsOk:      true,
+		ErrorCode: 0,
+	}
+
+	return out, nil
+}
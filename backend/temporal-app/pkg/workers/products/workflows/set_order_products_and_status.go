@@ -3,6 +3,7 @@ package workflows
 import (
 	"time"
 
+	orderscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/orders"
 	productscl "github.com/m11ano/mipt-webdev-course/backend/clients/clgrpc/pkg/products"
 	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/e2temperr"
 	"github.com/m11ano/mipt-webdev-course/backend/temporal-app/pkg/workers/products/activities"
@@ -12,16 +13,40 @@ import (
 	"go.temporal.io/sdk/workflow"
 )
 
+// Что происходит с бронью товаров заказа при смене статуса
+type OrderStockAction string
+
+const (
+	// Бронь не меняется
+	OrderStockActionNone OrderStockAction = ""
+	// Бронь возвращается на склад, состав заказа не меняется
+	OrderStockActionRelease OrderStockAction = "release"
+	// Бронь списывается со склада окончательно
+	OrderStockActionConsume OrderStockAction = "consume"
+)
+
 type OrderProductsItem struct {
-	ProductID int64
-	Quantity  int32
-	Price     decimal.Decimal
+	ProductID       int64
+	VariantID       int64
+	Quantity        int32
+	Price           decimal.Decimal
+	TaxRate         decimal.Decimal
+	Name            string
+	SKU             string
+	ImagePreviewURL string
 }
 
 type SetOrderProductsAndStatusIn struct {
 	OrderID       int64
 	OrderProducts *[]OrderProductsItem
 	OrderStatus   *string
+	// Действие с бронью по переходу статуса из таблицы переходов заказа
+	StockAction  OrderStockAction
+	DeliveryZone string
+	// Версия заказа, которую видел клиент: микросервис заказов применит изменение только к ней
+	OrderVersion *int64
+	// Данные заказа, сохраняются вместе с составом
+	OrderDetails *orderscl.OrderDetails
 }
 
 type SetOrderProductsAndStatusOut struct {
@@ -61,9 +86,8 @@ func SetOrderProductsAndStatus(ctx workflow.Context, input SetOrderProductsAndSt
 
 	currentOrderBlockedProducts := []*productscl.OrderBlockedProduct{}
 
-	if input.OrderProducts != nil {
-
-		// Получим текущий список заблокированных товаров у заказа (если есть)
+	// Текущая бронь нужна, чтобы вернуть ее, если микросервис заказов не примет изменение
+	if input.OrderProducts != nil || input.StockAction == OrderStockActionRelease {
 		err := workflow.ExecuteActivity(onceTryCtx, "GetOrderBlockedProductsByOrderID", input.OrderID).Get(onceTryCtx, &currentOrderBlockedProducts)
 		if err != nil {
 			infBadInput := activities.InformOrdersServiceAboutOrderCompositionIn{
@@ -79,19 +103,51 @@ func SetOrderProductsAndStatus(ctx workflow.Context, input SetOrderProductsAndSt
 				ErrorCode: 99,
 			}, nil
 		}
+	}
+
+	// Переход статуса возвращает бронь на склад. Снимаем бронь до сохранения статуса, повторяем до успеха или ответа 4xx
+	if input.StockAction == OrderStockActionRelease && input.OrderProducts == nil {
+		releaseInput := activities.SetOrderBlockedProductsByOrderIDIn{
+			OrderID:       input.OrderID,
+			OrderStatus:   lo.FromPtr(input.OrderStatus),
+			DeliveryZone:  input.DeliveryZone,
+			OrderProducts: []activities.SetOrderBlockedProductsByOrderIDItem{},
+		}
+
+		err := workflow.ExecuteActivity(unlimTryCtx, "SetOrderBlockedProductsByOrderID", releaseInput).Get(unlimTryCtx, nil)
+		if err != nil {
+			infBadInput := activities.InformOrdersServiceAboutOrderCompositionIn{
+				OrderID: input.OrderID,
+				IsOk:    false,
+			}
+
+			// Уведомим микросервис заказов о неуспешном снятии брони
+			_ = workflow.ExecuteActivity(unlimTryCtx, "InformOrdersServiceAboutOrderComposition", infBadInput).Get(unlimTryCtx, nil)
+
+			return &SetOrderProductsAndStatusOut{
+				IsOk:      false,
+				ErrorCode: 1,
+			}, nil
+		}
+	}
+
+	if input.OrderProducts != nil {
 
 		//Установить список товаров для блокировки
 		blockInput := activities.SetOrderBlockedProductsByOrderIDIn{
-			OrderID: input.OrderID,
+			OrderID:      input.OrderID,
+			OrderStatus:  lo.FromPtr(input.OrderStatus),
+			DeliveryZone: input.DeliveryZone,
 			OrderProducts: lo.Map(*input.OrderProducts, func(item OrderProductsItem, _ int) activities.SetOrderBlockedProductsByOrderIDItem {
 				return activities.SetOrderBlockedProductsByOrderIDItem{
 					ProductID: item.ProductID,
+					VariantID: item.VariantID,
 					Quantity:  item.Quantity,
 				}
 			}),
 		}
 
-		err = workflow.ExecuteActivity(onceTryCtx, "SetOrderBlockedProductsByOrderID", blockInput).Get(onceTryCtx, nil)
+		err := workflow.ExecuteActivity(onceTryCtx, "SetOrderBlockedProductsByOrderID", blockInput).Get(onceTryCtx, nil)
 		if err != nil {
 			// Если при блокировке истек таймаут или 500 - мы не знаем заблокировалось или нет, нужно отменять пока не будет успех
 			needToCancel := false
@@ -107,10 +163,12 @@ func SetOrderProductsAndStatus(ctx workflow.Context, input SetOrderProductsAndSt
 			if needToCancel {
 				// Возвращаем блокированные товары назад
 				cancelBlockInput := activities.SetOrderBlockedProductsByOrderIDIn{
-					OrderID: input.OrderID,
+					OrderID:      input.OrderID,
+					DeliveryZone: input.DeliveryZone,
 					OrderProducts: lo.Map(currentOrderBlockedProducts, func(item *productscl.OrderBlockedProduct, _ int) activities.SetOrderBlockedProductsByOrderIDItem {
 						return activities.SetOrderBlockedProductsByOrderIDItem{
 							ProductID: item.ProductID,
+							VariantID: item.VariantID,
 							Quantity:  item.Quantity,
 						}
 					}),
@@ -134,16 +192,23 @@ func SetOrderProductsAndStatus(ctx workflow.Context, input SetOrderProductsAndSt
 	}
 
 	infSuccessInput := activities.InformOrdersServiceAboutOrderCompositionIn{
-		OrderID: input.OrderID,
-		IsOk:    true,
+		OrderID:      input.OrderID,
+		IsOk:         true,
+		OrderVersion: input.OrderVersion,
+		OrderDetails: input.OrderDetails,
 	}
 
 	if input.OrderProducts != nil {
 		infSuccessInput.OrderProducts = lo.ToPtr(lo.Map(*input.OrderProducts, func(item OrderProductsItem, _ int) activities.InformOrdersServiceAboutOrderCompositionItem {
 			return activities.InformOrdersServiceAboutOrderCompositionItem{
-				ProductID: item.ProductID,
-				Quantity:  item.Quantity,
-				Price:     item.Price,
+				ProductID:       item.ProductID,
+				VariantID:       item.VariantID,
+				Quantity:        item.Quantity,
+				Price:           item.Price,
+				TaxRate:         item.TaxRate,
+				Name:            item.Name,
+				SKU:             item.SKU,
+				ImagePreviewURL: item.ImagePreviewURL,
 			}
 		}))
 	}
@@ -155,13 +220,15 @@ func SetOrderProductsAndStatus(ctx workflow.Context, input SetOrderProductsAndSt
 	//Уведомим микросервис заказов
 	err := workflow.ExecuteActivity(unlimTryCtx, "InformOrdersServiceAboutOrderComposition", infSuccessInput).Get(unlimTryCtx, nil)
 	if err != nil {
-		//Если ошибка - возвращаем блокированные товары назад
-		if len(currentOrderBlockedProducts) > 0 {
+		//Если ошибка - возвращаем бронь в состояние до воркфлоу, в том числе снимаем новую бронь, если прежней не было
+		if input.OrderProducts != nil || input.StockAction == OrderStockActionRelease {
 			cancelBlockInput := activities.SetOrderBlockedProductsByOrderIDIn{
-				OrderID: input.OrderID,
+				OrderID:      input.OrderID,
+				DeliveryZone: input.DeliveryZone,
 				OrderProducts: lo.Map(currentOrderBlockedProducts, func(item *productscl.OrderBlockedProduct, _ int) activities.SetOrderBlockedProductsByOrderIDItem {
 					return activities.SetOrderBlockedProductsByOrderIDItem{
 						ProductID: item.ProductID,
+						VariantID: item.VariantID,
 						Quantity:  item.Quantity,
 					}
 				}),
@@ -176,12 +243,31 @@ func SetOrderProductsAndStatus(ctx workflow.Context, input SetOrderProductsAndSt
 
 		_ = workflow.ExecuteActivity(unlimTryCtx, "InformOrdersServiceAboutOrderComposition", infBadInput).Get(unlimTryCtx, nil)
 
+		// Заказ изменили параллельно, изменение не применено
+		if ok, lgErr := e2temperr.TempErrConvertToLogicError(err); ok && lgErr.Code() == 409 {
+			return &SetOrderProductsAndStatusOut{
+				IsOk:      false,
+				ErrorCode: 3,
+			}, nil
+		}
+
 		return &SetOrderProductsAndStatusOut{
 			IsOk:      false,
 			ErrorCode: 99,
 		}, nil
 	}
 
+	// Статус уже сохранен в микросервисе заказов, поэтому списание брони повторяем до успеха или ответа 4xx
+	if input.StockAction == OrderStockActionConsume {
+		err = workflow.ExecuteActivity(unlimTryCtx, "ApplyOrderBlockedProductsByOrderID", input.OrderID).Get(unlimTryCtx, nil)
+		if err != nil {
+			// Заказ выполнен, а товары не списаны: завершаем воркфлоу ошибкой, чтобы сбой был виден в Temporal и не потерялся
+			workflow.GetLogger(ctx).Error("order stock reserve was not consumed", "order_id", input.OrderID, "error", err)
+
+			return nil, temporal.NewNonRetryableApplicationError("order stock reserve was not consumed", "OrderStockNotConsumed", err)
+		}
+	}
+
 	out := &SetOrderProductsAndStatusOut{
 		IsOk:      true,
 		ErrorCode: 0,
@@ -7,23 +7,37 @@ interface Response {
 
 interface Request {
     name: string;
+    slug: string;
+    sku: string;
     is_published: boolean;
+    publish_at: string | null;
+    unpublish_at: string | null;
     full_description: string;
     price: number;
+    tax_rate: number | null;
+    low_stock_threshold: number | null;
     stock_available: number;
     image_preview_file_id: string;
     slider_files_ids: string[];
+    category_ids: number[];
 }
 
 const mapDataToRequest = (data: IProductItem): Request => {
     const reqData: Request = {
         name: data.name,
+        slug: data.slug,
+        sku: data.sku,
         is_published: data.is_published,
+        publish_at: data.publish_at,
+        unpublish_at: data.unpublish_at,
         full_description: data.full_description,
         price: data.price,
+        tax_rate: data.tax_rate,
+        low_stock_threshold: data.low_stock_threshold,
         stock_available: data.stock_available,
         image_preview_file_id: data.image_preview ? data.image_preview.id : '',
         slider_files_ids: data.slider.map((item) => item.id),
+        category_ids: data.category_ids,
     };
 
     return reqData;
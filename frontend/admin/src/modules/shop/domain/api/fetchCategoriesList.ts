@@ -0,0 +1,10 @@
+import type { ICategoryItem } from '~/modules/shop/domain/model/types/category';
+import { tryToCatchApiErrors } from '~/shared/errors/errors';
+
+export const fetchCategoriesList = async () => {
+    try {
+        return await useNuxtApp().$apiFetch<{ items: ICategoryItem[] }>('/products/categories');
+    } catch (e: unknown) {
+        throw tryToCatchApiErrors(e);
+    }
+};
@@ -1,14 +1,20 @@
 import { tryToCatchApiErrors } from '~/shared/errors/errors';
+import { versionFromETag, versionToIfMatch } from '~/shared/helpers/functions';
 import type { OrderStatus } from '../model/types/order';
 
-export async function setOrderStatus(id: number, status: OrderStatus) {
+export async function setOrderStatus(id: number, status: OrderStatus, version: number) {
     try {
-        return await useNuxtApp().$apiFetch<Response>(`/orders/${id}/status`, {
+        const response = await useNuxtApp().$apiFetch.raw(`/orders/${id}/status`, {
             method: 'PUT',
+            headers: {
+                'If-Match': versionToIfMatch(version),
+            },
             body: {
                 status,
             },
         });
+
+        return versionFromETag(response.headers.get('ETag'));
     } catch (e: unknown) {
         throw tryToCatchApiErrors(e);
     }
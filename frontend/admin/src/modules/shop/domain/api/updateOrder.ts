@@ -1,4 +1,5 @@
 import { tryToCatchApiErrors } from '~/shared/errors/errors';
+import { versionFromETag, versionToIfMatch } from '~/shared/helpers/functions';
 import type { IOrderItem } from '../model/types/order';
 
 interface Request {
@@ -11,6 +12,7 @@ interface Request {
     };
     products: {
         id: number;
+        variant_id: number;
         quantity: number;
         price: number;
     }[];
@@ -27,6 +29,7 @@ const mapDataToRequest = (data: IOrderItem): Request => {
         },
         products: data.products.map((item) => ({
             id: item.id,
+            variant_id: item.variant_id ?? 0,
             quantity: item.quantity,
             price: item.price,
         })),
@@ -37,10 +40,15 @@ const mapDataToRequest = (data: IOrderItem): Request => {
 
 export async function updateOrder(data: IOrderItem) {
     try {
-        await useNuxtApp().$apiFetch(`/orders/${data.id}`, {
+        const response = await useNuxtApp().$apiFetch.raw(`/orders/${data.id}`, {
             method: 'PUT',
+            headers: {
+                'If-Match': versionToIfMatch(data.version),
+            },
             body: mapDataToRequest(data),
         });
+
+        return versionFromETag(response.headers.get('ETag'));
     } catch (e: unknown) {
         throw tryToCatchApiErrors(e);
     }
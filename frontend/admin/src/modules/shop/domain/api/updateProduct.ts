@@ -1,23 +1,38 @@
 import { tryToCatchApiErrors } from '~/shared/errors/errors';
+import { versionFromETag, versionToIfMatch } from '~/shared/helpers/functions';
 import type { IProductItem } from '../model/types/product';
 
 interface Request {
     name: string;
+    slug: string;
+    sku: string;
     is_published: boolean;
+    publish_at: string | null;
+    unpublish_at: string | null;
     full_description: string;
     price: number;
+    tax_rate: number | null;
+    low_stock_threshold: number | null;
     image_preview_file_id: string;
     slider_files_ids: string[];
+    category_ids: number[];
 }
 
 const mapDataToRequest = (data: IProductItem): Request => {
     const reqData: Request = {
         name: data.name,
+        slug: data.slug,
+        sku: data.sku,
         is_published: data.is_published,
+        publish_at: data.publish_at,
+        unpublish_at: data.unpublish_at,
         full_description: data.full_description,
         price: data.price,
+        tax_rate: data.tax_rate,
+        low_stock_threshold: data.low_stock_threshold,
         image_preview_file_id: data.image_preview ? data.image_preview.id : '',
         slider_files_ids: data.slider.map((item) => item.id),
+        category_ids: data.category_ids,
     };
 
     return reqData;
@@ -25,10 +40,15 @@ const mapDataToRequest = (data: IProductItem): Request => {
 
 export async function updateProduct(data: IProductItem) {
     try {
-        await useNuxtApp().$apiFetch(`/products/${data.id}`, {
+        const response = await useNuxtApp().$apiFetch.raw(`/products/${data.id}`, {
             method: 'PUT',
+            headers: {
+                'If-Match': versionToIfMatch(data.version),
+            },
             body: mapDataToRequest(data),
         });
+
+        return versionFromETag(response.headers.get('ETag'));
     } catch (e: unknown) {
         throw tryToCatchApiErrors(e);
     }
@@ -0,0 +1,8 @@
+export interface ICategoryItem {
+    id: number;
+    parent_id: number | null;
+    name: string;
+    slug: string;
+    sort: number;
+    is_published: boolean;
+}
@@ -1,13 +1,20 @@
 export enum OrderStatus {
     New = 'new',
     Created = 'created',
+    Paid = 'paid',
     InWork = 'in_work',
+    Shipped = 'shipped',
+    Delivered = 'delivered',
     Finished = 'finished',
+    Returned = 'returned',
     Canceled = 'canceled',
 }
 
 type OrderStatusParams = {
     title: string;
+    action: string;
+    confirm: string;
+    isEditable?: boolean;
     variant?: 'solid' | 'outline' | 'soft' | 'subtle';
     color?: 'primary' | 'secondary' | 'graylight' | 'info' | 'success' | 'warning' | 'error' | 'neutral';
 };
@@ -15,26 +22,68 @@ type OrderStatusParams = {
 export const OrderStatusParams: Record<OrderStatus, OrderStatusParams> = {
     [OrderStatus.New]: {
         title: 'Новый',
+        action: '',
+        confirm: '',
+        isEditable: true,
         variant: 'subtle',
         color: 'success',
     },
     [OrderStatus.Created]: {
         title: 'Создан',
+        action: '',
+        confirm: '',
+        isEditable: true,
+        variant: 'subtle',
+        color: 'success',
+    },
+    [OrderStatus.Paid]: {
+        title: 'Оплачен',
+        action: 'Отметить оплату',
+        confirm: 'Вы действительно хотите отметить заказ оплаченным?',
+        isEditable: true,
         variant: 'subtle',
         color: 'success',
     },
     [OrderStatus.InWork]: {
         title: 'В работе',
+        action: 'Взять в работу',
+        confirm: 'Вы действительно хотите взять заказ в работу?',
+        isEditable: true,
+        variant: 'subtle',
+        color: 'info',
+    },
+    [OrderStatus.Shipped]: {
+        title: 'Отправлен',
+        action: 'Отправить',
+        confirm: 'Вы действительно хотите отметить заказ отправленным?',
+        variant: 'subtle',
+        color: 'info',
+    },
+    [OrderStatus.Delivered]: {
+        title: 'Доставлен',
+        action: 'Доставлен',
+        confirm: 'Вы действительно хотите отметить заказ доставленным?',
         variant: 'subtle',
         color: 'info',
     },
     [OrderStatus.Finished]: {
         title: 'Выполнен',
+        action: 'Завершить',
+        confirm: 'Вы действительно хотите завершить заказ?',
         variant: 'subtle',
         color: 'neutral',
     },
+    [OrderStatus.Returned]: {
+        title: 'Возвращен',
+        action: 'Возврат',
+        confirm: 'Вы действительно хотите отметить заказ возвращенным?',
+        variant: 'subtle',
+        color: 'warning',
+    },
     [OrderStatus.Canceled]: {
         title: 'Отменен',
+        action: 'Отменить',
+        confirm: 'Вы действительно хотите отменить заказ?',
         variant: 'subtle',
         color: 'graylight',
     },
@@ -52,6 +101,7 @@ export interface IOrderListItem {
     order_sum: number;
     secret_key: string;
     status: OrderStatus;
+    next_statuses: OrderStatus[];
 }
 
 export interface IOrderItem {
@@ -59,6 +109,7 @@ export interface IOrderItem {
     secret_key: string;
     order_sum: number;
     status: OrderStatus;
+    version: number;
     details: {
         client_name: string;
         client_surname: string;
@@ -68,7 +119,12 @@ export interface IOrderItem {
     };
     products: {
         id: number;
+        variant_id?: number;
         quantity: number;
         price: number;
+        name?: string;
+        sku?: string;
+        image_preview?: string;
     }[];
+    next_statuses?: OrderStatus[];
 }
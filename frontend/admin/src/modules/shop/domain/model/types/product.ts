@@ -5,6 +5,8 @@ export interface IProductListItem {
     image_preview: string;
     is_published: boolean;
     name: string;
+    slug: string;
+    sku: string;
     price: number;
     stock_available: number;
 }
@@ -12,10 +14,18 @@ export interface IProductListItem {
 export interface IProductItem {
     id: number;
     name: string;
+    slug: string;
+    sku: string;
     is_published: boolean;
+    publish_at: string | null;
+    unpublish_at: string | null;
     full_description: string;
     price: number;
+    tax_rate: number | null;
     stock_available: number;
+    low_stock_threshold: number | null;
+    version: number;
+    category_ids: number[];
     image_preview: {
         id: string;
         url: string;
@@ -14,6 +14,8 @@ export function tryToCatchApiErrors(e: unknown): any {
     if (e instanceof FetchError && e.statusCode) {
         if (e.statusCode === 429) {
             return new StandartErrorList(['Вы отправляете слишком много запросов, попробуйте повторить позже'], e.statusCode);
+        } else if (e.statusCode === 412) {
+            return new StandartErrorList(['Данные были изменены другим пользователем, обновите страницу'], e.statusCode);
         } else if (e.statusCode >= 400 && e.statusCode < 500 && e.data && typeof e.data.details == 'object' && e.data.details !== null) {
             return new StandartErrorList(e.data.details, e.statusCode);
         } else if (e.statusCode >= 400 && e.statusCode < 500 && e.data && typeof e.data.error == 'string') {
@@ -104,3 +104,16 @@ export function declOfNum(number: number, titles: [string, string, string]): str
     const cases = [2, 0, 1, 1, 1, 2];
     return titles[number % 100 > 4 && number % 100 < 20 ? 2 : cases[Math.min(number % 10, 5)]];
 }
+
+// Версия сущности передается в заголовках ETag / If-Match в виде "123"
+export function versionToIfMatch(version: number): string {
+    return `"${version}"`;
+}
+
+export function versionFromETag(etag: string | null): number | null {
+    if (!etag) return null;
+
+    const version = parseInt(etag.replace(/^W\//, '').replace(/"/g, ''), 10);
+
+    return isNaN(version) ? null : version;
+}
@@ -1,16 +1,24 @@
 enum OrderStatus {
     New = 'new',
     Created = 'created',
+    Paid = 'paid',
     InWork = 'in_work',
+    Shipped = 'shipped',
+    Delivered = 'delivered',
     Finished = 'finished',
+    Returned = 'returned',
     Canceled = 'canceled',
 }
 
 export const OrderStatusText = {
     [OrderStatus.New]: 'Новый',
     [OrderStatus.Created]: 'Создан',
+    [OrderStatus.Paid]: 'Оплачен',
     [OrderStatus.InWork]: 'В работе',
+    [OrderStatus.Shipped]: 'Отправлен',
+    [OrderStatus.Delivered]: 'Доставлен',
     [OrderStatus.Finished]: 'Выполнен',
+    [OrderStatus.Returned]: 'Возвращен',
     [OrderStatus.Canceled]: 'Отменен',
 };
 
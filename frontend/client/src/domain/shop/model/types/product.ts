@@ -3,6 +3,7 @@ export interface IProductListItem {
     name: string;
     is_published: boolean;
     price: number;
+    compare_at_price?: number | null;
     stock_available: number;
     image_preview: string;
 }
@@ -13,6 +14,7 @@ export interface IProductItem {
     is_published: boolean;
     full_description: string;
     price: number;
+    compare_at_price?: number | null;
     stock_available: number;
     image_preview: {
         id: string;